@@ -0,0 +1,2049 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+//
+// Copyright (C) 2018-2025 SCANOSS.COM
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 2 of the License, or
+// (at your option) any later version.
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//**
+// Semgrep service extensions not (yet) published in the SCANOSS papi definitions.
+// The messages are self-contained so they can be generated without the papi sources.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.27.0
+// source: api/semgrepextv2/scanoss-semgrep-ext.proto
+
+package semgrepextv2
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Response status codes (mirrors scanoss.api.common.v2.StatusCode).
+type StatusCode int32
+
+const (
+	StatusCode_UNSPECIFIED             StatusCode = 0
+	StatusCode_SUCCESS                 StatusCode = 1
+	StatusCode_SUCCEEDED_WITH_WARNINGS StatusCode = 2
+	StatusCode_WARNING                 StatusCode = 3
+	StatusCode_FAILED                  StatusCode = 4
+)
+
+// Enum value maps for StatusCode.
+var (
+	StatusCode_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "SUCCESS",
+		2: "SUCCEEDED_WITH_WARNINGS",
+		3: "WARNING",
+		4: "FAILED",
+	}
+	StatusCode_value = map[string]int32{
+		"UNSPECIFIED":             0,
+		"SUCCESS":                 1,
+		"SUCCEEDED_WITH_WARNINGS": 2,
+		"WARNING":                 3,
+		"FAILED":                  4,
+	}
+)
+
+func (x StatusCode) Enum() *StatusCode {
+	p := new(StatusCode)
+	*p = x
+	return p
+}
+
+func (x StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_enumTypes[0].Descriptor()
+}
+
+func (StatusCode) Type() protoreflect.EnumType {
+	return &file_api_semgrepextv2_scanoss_semgrep_ext_proto_enumTypes[0]
+}
+
+func (x StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatusCode.Descriptor instead.
+func (StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{0}
+}
+
+// Response status (mirrors scanoss.api.common.v2.StatusResponse).
+type StatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        StatusCode             `protobuf:"varint,1,opt,name=status,proto3,enum=scanoss.api.semgrepext.v2.StatusCode" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusResponse) Reset() {
+	*x = StatusResponse{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse) ProtoMessage() {}
+
+func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StatusResponse) GetStatus() StatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return StatusCode_UNSPECIFIED
+}
+
+func (x *StatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// A triage decision on a Semgrep finding (identified by file MD5, rule ID and optional line range).
+type TriageDecision struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Project the decision applies to (empty for a global decision)
+	Project string `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	FileMd5 string `protobuf:"bytes,3,opt,name=file_md5,json=fileMd5,proto3" json:"file_md5,omitempty"`
+	RuleId  string `protobuf:"bytes,4,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// Optional start/end lines (empty matches the rule anywhere in the file)
+	From string `protobuf:"bytes,5,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,6,opt,name=to,proto3" json:"to,omitempty"`
+	// false_positive, accepted_risk or confirmed
+	State   string `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
+	Author  string `protobuf:"bytes,8,opt,name=author,proto3" json:"author,omitempty"`
+	Comment string `protobuf:"bytes,9,opt,name=comment,proto3" json:"comment,omitempty"`
+	// RFC 3339 timestamps
+	CreatedAt     string `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriageDecision) Reset() {
+	*x = TriageDecision{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriageDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriageDecision) ProtoMessage() {}
+
+func (x *TriageDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriageDecision.ProtoReflect.Descriptor instead.
+func (*TriageDecision) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TriageDecision) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TriageDecision) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *TriageDecision) GetFileMd5() string {
+	if x != nil {
+		return x.FileMd5
+	}
+	return ""
+}
+
+func (x *TriageDecision) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *TriageDecision) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *TriageDecision) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *TriageDecision) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *TriageDecision) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *TriageDecision) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *TriageDecision) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *TriageDecision) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type TriageDecisionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Decision      *TriageDecision        `protobuf:"bytes,1,opt,name=decision,proto3" json:"decision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriageDecisionRequest) Reset() {
+	*x = TriageDecisionRequest{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriageDecisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriageDecisionRequest) ProtoMessage() {}
+
+func (x *TriageDecisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriageDecisionRequest.ProtoReflect.Descriptor instead.
+func (*TriageDecisionRequest) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TriageDecisionRequest) GetDecision() *TriageDecision {
+	if x != nil {
+		return x.Decision
+	}
+	return nil
+}
+
+type TriageDecisionIdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriageDecisionIdRequest) Reset() {
+	*x = TriageDecisionIdRequest{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriageDecisionIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriageDecisionIdRequest) ProtoMessage() {}
+
+func (x *TriageDecisionIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriageDecisionIdRequest.ProtoReflect.Descriptor instead.
+func (*TriageDecisionIdRequest) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TriageDecisionIdRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type TriageDecisionListRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional filters. Use global_only to restrict the list to global decisions
+	Project       string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	GlobalOnly    bool   `protobuf:"varint,2,opt,name=global_only,json=globalOnly,proto3" json:"global_only,omitempty"`
+	FileMd5       string `protobuf:"bytes,3,opt,name=file_md5,json=fileMd5,proto3" json:"file_md5,omitempty"`
+	RuleId        string `protobuf:"bytes,4,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	State         string `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriageDecisionListRequest) Reset() {
+	*x = TriageDecisionListRequest{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriageDecisionListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriageDecisionListRequest) ProtoMessage() {}
+
+func (x *TriageDecisionListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriageDecisionListRequest.ProtoReflect.Descriptor instead.
+func (*TriageDecisionListRequest) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TriageDecisionListRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *TriageDecisionListRequest) GetGlobalOnly() bool {
+	if x != nil {
+		return x.GlobalOnly
+	}
+	return false
+}
+
+func (x *TriageDecisionListRequest) GetFileMd5() string {
+	if x != nil {
+		return x.FileMd5
+	}
+	return ""
+}
+
+func (x *TriageDecisionListRequest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *TriageDecisionListRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type TriageDecisionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Decision      *TriageDecision        `protobuf:"bytes,1,opt,name=decision,proto3" json:"decision,omitempty"`
+	Status        *StatusResponse        `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriageDecisionResponse) Reset() {
+	*x = TriageDecisionResponse{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriageDecisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriageDecisionResponse) ProtoMessage() {}
+
+func (x *TriageDecisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriageDecisionResponse.ProtoReflect.Descriptor instead.
+func (*TriageDecisionResponse) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TriageDecisionResponse) GetDecision() *TriageDecision {
+	if x != nil {
+		return x.Decision
+	}
+	return nil
+}
+
+func (x *TriageDecisionResponse) GetStatus() *StatusResponse {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type TriageDecisionListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Decisions     []*TriageDecision      `protobuf:"bytes,1,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	Status        *StatusResponse        `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriageDecisionListResponse) Reset() {
+	*x = TriageDecisionListResponse{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriageDecisionListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriageDecisionListResponse) ProtoMessage() {}
+
+func (x *TriageDecisionListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriageDecisionListResponse.ProtoReflect.Descriptor instead.
+func (*TriageDecisionListResponse) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TriageDecisionListResponse) GetDecisions() []*TriageDecision {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+func (x *TriageDecisionListResponse) GetStatus() *StatusResponse {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+// Parts of the issue responses to leave out, to keep the responses of large components small.
+type FieldMask struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Leave out the file paths
+	OmitPaths bool `protobuf:"varint,1,opt,name=omit_paths,json=omitPaths,proto3" json:"omit_paths,omitempty"`
+	// Leave out the issue details, keeping the number of issues of each file
+	OmitIssues    bool `protobuf:"varint,2,opt,name=omit_issues,json=omitIssues,proto3" json:"omit_issues,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldMask) Reset() {
+	*x = FieldMask{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldMask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldMask) ProtoMessage() {}
+
+func (x *FieldMask) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldMask.ProtoReflect.Descriptor instead.
+func (*FieldMask) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FieldMask) GetOmitPaths() bool {
+	if x != nil {
+		return x.OmitPaths
+	}
+	return false
+}
+
+func (x *FieldMask) GetOmitIssues() bool {
+	if x != nil {
+		return x.OmitIssues
+	}
+	return false
+}
+
+type IssueItem struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	RuleId   string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	From     string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To       string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Severity string                 `protobuf:"bytes,4,opt,name=severity,proto3" json:"severity,omitempty"`
+	// Stable identifier of the finding (purl name, normalised path, rule ID and occurrence)
+	Fingerprint string `protobuf:"bytes,5,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	// Central triage decision recorded for this finding (if any)
+	Triage *IssueTriage `protobuf:"bytes,6,opt,name=triage,proto3" json:"triage,omitempty"`
+	// Rule catalog details (if the rule has been imported)
+	Rule          *RuleMetadata `protobuf:"bytes,7,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueItem) Reset() {
+	*x = IssueItem{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueItem) ProtoMessage() {}
+
+func (x *IssueItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueItem.ProtoReflect.Descriptor instead.
+func (*IssueItem) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *IssueItem) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *IssueItem) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *IssueItem) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *IssueItem) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *IssueItem) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *IssueItem) GetTriage() *IssueTriage {
+	if x != nil {
+		return x.Triage
+	}
+	return nil
+}
+
+func (x *IssueItem) GetRule() *RuleMetadata {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+// The triage decision annotated onto a finding.
+type IssueTriage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// false_positive, accepted_risk or confirmed
+	State string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	// Empty for a global decision
+	Project string `protobuf:"bytes,3,opt,name=project,proto3" json:"project,omitempty"`
+	Author  string `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
+	Comment string `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+	// RFC 3339 timestamp
+	UpdatedAt     string `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueTriage) Reset() {
+	*x = IssueTriage{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueTriage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueTriage) ProtoMessage() {}
+
+func (x *IssueTriage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueTriage.ProtoReflect.Descriptor instead.
+func (*IssueTriage) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *IssueTriage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *IssueTriage) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *IssueTriage) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *IssueTriage) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *IssueTriage) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *IssueTriage) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// Rule catalog details of a finding.
+type RuleMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Cwe           []string               `protobuf:"bytes,3,rep,name=cwe,proto3" json:"cwe,omitempty"`
+	Owasp         []string               `protobuf:"bytes,4,rep,name=owasp,proto3" json:"owasp,omitempty"`
+	Confidence    string                 `protobuf:"bytes,5,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	Likelihood    string                 `protobuf:"bytes,6,opt,name=likelihood,proto3" json:"likelihood,omitempty"`
+	Impact        string                 `protobuf:"bytes,7,opt,name=impact,proto3" json:"impact,omitempty"`
+	References    []string               `protobuf:"bytes,8,rep,name=references,proto3" json:"references,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleMetadata) Reset() {
+	*x = RuleMetadata{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleMetadata) ProtoMessage() {}
+
+func (x *RuleMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleMetadata.ProtoReflect.Descriptor instead.
+func (*RuleMetadata) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RuleMetadata) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *RuleMetadata) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RuleMetadata) GetCwe() []string {
+	if x != nil {
+		return x.Cwe
+	}
+	return nil
+}
+
+func (x *RuleMetadata) GetOwasp() []string {
+	if x != nil {
+		return x.Owasp
+	}
+	return nil
+}
+
+func (x *RuleMetadata) GetConfidence() string {
+	if x != nil {
+		return x.Confidence
+	}
+	return ""
+}
+
+func (x *RuleMetadata) GetLikelihood() string {
+	if x != nil {
+		return x.Likelihood
+	}
+	return ""
+}
+
+func (x *RuleMetadata) GetImpact() string {
+	if x != nil {
+		return x.Impact
+	}
+	return ""
+}
+
+func (x *RuleMetadata) GetReferences() []string {
+	if x != nil {
+		return x.References
+	}
+	return nil
+}
+
+type FileIssues struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	FileMd5 string                 `protobuf:"bytes,1,opt,name=file_md5,json=fileMd5,proto3" json:"file_md5,omitempty"`
+	Path    string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Issues  []*IssueItem           `protobuf:"bytes,3,rep,name=issues,proto3" json:"issues,omitempty"`
+	// Number of issues in the file (set when the issue details are omitted)
+	IssueCount    int32 `protobuf:"varint,4,opt,name=issue_count,json=issueCount,proto3" json:"issue_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileIssues) Reset() {
+	*x = FileIssues{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileIssues) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileIssues) ProtoMessage() {}
+
+func (x *FileIssues) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileIssues.ProtoReflect.Descriptor instead.
+func (*FileIssues) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FileIssues) GetFileMd5() string {
+	if x != nil {
+		return x.FileMd5
+	}
+	return ""
+}
+
+func (x *FileIssues) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileIssues) GetIssues() []*IssueItem {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+func (x *FileIssues) GetIssueCount() int32 {
+	if x != nil {
+		return x.IssueCount
+	}
+	return 0
+}
+
+// The files and issues of a component (mirrors scanoss.api.semgrep.v2.ComponentIssueInfo).
+type ComponentIssueInfo struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Purl        string                 `protobuf:"bytes,1,opt,name=purl,proto3" json:"purl,omitempty"`
+	Version     string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Requirement string                 `protobuf:"bytes,3,opt,name=requirement,proto3" json:"requirement,omitempty"`
+	Files       []*FileIssues          `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`
+	// Number of files analysed
+	AnalysedFiles int32 `protobuf:"varint,5,opt,name=analysed_files,json=analysedFiles,proto3" json:"analysed_files,omitempty"`
+	// Rank within the components of the same ecosystem (if available)
+	Peer *PeerRank `protobuf:"bytes,6,opt,name=peer,proto3" json:"peer,omitempty"`
+	// When and how the selected version was analysed (if known)
+	Provenance *Provenance `protobuf:"bytes,7,opt,name=provenance,proto3" json:"provenance,omitempty"`
+	// Weighted findings per 100 analysed files (accepted findings are not counted)
+	RiskScore float64 `protobuf:"fixed64,8,opt,name=risk_score,json=riskScore,proto3" json:"risk_score,omitempty"`
+	// How much of the component is present in the file inventory (only set when an inventory was supplied)
+	Presence      *Presence `protobuf:"bytes,9,opt,name=presence,proto3" json:"presence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentIssueInfo) Reset() {
+	*x = ComponentIssueInfo{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentIssueInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentIssueInfo) ProtoMessage() {}
+
+func (x *ComponentIssueInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentIssueInfo.ProtoReflect.Descriptor instead.
+func (*ComponentIssueInfo) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ComponentIssueInfo) GetPurl() string {
+	if x != nil {
+		return x.Purl
+	}
+	return ""
+}
+
+func (x *ComponentIssueInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ComponentIssueInfo) GetRequirement() string {
+	if x != nil {
+		return x.Requirement
+	}
+	return ""
+}
+
+func (x *ComponentIssueInfo) GetFiles() []*FileIssues {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ComponentIssueInfo) GetAnalysedFiles() int32 {
+	if x != nil {
+		return x.AnalysedFiles
+	}
+	return 0
+}
+
+func (x *ComponentIssueInfo) GetPeer() *PeerRank {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+func (x *ComponentIssueInfo) GetProvenance() *Provenance {
+	if x != nil {
+		return x.Provenance
+	}
+	return nil
+}
+
+func (x *ComponentIssueInfo) GetRiskScore() float64 {
+	if x != nil {
+		return x.RiskScore
+	}
+	return 0
+}
+
+func (x *ComponentIssueInfo) GetPresence() *Presence {
+	if x != nil {
+		return x.Presence
+	}
+	return nil
+}
+
+// Finding density rank of a component within its ecosystem.
+type PeerRank struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Ecosystem string                 `protobuf:"bytes,1,opt,name=ecosystem,proto3" json:"ecosystem,omitempty"`
+	// Findings per analysed file
+	Density float64 `protobuf:"fixed64,2,opt,name=density,proto3" json:"density,omitempty"`
+	// Percentage of the ecosystem components with a lower finding density
+	WorseThan     int32  `protobuf:"varint,3,opt,name=worse_than,json=worseThan,proto3" json:"worse_than,omitempty"`
+	Components    int32  `protobuf:"varint,4,opt,name=components,proto3" json:"components,omitempty"`
+	Summary       string `protobuf:"bytes,5,opt,name=summary,proto3" json:"summary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerRank) Reset() {
+	*x = PeerRank{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerRank) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerRank) ProtoMessage() {}
+
+func (x *PeerRank) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerRank.ProtoReflect.Descriptor instead.
+func (*PeerRank) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PeerRank) GetEcosystem() string {
+	if x != nil {
+		return x.Ecosystem
+	}
+	return ""
+}
+
+func (x *PeerRank) GetDensity() float64 {
+	if x != nil {
+		return x.Density
+	}
+	return 0
+}
+
+func (x *PeerRank) GetWorseThan() int32 {
+	if x != nil {
+		return x.WorseThan
+	}
+	return 0
+}
+
+func (x *PeerRank) GetComponents() int32 {
+	if x != nil {
+		return x.Components
+	}
+	return 0
+}
+
+func (x *PeerRank) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+// When and how a component version was analysed.
+type Presence struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Distinct component files found in the inventory
+	Files int32 `protobuf:"varint,1,opt,name=files,proto3" json:"files,omitempty"`
+	// Share of the distinct analysed files found in the inventory
+	Percent       float64 `protobuf:"fixed64,2,opt,name=percent,proto3" json:"percent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Presence) Reset() {
+	*x = Presence{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Presence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Presence) ProtoMessage() {}
+
+func (x *Presence) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Presence.ProtoReflect.Descriptor instead.
+func (*Presence) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Presence) GetFiles() int32 {
+	if x != nil {
+		return x.Files
+	}
+	return 0
+}
+
+func (x *Presence) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+type KBInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Version        string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	SnapshotDate   string                 `protobuf:"bytes,2,opt,name=snapshot_date,json=snapshotDate,proto3" json:"snapshot_date,omitempty"`
+	EngineVersion  string                 `protobuf:"bytes,3,opt,name=engine_version,json=engineVersion,proto3" json:"engine_version,omitempty"`
+	RulesetVersion string                 `protobuf:"bytes,4,opt,name=ruleset_version,json=rulesetVersion,proto3" json:"ruleset_version,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *KBInfo) Reset() {
+	*x = KBInfo{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KBInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KBInfo) ProtoMessage() {}
+
+func (x *KBInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KBInfo.ProtoReflect.Descriptor instead.
+func (*KBInfo) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *KBInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *KBInfo) GetSnapshotDate() string {
+	if x != nil {
+		return x.SnapshotDate
+	}
+	return ""
+}
+
+func (x *KBInfo) GetEngineVersion() string {
+	if x != nil {
+		return x.EngineVersion
+	}
+	return ""
+}
+
+func (x *KBInfo) GetRulesetVersion() string {
+	if x != nil {
+		return x.RulesetVersion
+	}
+	return ""
+}
+
+type Provenance struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// kb or local
+	Source         string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	ScanDate       string `protobuf:"bytes,2,opt,name=scan_date,json=scanDate,proto3" json:"scan_date,omitempty"`
+	EngineVersion  string `protobuf:"bytes,3,opt,name=engine_version,json=engineVersion,proto3" json:"engine_version,omitempty"`
+	RulesetVersion string `protobuf:"bytes,4,opt,name=ruleset_version,json=rulesetVersion,proto3" json:"ruleset_version,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Provenance) Reset() {
+	*x = Provenance{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Provenance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Provenance) ProtoMessage() {}
+
+func (x *Provenance) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Provenance.ProtoReflect.Descriptor instead.
+func (*Provenance) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Provenance) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Provenance) GetScanDate() string {
+	if x != nil {
+		return x.ScanDate
+	}
+	return ""
+}
+
+func (x *Provenance) GetEngineVersion() string {
+	if x != nil {
+		return x.EngineVersion
+	}
+	return ""
+}
+
+func (x *Provenance) GetRulesetVersion() string {
+	if x != nil {
+		return x.RulesetVersion
+	}
+	return ""
+}
+
+type ComponentIssuesPageRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Purl        string                 `protobuf:"bytes,1,opt,name=purl,proto3" json:"purl,omitempty"`
+	Requirement string                 `protobuf:"bytes,2,opt,name=requirement,proto3" json:"requirement,omitempty"`
+	// next_cursor of the previous page (empty for the first page)
+	Cursor string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Maximum number of issues (or files, if the issue details are omitted) per page
+	Limit         int32      `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	FieldMask     *FieldMask `protobuf:"bytes,5,opt,name=field_mask,json=fieldMask,proto3" json:"field_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentIssuesPageRequest) Reset() {
+	*x = ComponentIssuesPageRequest{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentIssuesPageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentIssuesPageRequest) ProtoMessage() {}
+
+func (x *ComponentIssuesPageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentIssuesPageRequest.ProtoReflect.Descriptor instead.
+func (*ComponentIssuesPageRequest) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ComponentIssuesPageRequest) GetPurl() string {
+	if x != nil {
+		return x.Purl
+	}
+	return ""
+}
+
+func (x *ComponentIssuesPageRequest) GetRequirement() string {
+	if x != nil {
+		return x.Requirement
+	}
+	return ""
+}
+
+func (x *ComponentIssuesPageRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ComponentIssuesPageRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ComponentIssuesPageRequest) GetFieldMask() *FieldMask {
+	if x != nil {
+		return x.FieldMask
+	}
+	return nil
+}
+
+type ComponentIssuesPageResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Component *ComponentIssueInfo    `protobuf:"bytes,1,opt,name=component,proto3" json:"component,omitempty"`
+	// Files with issues and issues of the whole component
+	TotalFiles  int32 `protobuf:"varint,2,opt,name=total_files,json=totalFiles,proto3" json:"total_files,omitempty"`
+	TotalIssues int32 `protobuf:"varint,3,opt,name=total_issues,json=totalIssues,proto3" json:"total_issues,omitempty"`
+	// Set when there are more pages
+	NextCursor    string          `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	Status        *StatusResponse `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentIssuesPageResponse) Reset() {
+	*x = ComponentIssuesPageResponse{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentIssuesPageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentIssuesPageResponse) ProtoMessage() {}
+
+func (x *ComponentIssuesPageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentIssuesPageResponse.ProtoReflect.Descriptor instead.
+func (*ComponentIssuesPageResponse) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ComponentIssuesPageResponse) GetComponent() *ComponentIssueInfo {
+	if x != nil {
+		return x.Component
+	}
+	return nil
+}
+
+func (x *ComponentIssuesPageResponse) GetTotalFiles() int32 {
+	if x != nil {
+		return x.TotalFiles
+	}
+	return 0
+}
+
+func (x *ComponentIssuesPageResponse) GetTotalIssues() int32 {
+	if x != nil {
+		return x.TotalIssues
+	}
+	return 0
+}
+
+func (x *ComponentIssuesPageResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ComponentIssuesPageResponse) GetStatus() *StatusResponse {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type ComponentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Purl          string                 `protobuf:"bytes,1,opt,name=purl,proto3" json:"purl,omitempty"`
+	Requirement   string                 `protobuf:"bytes,2,opt,name=requirement,proto3" json:"requirement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentRequest) Reset() {
+	*x = ComponentRequest{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentRequest) ProtoMessage() {}
+
+func (x *ComponentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentRequest.ProtoReflect.Descriptor instead.
+func (*ComponentRequest) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ComponentRequest) GetPurl() string {
+	if x != nil {
+		return x.Purl
+	}
+	return ""
+}
+
+func (x *ComponentRequest) GetRequirement() string {
+	if x != nil {
+		return x.Requirement
+	}
+	return ""
+}
+
+type ComponentsIssuesStreamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Components    []*ComponentRequest    `protobuf:"bytes,1,rep,name=components,proto3" json:"components,omitempty"`
+	FieldMask     *FieldMask             `protobuf:"bytes,2,opt,name=field_mask,json=fieldMask,proto3" json:"field_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentsIssuesStreamRequest) Reset() {
+	*x = ComponentsIssuesStreamRequest{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentsIssuesStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentsIssuesStreamRequest) ProtoMessage() {}
+
+func (x *ComponentsIssuesStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentsIssuesStreamRequest.ProtoReflect.Descriptor instead.
+func (*ComponentsIssuesStreamRequest) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ComponentsIssuesStreamRequest) GetComponents() []*ComponentRequest {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *ComponentsIssuesStreamRequest) GetFieldMask() *FieldMask {
+	if x != nil {
+		return x.FieldMask
+	}
+	return nil
+}
+
+// Progress of a components issues stream, sent after every batch of resolved components.
+type StreamProgress struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of requested components resolved so far
+	Resolved int32 `protobuf:"varint,1,opt,name=resolved,proto3" json:"resolved,omitempty"`
+	Total    int32 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// Knowledge base snapshot the components were resolved from (if known)
+	Kb            *KBInfo `protobuf:"bytes,3,opt,name=kb,proto3" json:"kb,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamProgress) Reset() {
+	*x = StreamProgress{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamProgress) ProtoMessage() {}
+
+func (x *StreamProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamProgress.ProtoReflect.Descriptor instead.
+func (*StreamProgress) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *StreamProgress) GetResolved() int32 {
+	if x != nil {
+		return x.Resolved
+	}
+	return 0
+}
+
+func (x *StreamProgress) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *StreamProgress) GetKb() *KBInfo {
+	if x != nil {
+		return x.Kb
+	}
+	return nil
+}
+
+// A message of a components issues stream: a resolved component, a progress update or the terminal status
+// (always the last message of the stream).
+type ComponentsIssuesStreamResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Message:
+	//
+	//	*ComponentsIssuesStreamResponse_Component
+	//	*ComponentsIssuesStreamResponse_Progress
+	//	*ComponentsIssuesStreamResponse_Status
+	Message       isComponentsIssuesStreamResponse_Message `protobuf_oneof:"message"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentsIssuesStreamResponse) Reset() {
+	*x = ComponentsIssuesStreamResponse{}
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentsIssuesStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentsIssuesStreamResponse) ProtoMessage() {}
+
+func (x *ComponentsIssuesStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentsIssuesStreamResponse.ProtoReflect.Descriptor instead.
+func (*ComponentsIssuesStreamResponse) Descriptor() ([]byte, []int) {
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ComponentsIssuesStreamResponse) GetMessage() isComponentsIssuesStreamResponse_Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ComponentsIssuesStreamResponse) GetComponent() *ComponentIssueInfo {
+	if x != nil {
+		if x, ok := x.Message.(*ComponentsIssuesStreamResponse_Component); ok {
+			return x.Component
+		}
+	}
+	return nil
+}
+
+func (x *ComponentsIssuesStreamResponse) GetProgress() *StreamProgress {
+	if x != nil {
+		if x, ok := x.Message.(*ComponentsIssuesStreamResponse_Progress); ok {
+			return x.Progress
+		}
+	}
+	return nil
+}
+
+func (x *ComponentsIssuesStreamResponse) GetStatus() *StatusResponse {
+	if x != nil {
+		if x, ok := x.Message.(*ComponentsIssuesStreamResponse_Status); ok {
+			return x.Status
+		}
+	}
+	return nil
+}
+
+type isComponentsIssuesStreamResponse_Message interface {
+	isComponentsIssuesStreamResponse_Message()
+}
+
+type ComponentsIssuesStreamResponse_Component struct {
+	Component *ComponentIssueInfo `protobuf:"bytes,1,opt,name=component,proto3,oneof"`
+}
+
+type ComponentsIssuesStreamResponse_Progress struct {
+	Progress *StreamProgress `protobuf:"bytes,2,opt,name=progress,proto3,oneof"`
+}
+
+type ComponentsIssuesStreamResponse_Status struct {
+	Status *StatusResponse `protobuf:"bytes,3,opt,name=status,proto3,oneof"`
+}
+
+func (*ComponentsIssuesStreamResponse_Component) isComponentsIssuesStreamResponse_Message() {}
+
+func (*ComponentsIssuesStreamResponse_Progress) isComponentsIssuesStreamResponse_Message() {}
+
+func (*ComponentsIssuesStreamResponse_Status) isComponentsIssuesStreamResponse_Message() {}
+
+var File_api_semgrepextv2_scanoss_semgrep_ext_proto protoreflect.FileDescriptor
+
+const file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDesc = "" +
+	"\n" +
+	"*api/semgrepextv2/scanoss-semgrep-ext.proto\x12\x19scanoss.api.semgrepext.v2\"i\n" +
+	"\x0eStatusResponse\x12=\n" +
+	"\x06status\x18\x01 \x01(\x0e2%.scanoss.api.semgrepext.v2.StatusCodeR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x98\x02\n" +
+	"\x0eTriageDecision\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aproject\x18\x02 \x01(\tR\aproject\x12\x19\n" +
+	"\bfile_md5\x18\x03 \x01(\tR\afileMd5\x12\x17\n" +
+	"\arule_id\x18\x04 \x01(\tR\x06ruleId\x12\x12\n" +
+	"\x04from\x18\x05 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x06 \x01(\tR\x02to\x12\x14\n" +
+	"\x05state\x18\a \x01(\tR\x05state\x12\x16\n" +
+	"\x06author\x18\b \x01(\tR\x06author\x12\x18\n" +
+	"\acomment\x18\t \x01(\tR\acomment\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\tR\tupdatedAt\"^\n" +
+	"\x15TriageDecisionRequest\x12E\n" +
+	"\bdecision\x18\x01 \x01(\v2).scanoss.api.semgrepext.v2.TriageDecisionR\bdecision\")\n" +
+	"\x17TriageDecisionIdRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xa0\x01\n" +
+	"\x19TriageDecisionListRequest\x12\x18\n" +
+	"\aproject\x18\x01 \x01(\tR\aproject\x12\x1f\n" +
+	"\vglobal_only\x18\x02 \x01(\bR\n" +
+	"globalOnly\x12\x19\n" +
+	"\bfile_md5\x18\x03 \x01(\tR\afileMd5\x12\x17\n" +
+	"\arule_id\x18\x04 \x01(\tR\x06ruleId\x12\x14\n" +
+	"\x05state\x18\x05 \x01(\tR\x05state\"\xa2\x01\n" +
+	"\x16TriageDecisionResponse\x12E\n" +
+	"\bdecision\x18\x01 \x01(\v2).scanoss.api.semgrepext.v2.TriageDecisionR\bdecision\x12A\n" +
+	"\x06status\x18\x02 \x01(\v2).scanoss.api.semgrepext.v2.StatusResponseR\x06status\"\xa8\x01\n" +
+	"\x1aTriageDecisionListResponse\x12G\n" +
+	"\tdecisions\x18\x01 \x03(\v2).scanoss.api.semgrepext.v2.TriageDecisionR\tdecisions\x12A\n" +
+	"\x06status\x18\x02 \x01(\v2).scanoss.api.semgrepext.v2.StatusResponseR\x06status\"K\n" +
+	"\tFieldMask\x12\x1d\n" +
+	"\n" +
+	"omit_paths\x18\x01 \x01(\bR\tomitPaths\x12\x1f\n" +
+	"\vomit_issues\x18\x02 \x01(\bR\n" +
+	"omitIssues\"\x83\x02\n" +
+	"\tIssueItem\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\tR\x06ruleId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x1a\n" +
+	"\bseverity\x18\x04 \x01(\tR\bseverity\x12 \n" +
+	"\vfingerprint\x18\x05 \x01(\tR\vfingerprint\x12>\n" +
+	"\x06triage\x18\x06 \x01(\v2&.scanoss.api.semgrepext.v2.IssueTriageR\x06triage\x12;\n" +
+	"\x04rule\x18\a \x01(\v2'.scanoss.api.semgrepext.v2.RuleMetadataR\x04rule\"\x9e\x01\n" +
+	"\vIssueTriage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x18\n" +
+	"\aproject\x18\x03 \x01(\tR\aproject\x12\x16\n" +
+	"\x06author\x18\x04 \x01(\tR\x06author\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\tR\tupdatedAt\"\xde\x01\n" +
+	"\fRuleMetadata\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x10\n" +
+	"\x03cwe\x18\x03 \x03(\tR\x03cwe\x12\x14\n" +
+	"\x05owasp\x18\x04 \x03(\tR\x05owasp\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x05 \x01(\tR\n" +
+	"confidence\x12\x1e\n" +
+	"\n" +
+	"likelihood\x18\x06 \x01(\tR\n" +
+	"likelihood\x12\x16\n" +
+	"\x06impact\x18\a \x01(\tR\x06impact\x12\x1e\n" +
+	"\n" +
+	"references\x18\b \x03(\tR\n" +
+	"references\"\x9a\x01\n" +
+	"\n" +
+	"FileIssues\x12\x19\n" +
+	"\bfile_md5\x18\x01 \x01(\tR\afileMd5\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12<\n" +
+	"\x06issues\x18\x03 \x03(\v2$.scanoss.api.semgrepext.v2.IssueItemR\x06issues\x12\x1f\n" +
+	"\vissue_count\x18\x04 \x01(\x05R\n" +
+	"issueCount\"\xa8\x03\n" +
+	"\x12ComponentIssueInfo\x12\x12\n" +
+	"\x04purl\x18\x01 \x01(\tR\x04purl\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12 \n" +
+	"\vrequirement\x18\x03 \x01(\tR\vrequirement\x12;\n" +
+	"\x05files\x18\x04 \x03(\v2%.scanoss.api.semgrepext.v2.FileIssuesR\x05files\x12%\n" +
+	"\x0eanalysed_files\x18\x05 \x01(\x05R\ranalysedFiles\x127\n" +
+	"\x04peer\x18\x06 \x01(\v2#.scanoss.api.semgrepext.v2.PeerRankR\x04peer\x12E\n" +
+	"\n" +
+	"provenance\x18\a \x01(\v2%.scanoss.api.semgrepext.v2.ProvenanceR\n" +
+	"provenance\x12\x1d\n" +
+	"\n" +
+	"risk_score\x18\b \x01(\x01R\triskScore\x12?\n" +
+	"\bpresence\x18\t \x01(\v2#.scanoss.api.semgrepext.v2.PresenceR\bpresence\"\x9b\x01\n" +
+	"\bPeerRank\x12\x1c\n" +
+	"\tecosystem\x18\x01 \x01(\tR\tecosystem\x12\x18\n" +
+	"\adensity\x18\x02 \x01(\x01R\adensity\x12\x1d\n" +
+	"\n" +
+	"worse_than\x18\x03 \x01(\x05R\tworseThan\x12\x1e\n" +
+	"\n" +
+	"components\x18\x04 \x01(\x05R\n" +
+	"components\x12\x18\n" +
+	"\asummary\x18\x05 \x01(\tR\asummary\":\n" +
+	"\bPresence\x12\x14\n" +
+	"\x05files\x18\x01 \x01(\x05R\x05files\x12\x18\n" +
+	"\apercent\x18\x02 \x01(\x01R\apercent\"\x97\x01\n" +
+	"\x06KBInfo\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12#\n" +
+	"\rsnapshot_date\x18\x02 \x01(\tR\fsnapshotDate\x12%\n" +
+	"\x0eengine_version\x18\x03 \x01(\tR\rengineVersion\x12'\n" +
+	"\x0fruleset_version\x18\x04 \x01(\tR\x0erulesetVersion\"\x91\x01\n" +
+	"\n" +
+	"Provenance\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x1b\n" +
+	"\tscan_date\x18\x02 \x01(\tR\bscanDate\x12%\n" +
+	"\x0eengine_version\x18\x03 \x01(\tR\rengineVersion\x12'\n" +
+	"\x0fruleset_version\x18\x04 \x01(\tR\x0erulesetVersion\"\xc5\x01\n" +
+	"\x1aComponentIssuesPageRequest\x12\x12\n" +
+	"\x04purl\x18\x01 \x01(\tR\x04purl\x12 \n" +
+	"\vrequirement\x18\x02 \x01(\tR\vrequirement\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12C\n" +
+	"\n" +
+	"field_mask\x18\x05 \x01(\v2$.scanoss.api.semgrepext.v2.FieldMaskR\tfieldMask\"\x92\x02\n" +
+	"\x1bComponentIssuesPageResponse\x12K\n" +
+	"\tcomponent\x18\x01 \x01(\v2-.scanoss.api.semgrepext.v2.ComponentIssueInfoR\tcomponent\x12\x1f\n" +
+	"\vtotal_files\x18\x02 \x01(\x05R\n" +
+	"totalFiles\x12!\n" +
+	"\ftotal_issues\x18\x03 \x01(\x05R\vtotalIssues\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursor\x12A\n" +
+	"\x06status\x18\x05 \x01(\v2).scanoss.api.semgrepext.v2.StatusResponseR\x06status\"H\n" +
+	"\x10ComponentRequest\x12\x12\n" +
+	"\x04purl\x18\x01 \x01(\tR\x04purl\x12 \n" +
+	"\vrequirement\x18\x02 \x01(\tR\vrequirement\"\xb1\x01\n" +
+	"\x1dComponentsIssuesStreamRequest\x12K\n" +
+	"\n" +
+	"components\x18\x01 \x03(\v2+.scanoss.api.semgrepext.v2.ComponentRequestR\n" +
+	"components\x12C\n" +
+	"\n" +
+	"field_mask\x18\x02 \x01(\v2$.scanoss.api.semgrepext.v2.FieldMaskR\tfieldMask\"u\n" +
+	"\x0eStreamProgress\x12\x1a\n" +
+	"\bresolved\x18\x01 \x01(\x05R\bresolved\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x121\n" +
+	"\x02kb\x18\x03 \x01(\v2!.scanoss.api.semgrepext.v2.KBInfoR\x02kb\"\x88\x02\n" +
+	"\x1eComponentsIssuesStreamResponse\x12M\n" +
+	"\tcomponent\x18\x01 \x01(\v2-.scanoss.api.semgrepext.v2.ComponentIssueInfoH\x00R\tcomponent\x12G\n" +
+	"\bprogress\x18\x02 \x01(\v2).scanoss.api.semgrepext.v2.StreamProgressH\x00R\bprogress\x12C\n" +
+	"\x06status\x18\x03 \x01(\v2).scanoss.api.semgrepext.v2.StatusResponseH\x00R\x06statusB\t\n" +
+	"\amessage*`\n" +
+	"\n" +
+	"StatusCode\x12\x0f\n" +
+	"\vUNSPECIFIED\x10\x00\x12\v\n" +
+	"\aSUCCESS\x10\x01\x12\x1b\n" +
+	"\x17SUCCEEDED_WITH_WARNINGS\x10\x02\x12\v\n" +
+	"\aWARNING\x10\x03\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x042\x89\x05\n" +
+	"\rSemgrepTriage\x12{\n" +
+	"\x14CreateTriageDecision\x120.scanoss.api.semgrepext.v2.TriageDecisionRequest\x1a1.scanoss.api.semgrepext.v2.TriageDecisionResponse\x12z\n" +
+	"\x11GetTriageDecision\x122.scanoss.api.semgrepext.v2.TriageDecisionIdRequest\x1a1.scanoss.api.semgrepext.v2.TriageDecisionResponse\x12\x82\x01\n" +
+	"\x13ListTriageDecisions\x124.scanoss.api.semgrepext.v2.TriageDecisionListRequest\x1a5.scanoss.api.semgrepext.v2.TriageDecisionListResponse\x12{\n" +
+	"\x14UpdateTriageDecision\x120.scanoss.api.semgrepext.v2.TriageDecisionRequest\x1a1.scanoss.api.semgrepext.v2.TriageDecisionResponse\x12}\n" +
+	"\x14DeleteTriageDecision\x122.scanoss.api.semgrepext.v2.TriageDecisionIdRequest\x1a1.scanoss.api.semgrepext.v2.TriageDecisionResponse2\xab\x02\n" +
+	"\rSemgrepIssues\x12\x87\x01\n" +
+	"\x16GetComponentIssuesPage\x125.scanoss.api.semgrepext.v2.ComponentIssuesPageRequest\x1a6.scanoss.api.semgrepext.v2.ComponentIssuesPageResponse\x12\x8f\x01\n" +
+	"\x16StreamComponentsIssues\x128.scanoss.api.semgrepext.v2.ComponentsIssuesStreamRequest\x1a9.scanoss.api.semgrepext.v2.ComponentsIssuesStreamResponse0\x01B3Z1scanoss.com/semgrep/api/semgrepextv2;semgrepextv2b\x06proto3"
+
+var (
+	file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescOnce sync.Once
+	file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescData []byte
+)
+
+func file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescGZIP() []byte {
+	file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescOnce.Do(func() {
+		file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDesc), len(file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDesc)))
+	})
+	return file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDescData
+}
+
+var file_api_semgrepextv2_scanoss_semgrep_ext_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_api_semgrepextv2_scanoss_semgrep_ext_proto_goTypes = []any{
+	(StatusCode)(0),                        // 0: scanoss.api.semgrepext.v2.StatusCode
+	(*StatusResponse)(nil),                 // 1: scanoss.api.semgrepext.v2.StatusResponse
+	(*TriageDecision)(nil),                 // 2: scanoss.api.semgrepext.v2.TriageDecision
+	(*TriageDecisionRequest)(nil),          // 3: scanoss.api.semgrepext.v2.TriageDecisionRequest
+	(*TriageDecisionIdRequest)(nil),        // 4: scanoss.api.semgrepext.v2.TriageDecisionIdRequest
+	(*TriageDecisionListRequest)(nil),      // 5: scanoss.api.semgrepext.v2.TriageDecisionListRequest
+	(*TriageDecisionResponse)(nil),         // 6: scanoss.api.semgrepext.v2.TriageDecisionResponse
+	(*TriageDecisionListResponse)(nil),     // 7: scanoss.api.semgrepext.v2.TriageDecisionListResponse
+	(*FieldMask)(nil),                      // 8: scanoss.api.semgrepext.v2.FieldMask
+	(*IssueItem)(nil),                      // 9: scanoss.api.semgrepext.v2.IssueItem
+	(*IssueTriage)(nil),                    // 10: scanoss.api.semgrepext.v2.IssueTriage
+	(*RuleMetadata)(nil),                   // 11: scanoss.api.semgrepext.v2.RuleMetadata
+	(*FileIssues)(nil),                     // 12: scanoss.api.semgrepext.v2.FileIssues
+	(*ComponentIssueInfo)(nil),             // 13: scanoss.api.semgrepext.v2.ComponentIssueInfo
+	(*PeerRank)(nil),                       // 14: scanoss.api.semgrepext.v2.PeerRank
+	(*Presence)(nil),                       // 15: scanoss.api.semgrepext.v2.Presence
+	(*KBInfo)(nil),                         // 16: scanoss.api.semgrepext.v2.KBInfo
+	(*Provenance)(nil),                     // 17: scanoss.api.semgrepext.v2.Provenance
+	(*ComponentIssuesPageRequest)(nil),     // 18: scanoss.api.semgrepext.v2.ComponentIssuesPageRequest
+	(*ComponentIssuesPageResponse)(nil),    // 19: scanoss.api.semgrepext.v2.ComponentIssuesPageResponse
+	(*ComponentRequest)(nil),               // 20: scanoss.api.semgrepext.v2.ComponentRequest
+	(*ComponentsIssuesStreamRequest)(nil),  // 21: scanoss.api.semgrepext.v2.ComponentsIssuesStreamRequest
+	(*StreamProgress)(nil),                 // 22: scanoss.api.semgrepext.v2.StreamProgress
+	(*ComponentsIssuesStreamResponse)(nil), // 23: scanoss.api.semgrepext.v2.ComponentsIssuesStreamResponse
+}
+var file_api_semgrepextv2_scanoss_semgrep_ext_proto_depIdxs = []int32{
+	0,  // 0: scanoss.api.semgrepext.v2.StatusResponse.status:type_name -> scanoss.api.semgrepext.v2.StatusCode
+	2,  // 1: scanoss.api.semgrepext.v2.TriageDecisionRequest.decision:type_name -> scanoss.api.semgrepext.v2.TriageDecision
+	2,  // 2: scanoss.api.semgrepext.v2.TriageDecisionResponse.decision:type_name -> scanoss.api.semgrepext.v2.TriageDecision
+	1,  // 3: scanoss.api.semgrepext.v2.TriageDecisionResponse.status:type_name -> scanoss.api.semgrepext.v2.StatusResponse
+	2,  // 4: scanoss.api.semgrepext.v2.TriageDecisionListResponse.decisions:type_name -> scanoss.api.semgrepext.v2.TriageDecision
+	1,  // 5: scanoss.api.semgrepext.v2.TriageDecisionListResponse.status:type_name -> scanoss.api.semgrepext.v2.StatusResponse
+	10, // 6: scanoss.api.semgrepext.v2.IssueItem.triage:type_name -> scanoss.api.semgrepext.v2.IssueTriage
+	11, // 7: scanoss.api.semgrepext.v2.IssueItem.rule:type_name -> scanoss.api.semgrepext.v2.RuleMetadata
+	9,  // 8: scanoss.api.semgrepext.v2.FileIssues.issues:type_name -> scanoss.api.semgrepext.v2.IssueItem
+	12, // 9: scanoss.api.semgrepext.v2.ComponentIssueInfo.files:type_name -> scanoss.api.semgrepext.v2.FileIssues
+	14, // 10: scanoss.api.semgrepext.v2.ComponentIssueInfo.peer:type_name -> scanoss.api.semgrepext.v2.PeerRank
+	17, // 11: scanoss.api.semgrepext.v2.ComponentIssueInfo.provenance:type_name -> scanoss.api.semgrepext.v2.Provenance
+	15, // 12: scanoss.api.semgrepext.v2.ComponentIssueInfo.presence:type_name -> scanoss.api.semgrepext.v2.Presence
+	8,  // 13: scanoss.api.semgrepext.v2.ComponentIssuesPageRequest.field_mask:type_name -> scanoss.api.semgrepext.v2.FieldMask
+	13, // 14: scanoss.api.semgrepext.v2.ComponentIssuesPageResponse.component:type_name -> scanoss.api.semgrepext.v2.ComponentIssueInfo
+	1,  // 15: scanoss.api.semgrepext.v2.ComponentIssuesPageResponse.status:type_name -> scanoss.api.semgrepext.v2.StatusResponse
+	20, // 16: scanoss.api.semgrepext.v2.ComponentsIssuesStreamRequest.components:type_name -> scanoss.api.semgrepext.v2.ComponentRequest
+	8,  // 17: scanoss.api.semgrepext.v2.ComponentsIssuesStreamRequest.field_mask:type_name -> scanoss.api.semgrepext.v2.FieldMask
+	16, // 18: scanoss.api.semgrepext.v2.StreamProgress.kb:type_name -> scanoss.api.semgrepext.v2.KBInfo
+	13, // 19: scanoss.api.semgrepext.v2.ComponentsIssuesStreamResponse.component:type_name -> scanoss.api.semgrepext.v2.ComponentIssueInfo
+	22, // 20: scanoss.api.semgrepext.v2.ComponentsIssuesStreamResponse.progress:type_name -> scanoss.api.semgrepext.v2.StreamProgress
+	1,  // 21: scanoss.api.semgrepext.v2.ComponentsIssuesStreamResponse.status:type_name -> scanoss.api.semgrepext.v2.StatusResponse
+	3,  // 22: scanoss.api.semgrepext.v2.SemgrepTriage.CreateTriageDecision:input_type -> scanoss.api.semgrepext.v2.TriageDecisionRequest
+	4,  // 23: scanoss.api.semgrepext.v2.SemgrepTriage.GetTriageDecision:input_type -> scanoss.api.semgrepext.v2.TriageDecisionIdRequest
+	5,  // 24: scanoss.api.semgrepext.v2.SemgrepTriage.ListTriageDecisions:input_type -> scanoss.api.semgrepext.v2.TriageDecisionListRequest
+	3,  // 25: scanoss.api.semgrepext.v2.SemgrepTriage.UpdateTriageDecision:input_type -> scanoss.api.semgrepext.v2.TriageDecisionRequest
+	4,  // 26: scanoss.api.semgrepext.v2.SemgrepTriage.DeleteTriageDecision:input_type -> scanoss.api.semgrepext.v2.TriageDecisionIdRequest
+	18, // 27: scanoss.api.semgrepext.v2.SemgrepIssues.GetComponentIssuesPage:input_type -> scanoss.api.semgrepext.v2.ComponentIssuesPageRequest
+	21, // 28: scanoss.api.semgrepext.v2.SemgrepIssues.StreamComponentsIssues:input_type -> scanoss.api.semgrepext.v2.ComponentsIssuesStreamRequest
+	6,  // 29: scanoss.api.semgrepext.v2.SemgrepTriage.CreateTriageDecision:output_type -> scanoss.api.semgrepext.v2.TriageDecisionResponse
+	6,  // 30: scanoss.api.semgrepext.v2.SemgrepTriage.GetTriageDecision:output_type -> scanoss.api.semgrepext.v2.TriageDecisionResponse
+	7,  // 31: scanoss.api.semgrepext.v2.SemgrepTriage.ListTriageDecisions:output_type -> scanoss.api.semgrepext.v2.TriageDecisionListResponse
+	6,  // 32: scanoss.api.semgrepext.v2.SemgrepTriage.UpdateTriageDecision:output_type -> scanoss.api.semgrepext.v2.TriageDecisionResponse
+	6,  // 33: scanoss.api.semgrepext.v2.SemgrepTriage.DeleteTriageDecision:output_type -> scanoss.api.semgrepext.v2.TriageDecisionResponse
+	19, // 34: scanoss.api.semgrepext.v2.SemgrepIssues.GetComponentIssuesPage:output_type -> scanoss.api.semgrepext.v2.ComponentIssuesPageResponse
+	23, // 35: scanoss.api.semgrepext.v2.SemgrepIssues.StreamComponentsIssues:output_type -> scanoss.api.semgrepext.v2.ComponentsIssuesStreamResponse
+	29, // [29:36] is the sub-list for method output_type
+	22, // [22:29] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_api_semgrepextv2_scanoss_semgrep_ext_proto_init() }
+func file_api_semgrepextv2_scanoss_semgrep_ext_proto_init() {
+	if File_api_semgrepextv2_scanoss_semgrep_ext_proto != nil {
+		return
+	}
+	file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes[22].OneofWrappers = []any{
+		(*ComponentsIssuesStreamResponse_Component)(nil),
+		(*ComponentsIssuesStreamResponse_Progress)(nil),
+		(*ComponentsIssuesStreamResponse_Status)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDesc), len(file_api_semgrepextv2_scanoss_semgrep_ext_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_api_semgrepextv2_scanoss_semgrep_ext_proto_goTypes,
+		DependencyIndexes: file_api_semgrepextv2_scanoss_semgrep_ext_proto_depIdxs,
+		EnumInfos:         file_api_semgrepextv2_scanoss_semgrep_ext_proto_enumTypes,
+		MessageInfos:      file_api_semgrepextv2_scanoss_semgrep_ext_proto_msgTypes,
+	}.Build()
+	File_api_semgrepextv2_scanoss_semgrep_ext_proto = out.File
+	file_api_semgrepextv2_scanoss_semgrep_ext_proto_goTypes = nil
+	file_api_semgrepextv2_scanoss_semgrep_ext_proto_depIdxs = nil
+}
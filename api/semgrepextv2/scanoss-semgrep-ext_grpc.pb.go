@@ -0,0 +1,459 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+//
+// Copyright (C) 2018-2025 SCANOSS.COM
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 2 of the License, or
+// (at your option) any later version.
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//**
+// Semgrep service extensions not (yet) published in the SCANOSS papi definitions.
+// The messages are self-contained so they can be generated without the papi sources.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.27.0
+// source: api/semgrepextv2/scanoss-semgrep-ext.proto
+
+package semgrepextv2
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SemgrepTriage_CreateTriageDecision_FullMethodName = "/scanoss.api.semgrepext.v2.SemgrepTriage/CreateTriageDecision"
+	SemgrepTriage_GetTriageDecision_FullMethodName    = "/scanoss.api.semgrepext.v2.SemgrepTriage/GetTriageDecision"
+	SemgrepTriage_ListTriageDecisions_FullMethodName  = "/scanoss.api.semgrepext.v2.SemgrepTriage/ListTriageDecisions"
+	SemgrepTriage_UpdateTriageDecision_FullMethodName = "/scanoss.api.semgrepext.v2.SemgrepTriage/UpdateTriageDecision"
+	SemgrepTriage_DeleteTriageDecision_FullMethodName = "/scanoss.api.semgrepext.v2.SemgrepTriage/DeleteTriageDecision"
+)
+
+// SemgrepTriageClient is the client API for SemgrepTriage service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Central triage of Semgrep findings.
+type SemgrepTriageClient interface {
+	// Record a new triage decision
+	CreateTriageDecision(ctx context.Context, in *TriageDecisionRequest, opts ...grpc.CallOption) (*TriageDecisionResponse, error)
+	// Get a triage decision by ID
+	GetTriageDecision(ctx context.Context, in *TriageDecisionIdRequest, opts ...grpc.CallOption) (*TriageDecisionResponse, error)
+	// List the triage decisions matching the supplied filters
+	ListTriageDecisions(ctx context.Context, in *TriageDecisionListRequest, opts ...grpc.CallOption) (*TriageDecisionListResponse, error)
+	// Update the state, author and comment of a triage decision
+	UpdateTriageDecision(ctx context.Context, in *TriageDecisionRequest, opts ...grpc.CallOption) (*TriageDecisionResponse, error)
+	// Delete a triage decision
+	DeleteTriageDecision(ctx context.Context, in *TriageDecisionIdRequest, opts ...grpc.CallOption) (*TriageDecisionResponse, error)
+}
+
+type semgrepTriageClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSemgrepTriageClient(cc grpc.ClientConnInterface) SemgrepTriageClient {
+	return &semgrepTriageClient{cc}
+}
+
+func (c *semgrepTriageClient) CreateTriageDecision(ctx context.Context, in *TriageDecisionRequest, opts ...grpc.CallOption) (*TriageDecisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TriageDecisionResponse)
+	err := c.cc.Invoke(ctx, SemgrepTriage_CreateTriageDecision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *semgrepTriageClient) GetTriageDecision(ctx context.Context, in *TriageDecisionIdRequest, opts ...grpc.CallOption) (*TriageDecisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TriageDecisionResponse)
+	err := c.cc.Invoke(ctx, SemgrepTriage_GetTriageDecision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *semgrepTriageClient) ListTriageDecisions(ctx context.Context, in *TriageDecisionListRequest, opts ...grpc.CallOption) (*TriageDecisionListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TriageDecisionListResponse)
+	err := c.cc.Invoke(ctx, SemgrepTriage_ListTriageDecisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *semgrepTriageClient) UpdateTriageDecision(ctx context.Context, in *TriageDecisionRequest, opts ...grpc.CallOption) (*TriageDecisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TriageDecisionResponse)
+	err := c.cc.Invoke(ctx, SemgrepTriage_UpdateTriageDecision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *semgrepTriageClient) DeleteTriageDecision(ctx context.Context, in *TriageDecisionIdRequest, opts ...grpc.CallOption) (*TriageDecisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TriageDecisionResponse)
+	err := c.cc.Invoke(ctx, SemgrepTriage_DeleteTriageDecision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SemgrepTriageServer is the server API for SemgrepTriage service.
+// All implementations must embed UnimplementedSemgrepTriageServer
+// for forward compatibility.
+//
+// Central triage of Semgrep findings.
+type SemgrepTriageServer interface {
+	// Record a new triage decision
+	CreateTriageDecision(context.Context, *TriageDecisionRequest) (*TriageDecisionResponse, error)
+	// Get a triage decision by ID
+	GetTriageDecision(context.Context, *TriageDecisionIdRequest) (*TriageDecisionResponse, error)
+	// List the triage decisions matching the supplied filters
+	ListTriageDecisions(context.Context, *TriageDecisionListRequest) (*TriageDecisionListResponse, error)
+	// Update the state, author and comment of a triage decision
+	UpdateTriageDecision(context.Context, *TriageDecisionRequest) (*TriageDecisionResponse, error)
+	// Delete a triage decision
+	DeleteTriageDecision(context.Context, *TriageDecisionIdRequest) (*TriageDecisionResponse, error)
+	mustEmbedUnimplementedSemgrepTriageServer()
+}
+
+// UnimplementedSemgrepTriageServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSemgrepTriageServer struct{}
+
+func (UnimplementedSemgrepTriageServer) CreateTriageDecision(context.Context, *TriageDecisionRequest) (*TriageDecisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTriageDecision not implemented")
+}
+func (UnimplementedSemgrepTriageServer) GetTriageDecision(context.Context, *TriageDecisionIdRequest) (*TriageDecisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTriageDecision not implemented")
+}
+func (UnimplementedSemgrepTriageServer) ListTriageDecisions(context.Context, *TriageDecisionListRequest) (*TriageDecisionListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTriageDecisions not implemented")
+}
+func (UnimplementedSemgrepTriageServer) UpdateTriageDecision(context.Context, *TriageDecisionRequest) (*TriageDecisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTriageDecision not implemented")
+}
+func (UnimplementedSemgrepTriageServer) DeleteTriageDecision(context.Context, *TriageDecisionIdRequest) (*TriageDecisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTriageDecision not implemented")
+}
+func (UnimplementedSemgrepTriageServer) mustEmbedUnimplementedSemgrepTriageServer() {}
+func (UnimplementedSemgrepTriageServer) testEmbeddedByValue()                       {}
+
+// UnsafeSemgrepTriageServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SemgrepTriageServer will
+// result in compilation errors.
+type UnsafeSemgrepTriageServer interface {
+	mustEmbedUnimplementedSemgrepTriageServer()
+}
+
+func RegisterSemgrepTriageServer(s grpc.ServiceRegistrar, srv SemgrepTriageServer) {
+	// If the following call pancis, it indicates UnimplementedSemgrepTriageServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SemgrepTriage_ServiceDesc, srv)
+}
+
+func _SemgrepTriage_CreateTriageDecision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriageDecisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemgrepTriageServer).CreateTriageDecision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SemgrepTriage_CreateTriageDecision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemgrepTriageServer).CreateTriageDecision(ctx, req.(*TriageDecisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SemgrepTriage_GetTriageDecision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriageDecisionIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemgrepTriageServer).GetTriageDecision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SemgrepTriage_GetTriageDecision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemgrepTriageServer).GetTriageDecision(ctx, req.(*TriageDecisionIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SemgrepTriage_ListTriageDecisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriageDecisionListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemgrepTriageServer).ListTriageDecisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SemgrepTriage_ListTriageDecisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemgrepTriageServer).ListTriageDecisions(ctx, req.(*TriageDecisionListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SemgrepTriage_UpdateTriageDecision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriageDecisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemgrepTriageServer).UpdateTriageDecision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SemgrepTriage_UpdateTriageDecision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemgrepTriageServer).UpdateTriageDecision(ctx, req.(*TriageDecisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SemgrepTriage_DeleteTriageDecision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriageDecisionIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemgrepTriageServer).DeleteTriageDecision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SemgrepTriage_DeleteTriageDecision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemgrepTriageServer).DeleteTriageDecision(ctx, req.(*TriageDecisionIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SemgrepTriage_ServiceDesc is the grpc.ServiceDesc for SemgrepTriage service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SemgrepTriage_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "scanoss.api.semgrepext.v2.SemgrepTriage",
+	HandlerType: (*SemgrepTriageServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTriageDecision",
+			Handler:    _SemgrepTriage_CreateTriageDecision_Handler,
+		},
+		{
+			MethodName: "GetTriageDecision",
+			Handler:    _SemgrepTriage_GetTriageDecision_Handler,
+		},
+		{
+			MethodName: "ListTriageDecisions",
+			Handler:    _SemgrepTriage_ListTriageDecisions_Handler,
+		},
+		{
+			MethodName: "UpdateTriageDecision",
+			Handler:    _SemgrepTriage_UpdateTriageDecision_Handler,
+		},
+		{
+			MethodName: "DeleteTriageDecision",
+			Handler:    _SemgrepTriage_DeleteTriageDecision_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/semgrepextv2/scanoss-semgrep-ext.proto",
+}
+
+const (
+	SemgrepIssues_GetComponentIssuesPage_FullMethodName = "/scanoss.api.semgrepext.v2.SemgrepIssues/GetComponentIssuesPage"
+	SemgrepIssues_StreamComponentsIssues_FullMethodName = "/scanoss.api.semgrepext.v2.SemgrepIssues/StreamComponentsIssues"
+)
+
+// SemgrepIssuesClient is the client API for SemgrepIssues service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Semgrep issue lookups for large components (complementing the papi Semgrep service).
+type SemgrepIssuesClient interface {
+	// Get a page of the files and issues of a single component
+	GetComponentIssuesPage(ctx context.Context, in *ComponentIssuesPageRequest, opts ...grpc.CallOption) (*ComponentIssuesPageResponse, error)
+	// Stream the issues of a (large) list of components, one component at a time as they are resolved
+	StreamComponentsIssues(ctx context.Context, in *ComponentsIssuesStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ComponentsIssuesStreamResponse], error)
+}
+
+type semgrepIssuesClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSemgrepIssuesClient(cc grpc.ClientConnInterface) SemgrepIssuesClient {
+	return &semgrepIssuesClient{cc}
+}
+
+func (c *semgrepIssuesClient) GetComponentIssuesPage(ctx context.Context, in *ComponentIssuesPageRequest, opts ...grpc.CallOption) (*ComponentIssuesPageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ComponentIssuesPageResponse)
+	err := c.cc.Invoke(ctx, SemgrepIssues_GetComponentIssuesPage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *semgrepIssuesClient) StreamComponentsIssues(ctx context.Context, in *ComponentsIssuesStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ComponentsIssuesStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SemgrepIssues_ServiceDesc.Streams[0], SemgrepIssues_StreamComponentsIssues_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ComponentsIssuesStreamRequest, ComponentsIssuesStreamResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SemgrepIssues_StreamComponentsIssuesClient = grpc.ServerStreamingClient[ComponentsIssuesStreamResponse]
+
+// SemgrepIssuesServer is the server API for SemgrepIssues service.
+// All implementations must embed UnimplementedSemgrepIssuesServer
+// for forward compatibility.
+//
+// Semgrep issue lookups for large components (complementing the papi Semgrep service).
+type SemgrepIssuesServer interface {
+	// Get a page of the files and issues of a single component
+	GetComponentIssuesPage(context.Context, *ComponentIssuesPageRequest) (*ComponentIssuesPageResponse, error)
+	// Stream the issues of a (large) list of components, one component at a time as they are resolved
+	StreamComponentsIssues(*ComponentsIssuesStreamRequest, grpc.ServerStreamingServer[ComponentsIssuesStreamResponse]) error
+	mustEmbedUnimplementedSemgrepIssuesServer()
+}
+
+// UnimplementedSemgrepIssuesServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSemgrepIssuesServer struct{}
+
+func (UnimplementedSemgrepIssuesServer) GetComponentIssuesPage(context.Context, *ComponentIssuesPageRequest) (*ComponentIssuesPageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetComponentIssuesPage not implemented")
+}
+func (UnimplementedSemgrepIssuesServer) StreamComponentsIssues(*ComponentsIssuesStreamRequest, grpc.ServerStreamingServer[ComponentsIssuesStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamComponentsIssues not implemented")
+}
+func (UnimplementedSemgrepIssuesServer) mustEmbedUnimplementedSemgrepIssuesServer() {}
+func (UnimplementedSemgrepIssuesServer) testEmbeddedByValue()                       {}
+
+// UnsafeSemgrepIssuesServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SemgrepIssuesServer will
+// result in compilation errors.
+type UnsafeSemgrepIssuesServer interface {
+	mustEmbedUnimplementedSemgrepIssuesServer()
+}
+
+func RegisterSemgrepIssuesServer(s grpc.ServiceRegistrar, srv SemgrepIssuesServer) {
+	// If the following call pancis, it indicates UnimplementedSemgrepIssuesServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SemgrepIssues_ServiceDesc, srv)
+}
+
+func _SemgrepIssues_GetComponentIssuesPage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComponentIssuesPageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SemgrepIssuesServer).GetComponentIssuesPage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SemgrepIssues_GetComponentIssuesPage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SemgrepIssuesServer).GetComponentIssuesPage(ctx, req.(*ComponentIssuesPageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SemgrepIssues_StreamComponentsIssues_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ComponentsIssuesStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SemgrepIssuesServer).StreamComponentsIssues(m, &grpc.GenericServerStream[ComponentsIssuesStreamRequest, ComponentsIssuesStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SemgrepIssues_StreamComponentsIssuesServer = grpc.ServerStreamingServer[ComponentsIssuesStreamResponse]
+
+// SemgrepIssues_ServiceDesc is the grpc.ServiceDesc for SemgrepIssues service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SemgrepIssues_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "scanoss.api.semgrepext.v2.SemgrepIssues",
+	HandlerType: (*SemgrepIssuesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetComponentIssuesPage",
+			Handler:    _SemgrepIssues_GetComponentIssuesPage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamComponentsIssues",
+			Handler:       _SemgrepIssues_StreamComponentsIssues_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/semgrepextv2/scanoss-semgrep-ext.proto",
+}
@@ -17,7 +17,18 @@
 // Package main load the Semgrep CLI
 package main
 
-func main() {
+import (
+	"fmt"
+	"os"
+
+	"scanoss.com/semgrep/pkg/cmd"
+)
 
-	// TODO Add CLI entry point...
+// main runs the Semgrep CLI.
+func main() {
+	err := cmd.RunCli(os.Args[1:])
+	if err != nil && !cmd.IsUsageError(err) && !cmd.IsPolicyViolation(err) {
+		_, _ = fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+	}
+	os.Exit(cmd.ExitCode(err))
 }
@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package baseline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"scanoss.com/semgrep/pkg/dtos"
+	"scanoss.com/semgrep/pkg/outputs"
+)
+
+// Baseline is the set of finding fingerprints reported by a previous run.
+type Baseline struct {
+	fingerprints map[string]string // fingerprint to the purl name (no version) of its component, if known
+}
+
+// Load reads the given baseline file (SemgrepOutput JSON or SARIF).
+func Load(filename string) (*Baseline, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline file %v: %v", filename, err)
+	}
+	b, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid baseline file %v: %v", filename, err)
+	}
+	return b, nil
+}
+
+// Parse detects the format of the baseline data (SemgrepOutput JSON or SARIF) and collects its fingerprints.
+func Parse(data []byte) (*Baseline, error) {
+	var probe struct {
+		Purls json.RawMessage `json:"purls"`
+		Runs  json.RawMessage `json:"runs"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline: %v", err)
+	}
+	switch {
+	case probe.Runs != nil:
+		var log outputs.SARIFLog
+		if err := json.Unmarshal(data, &log); err != nil {
+			return nil, fmt.Errorf("failed to parse SARIF baseline: %v", err)
+		}
+		return FromSARIF(log)
+	case probe.Purls != nil:
+		output, err := dtos.ParseSemgrepOutput(data)
+		if err != nil {
+			return nil, err
+		}
+		return FromOutput(output), nil
+	default:
+		return nil, errors.New("baseline is neither a Semgrep output ('purls') nor a SARIF log ('runs')")
+	}
+}
+
+// FromOutput builds a baseline from a previous Semgrep output. Findings without a fingerprint are fingerprinted first.
+func FromOutput(output dtos.SemgrepOutput) *Baseline {
+	ensureFingerprints(output)
+	b := &Baseline{fingerprints: make(map[string]string)}
+	for _, item := range output.Purls {
+		for _, file := range item.Files {
+			for _, issue := range file.Issues {
+				b.fingerprints[issue.Fingerprint] = dtos.PurlName(item.Purl)
+			}
+		}
+	}
+	return b
+}
+
+// FromSARIF builds a baseline from a previous SARIF log. Every result must carry a fingerprint (see outputs.SARIFFingerprintKey).
+// The component of each result is read from its purl property (if any).
+func FromSARIF(log outputs.SARIFLog) (*Baseline, error) {
+	b := &Baseline{fingerprints: make(map[string]string)}
+	for _, run := range log.Runs {
+		for i, result := range run.Results {
+			if result.BaselineState == "absent" {
+				continue // reported as gone in that run, so not part of its findings
+			}
+			fingerprint := result.PartialFingerprints[outputs.SARIFFingerprintKey]
+			if len(fingerprint) == 0 {
+				return nil, fmt.Errorf("SARIF result %d (%v) has no '%v' fingerprint", i+1, result.RuleID, outputs.SARIFFingerprintKey)
+			}
+			purl, _ := result.Properties["purl"].(string)
+			b.fingerprints[fingerprint] = dtos.PurlName(purl)
+		}
+	}
+	return b, nil
+}
+
+// Len returns the number of distinct findings in the baseline.
+func (b *Baseline) Len() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.fingerprints)
+}
+
+// Compare removes the findings present in the baseline from the output (updating it in place), leaving only the new ones.
+// Files left without findings are dropped (components are kept). The outcome is also recorded in the output Baseline summary.
+// Only the baseline findings of components present in the output are counted as fixed, so a narrower run doesn't
+// report the findings of the components it left out. Findings of unknown components (SARIF results without a purl) always count.
+func (b *Baseline) Compare(output *dtos.SemgrepOutput) dtos.BaselineSummary {
+	summary := dtos.BaselineSummary{}
+	if b == nil {
+		return summary
+	}
+	ensureFingerprints(*output)
+	seen := make(map[string]struct{})
+	components := make(map[string]struct{}, len(output.Purls))
+	for i := range output.Purls {
+		item := &output.Purls[i]
+		components[dtos.PurlName(item.Purl)] = struct{}{}
+		var files []dtos.SemgrepFileIssues
+		for _, file := range item.Files {
+			if len(file.Issues) == 0 {
+				files = append(files, file)
+				continue
+			}
+			var issues []dtos.IssueItem
+			for _, issue := range file.Issues {
+				seen[issue.Fingerprint] = struct{}{}
+				if _, found := b.fingerprints[issue.Fingerprint]; found {
+					summary.Unchanged++
+					continue
+				}
+				summary.New++
+				issues = append(issues, issue)
+			}
+			if len(issues) > 0 {
+				file.Issues = issues
+				files = append(files, file)
+			}
+		}
+		item.Files = files
+	}
+	for fingerprint, purlName := range b.fingerprints {
+		if _, found := seen[fingerprint]; found {
+			continue
+		}
+		if _, found := components[purlName]; found || len(purlName) == 0 {
+			summary.Fixed++
+		}
+	}
+	output.Baseline = &summary
+	return summary
+}
+
+// ensureFingerprints fingerprints the findings of any component with findings missing a fingerprint (i.e. older outputs).
+func ensureFingerprints(output dtos.SemgrepOutput) {
+	for i := range output.Purls {
+		if missingFingerprints(output.Purls[i]) {
+			dtos.AssignFingerprints(dtos.SemgrepOutput{Purls: output.Purls[i : i+1]})
+		}
+	}
+}
+
+// missingFingerprints checks if any finding of the component has no fingerprint.
+func missingFingerprints(item dtos.SemgrepOutputItem) bool {
+	for _, file := range item.Files {
+		for _, issue := range file.Issues {
+			if len(issue.Fingerprint) == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package baseline
+
+import (
+	"testing"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+	"scanoss.com/semgrep/pkg/outputs"
+)
+
+// currentOutput returns the output of a later run: lodash has been bumped (one finding fixed, one added, with shifted lines).
+func currentOutput() dtos.SemgrepOutput {
+	return dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{
+		{Purl: "pkg:npm/lodash", Version: "4.17.22", Files: []dtos.SemgrepFileIssues{
+			{File: "bcd234", Path: "lodash-4.17.22/lodash.js", Issues: []dtos.IssueItem{
+				{RuleID: "javascript.lang.security.audit.prototype-pollution", From: "12", To: "17", Severity: "ERROR"},
+				{RuleID: "javascript.lang.security.audit.unsafe-regex", From: "40", To: "40", Severity: "WARNING"},
+			}},
+		}},
+		{Purl: "pkg:github/madler/zlib", Version: "1.2.13", Files: []dtos.SemgrepFileIssues{
+			{File: "0011aa", Path: "zlib-1.2.13/inflate.c", Issues: []dtos.IssueItem{
+				{RuleID: "c.lang.security.insecure-use-memset", From: "5", To: "5", Severity: "WARNING"},
+			}},
+		}},
+		{Purl: "pkg:npm/left-pad", Version: "1.3.0"},
+	}}
+}
+
+func TestLoadCompare(t *testing.T) {
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	b, err := Load("./tests/baseline.json")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if b.Len() != 3 {
+		t.Fatalf("Load() baseline findings = %v, want 3", b.Len())
+	}
+	output := currentOutput()
+	summary := b.Compare(&output)
+	want := dtos.BaselineSummary{New: 1, Unchanged: 2, Fixed: 1}
+	if summary != want || output.Baseline == nil || *output.Baseline != want {
+		t.Errorf("Compare() = %+v (output %+v), want %+v", summary, output.Baseline, want)
+	}
+	if len(output.Purls) != 3 || len(output.Purls[0].Files) != 1 || len(output.Purls[1].Files) != 0 {
+		t.Fatalf("Compare() output = %+v", output.Purls)
+	}
+	if issues := output.Purls[0].Files[0].Issues; len(issues) != 1 || issues[0].RuleID != "javascript.lang.security.audit.unsafe-regex" {
+		t.Errorf("Compare() new findings = %+v", issues)
+	}
+}
+
+func TestCompareFixedScope(t *testing.T) {
+	previous := currentOutput()
+	dtos.AssignFingerprints(previous)
+	data, err := outputs.ExportSARIF(previous)
+	if err != nil {
+		t.Fatalf("ExportSARIF() error = %v", err)
+	}
+	sarif, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse(SARIF) error = %v", err)
+	}
+	tests := []struct {
+		name     string
+		baseline *Baseline
+		purls    []dtos.SemgrepOutputItem
+		want     dtos.BaselineSummary
+	}{
+		{name: "component left out", baseline: FromOutput(previous), purls: currentOutput().Purls[:1], want: dtos.BaselineSummary{Unchanged: 2}},
+		{name: "component clean", baseline: FromOutput(previous), purls: []dtos.SemgrepOutputItem{{Purl: "pkg:github/madler/zlib@1.3.1", Version: "1.3.1"}},
+			want: dtos.BaselineSummary{Fixed: 1}},
+		{name: "SARIF component left out", baseline: sarif, purls: currentOutput().Purls[1:], want: dtos.BaselineSummary{Unchanged: 1}},
+		{name: "unknown component", baseline: &Baseline{fingerprints: map[string]string{"0123456789abcdef": ""}}, purls: currentOutput().Purls[2:],
+			want: dtos.BaselineSummary{Fixed: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := dtos.SemgrepOutput{Purls: tt.purls}
+			if summary := tt.baseline.Compare(&output); summary != tt.want {
+				t.Errorf("Compare() = %+v, want %+v", summary, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSARIF(t *testing.T) {
+	previous := currentOutput()
+	dtos.AssignFingerprints(previous)
+	data, err := outputs.ExportSARIF(previous)
+	if err != nil {
+		t.Fatalf("ExportSARIF() error = %v", err)
+	}
+	b, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse(SARIF) error = %v", err)
+	}
+	output := currentOutput()
+	if summary := b.Compare(&output); summary != (dtos.BaselineSummary{Unchanged: 3}) {
+		t.Errorf("Compare() against itself = %+v", summary)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "not json", input: "purls: []"},
+		{name: "unknown document", input: `{"components": []}`},
+		{name: "bad output", input: `{"purls": {}}`},
+		{name: "no fingerprint", input: `{"runs": [{"results": [{"ruleId": "js.rule"}]}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.input)); err == nil {
+				t.Errorf("Parse(%v) should fail", tt.input)
+			}
+		})
+	}
+	if _, err := Load("./tests/missing.json"); err == nil {
+		t.Errorf("Load() missing file should fail")
+	}
+}
+
+func TestNilBaseline(t *testing.T) {
+	var b *Baseline
+	output := currentOutput()
+	if summary := b.Compare(&output); summary != (dtos.BaselineSummary{}) || output.Baseline != nil || b.Len() != 0 {
+		t.Errorf("Compare() nil baseline = %+v", summary)
+	}
+}
@@ -0,0 +1,259 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// Default remote request settings.
+const (
+	DefaultTimeout   = 3 * time.Minute
+	DefaultRetries   = 3
+	DefaultChunkSize = 100
+)
+
+const (
+	defaultRetryWait = time.Second
+	maxRetryWait     = 30 * time.Second
+	apiKeyHeader     = "x-api-key"
+	projectHeader    = "x-scanoss-project" // Scopes the triage decisions annotated onto the findings
+)
+
+// Config holds the details required to connect to a remote Semgrep service.
+type Config struct {
+	GRPCAddress string        // gRPC server address (host:port)
+	RESTURL     string        // REST server base URL (http(s)://host:port)
+	UseTLS      bool          // Use TLS for the gRPC connection (REST uses the URL scheme)
+	CACertFile  string        // PEM CA bundle used to verify the server certificate (implies TLS)
+	APIKey      string        // API key sent with every request
+	Project     string        // Project sent with every request, selecting its triage decisions
+	Timeout     time.Duration // Timeout for each individual request
+	MaxRetries  int           // Number of times to retry a failed request
+	RetryWait   time.Duration // Initial wait between retries (doubled after every attempt)
+	ChunkSize   int           // Maximum number of components sent in a single request
+}
+
+// transport sends a single components issues request to the remote service.
+type transport interface {
+	name() string
+	componentsIssues(ctx context.Context, components []dtos.ComponentDTO) (remoteIssues, error)
+	close() error
+}
+
+// remoteIssues holds the components returned by a single components issues request.
+type remoteIssues struct {
+	items  []dtos.SemgrepOutputItem
+	kb     *dtos.KBInfo // KB snapshot reported by the service (if any)
+	legacy bool         // looked up using the standard (semgrepv2) API, so the findings carry no triage or rule details
+}
+
+// Client queries a remote Semgrep service.
+type Client struct {
+	config     Config
+	transports []transport // transports in order of preference (gRPC, then REST)
+	legacy     bool        // the service has fallen back to the standard (semgrepv2) API
+	s          *zap.SugaredLogger
+}
+
+// NewClient creates a client for the remote service described in the config.
+// If both a gRPC address and a REST URL are supplied, REST is used as a fallback when gRPC is unavailable.
+func NewClient(s *zap.SugaredLogger, config Config) (*Client, error) {
+	if len(config.GRPCAddress) == 0 && len(config.RESTURL) == 0 {
+		return nil, errors.New("no gRPC address or REST URL supplied for the remote service")
+	}
+	setConfigDefaults(&config)
+	tlsConfig, err := loadTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{config: config, s: s}
+	if len(config.GRPCAddress) > 0 {
+		t, err := newGRPCTransport(config, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		c.transports = append(c.transports, t)
+	}
+	if len(config.RESTURL) > 0 {
+		t, err := newRESTTransport(config, tlsConfig)
+		if err != nil {
+			_ = c.Close()
+			return nil, err
+		}
+		c.transports = append(c.transports, t)
+	}
+	return c, nil
+}
+
+// setConfigDefaults fills in any missing config values with sensible defaults.
+func setConfigDefaults(config *Config) {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	if config.RetryWait <= 0 {
+		config.RetryWait = defaultRetryWait
+	}
+	if config.ChunkSize <= 0 {
+		config.ChunkSize = DefaultChunkSize
+	}
+}
+
+// loadTLSConfig builds the TLS config (if any) used to verify the remote service.
+func loadTLSConfig(config Config) (*tls.Config, error) {
+	if len(config.CACertFile) == 0 {
+		return &tls.Config{MinVersion: tls.VersionTLS12}, nil
+	}
+	pem, err := os.ReadFile(config.CACertFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle %v: %v", config.CACertFile, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no valid certificates found in CA bundle %v", config.CACertFile)
+	}
+	return &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: pool}, nil
+}
+
+// Close releases all the connections held by the client.
+func (c *Client) Close() error {
+	var errs []error
+	for _, t := range c.transports {
+		errs = append(errs, t.close())
+	}
+	return errors.Join(errs...)
+}
+
+// GetIssues looks up the Semgrep issues of the supplied components on the remote service.
+// The components are sent in chunks and the results are merged (in request order) into a single output.
+// Services without the streaming API are queried using the standard API, whose findings carry no triage or rule details.
+func (c *Client) GetIssues(ctx context.Context, components []dtos.ComponentDTO) (dtos.SemgrepOutput, error) {
+	output := dtos.SemgrepOutput{}
+	for start := 0; start < len(components); start += c.config.ChunkSize {
+		end := min(start+c.config.ChunkSize, len(components))
+		c.s.Debugf("Requesting issues for components %d-%d of %d", start+1, end, len(components))
+		issues, err := c.chunkIssues(ctx, components[start:end])
+		if err != nil {
+			return dtos.SemgrepOutput{}, err
+		}
+		output.Purls = append(output.Purls, issues.items...)
+		if issues.kb != nil {
+			output.KB = issues.kb
+		}
+	}
+	return output, nil
+}
+
+// chunkIssues requests the issues of a single chunk of components, falling back to the next transport if needed.
+func (c *Client) chunkIssues(ctx context.Context, components []dtos.ComponentDTO) (remoteIssues, error) {
+	var lastErr error
+	for i, t := range c.transports {
+		issues, err := c.withRetries(ctx, t, components)
+		if err == nil {
+			if i > 0 {
+				// Stick with the working transport for the remaining chunks
+				c.transports = c.transports[i:]
+			}
+			if issues.legacy {
+				if !c.legacy {
+					c.legacy = true
+					c.s.Warnf("%v service does not support streaming, using the standard API. Findings will have no triage or rule details", t.name())
+				}
+				dtos.AssignFingerprints(dtos.SemgrepOutput{Purls: issues.items}) // the standard API doesn't fingerprint the findings
+			}
+			return issues, nil
+		}
+		lastErr = err
+		if !isUnavailable(err) || i == len(c.transports)-1 {
+			break
+		}
+		c.s.Warnf("%v request failed (%v), falling back to %v", t.name(), err, c.transports[i+1].name())
+	}
+	return remoteIssues{}, lastErr
+}
+
+// withRetries sends a request using the given transport, retrying (with exponential backoff) on temporary failures.
+func (c *Client) withRetries(ctx context.Context, t transport, components []dtos.ComponentDTO) (remoteIssues, error) {
+	wait := c.config.RetryWait
+	for attempt := 0; ; attempt++ {
+		reqCtx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+		issues, err := t.componentsIssues(reqCtx, components)
+		cancel()
+		if err == nil {
+			return issues, nil
+		}
+		if !isRetryable(err) || attempt >= c.config.MaxRetries {
+			return remoteIssues{}, err
+		}
+		// Add up to 25% jitter so parallel clients don't retry in lock step
+		sleep := wait + time.Duration(rand.Int64N(int64(wait)/4+1))
+		c.s.Debugf("%v request failed (attempt %d of %d), retrying in %v: %v", t.name(), attempt+1, c.config.MaxRetries+1, sleep, err)
+		select {
+		case <-ctx.Done():
+			return remoteIssues{}, ctx.Err()
+		case <-time.After(sleep):
+		}
+		wait = min(wait*2, maxRetryWait)
+	}
+}
+
+// remoteError is an error returned by a remote request, flagging whether it's worth retrying.
+type remoteError struct {
+	err           error
+	retryable     bool // temporary failure (i.e. overloaded server, timeout)
+	unavailable   bool // the service could not be reached with this transport
+	unimplemented bool // the service does not provide the requested API (i.e. an older server)
+}
+
+func (e *remoteError) Error() string {
+	return e.err.Error()
+}
+
+func (e *remoteError) Unwrap() error {
+	return e.err
+}
+
+// isRetryable checks if the request that produced the error should be retried.
+func isRetryable(err error) bool {
+	var re *remoteError
+	return errors.As(err, &re) && re.retryable
+}
+
+// isUnimplemented checks if the error means the service does not provide the requested API.
+func isUnimplemented(err error) bool {
+	var re *remoteError
+	return errors.As(err, &re) && re.unimplemented
+}
+
+// isUnavailable checks if the error means the service could not be reached (so another transport should be tried).
+func isUnavailable(err error) bool {
+	var re *remoteError
+	return errors.As(err, &re) && re.unavailable
+}
@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// fakeTransport records the requests it receives and fails the first 'failures' calls with 'err'.
+// Each component is returned with a copy of 'files', along with the KB snapshot and legacy flag.
+type fakeTransport struct {
+	label    string
+	failures int
+	err      error
+	calls    int
+	chunks   [][]dtos.ComponentDTO
+	files    []dtos.SemgrepFileIssues
+	kb       *dtos.KBInfo
+	legacy   bool
+}
+
+func (f *fakeTransport) name() string { return f.label }
+func (f *fakeTransport) close() error { return nil }
+func (f *fakeTransport) componentsIssues(_ context.Context, components []dtos.ComponentDTO) (remoteIssues, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return remoteIssues{}, f.err
+	}
+	f.chunks = append(f.chunks, components)
+	issues := remoteIssues{items: make([]dtos.SemgrepOutputItem, 0, len(components)), kb: f.kb, legacy: f.legacy}
+	for _, c := range components {
+		item := dtos.SemgrepOutputItem{Purl: c.Purl, Version: f.label}
+		for _, file := range f.files {
+			file.Issues = append([]dtos.IssueItem(nil), file.Issues...)
+			item.Files = append(item.Files, file)
+		}
+		issues.items = append(issues.items, item)
+	}
+	return issues, nil
+}
+
+func testComponents(n int) []dtos.ComponentDTO {
+	components := make([]dtos.ComponentDTO, 0, n)
+	for i := 0; i < n; i++ {
+		components = append(components, dtos.ComponentDTO{Purl: fmt.Sprintf("pkg:npm/component-%d", i)})
+	}
+	return components
+}
+
+func newTestClient(config Config, transports ...transport) *Client {
+	setConfigDefaults(&config)
+	return &Client{config: config, transports: transports, s: zlog.S}
+}
+
+func TestMain(m *testing.M) {
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		panic(err)
+	}
+	defer zlog.SyncZap()
+	m.Run()
+}
+
+func TestGetIssuesChunking(t *testing.T) {
+	grpcT := &fakeTransport{label: "gRPC"}
+	c := newTestClient(Config{ChunkSize: 4}, grpcT)
+	output, err := c.GetIssues(context.Background(), testComponents(10))
+	if err != nil {
+		t.Fatalf("GetIssues() error = %v", err)
+	}
+	if len(grpcT.chunks) != 3 || len(grpcT.chunks[0]) != 4 || len(grpcT.chunks[2]) != 2 {
+		t.Errorf("GetIssues() chunks = %v, want 4/4/2", grpcT.chunks)
+	}
+	if len(output.Purls) != 10 || output.Purls[9].Purl != "pkg:npm/component-9" {
+		t.Errorf("GetIssues() output not merged in request order: %+v", output.Purls)
+	}
+}
+
+func TestGetIssuesRetries(t *testing.T) {
+	temporary := &remoteError{err: errors.New("busy"), retryable: true}
+	tests := []struct {
+		name      string
+		failures  int
+		err       error
+		retries   int
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "recovers", failures: 2, err: temporary, retries: 3, wantCalls: 3},
+		{name: "gives up", failures: 5, err: temporary, retries: 2, wantCalls: 3, wantErr: true},
+		{name: "no retries", failures: 1, err: temporary, retries: 0, wantCalls: 1, wantErr: true},
+		{name: "permanent", failures: 1, err: errors.New("bad request"), retries: 3, wantCalls: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft := &fakeTransport{label: "gRPC", failures: tt.failures, err: tt.err}
+			c := newTestClient(Config{MaxRetries: tt.retries, RetryWait: time.Millisecond}, ft)
+			_, err := c.GetIssues(context.Background(), testComponents(1))
+			if (err != nil) != tt.wantErr || ft.calls != tt.wantCalls {
+				t.Errorf("GetIssues() error = %v, calls = %v, want error %v, calls %v", err, ft.calls, tt.wantErr, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestGetIssuesFallback(t *testing.T) {
+	grpcT := &fakeTransport{label: "gRPC", failures: 100, err: &remoteError{err: errors.New("unavailable"), retryable: true, unavailable: true}}
+	restT := &fakeTransport{label: "REST"}
+	c := newTestClient(Config{MaxRetries: 1, RetryWait: time.Millisecond, ChunkSize: 1}, grpcT, restT)
+	output, err := c.GetIssues(context.Background(), testComponents(3))
+	if err != nil {
+		t.Fatalf("GetIssues() error = %v", err)
+	}
+	if grpcT.calls != 2 || len(restT.chunks) != 3 {
+		t.Errorf("GetIssues() gRPC calls = %v (want 2), REST chunks = %v (want 3)", grpcT.calls, len(restT.chunks))
+	}
+	if output.Purls[0].Version != "REST" {
+		t.Errorf("GetIssues() output not from the REST fallback: %+v", output.Purls[0])
+	}
+	// Non availability errors must not trigger the fallback
+	grpcT = &fakeTransport{label: "gRPC", failures: 1, err: errors.New("invalid purl")}
+	restT = &fakeTransport{label: "REST"}
+	c = newTestClient(Config{}, grpcT, restT)
+	if _, err = c.GetIssues(context.Background(), testComponents(1)); err == nil || restT.calls != 0 {
+		t.Errorf("GetIssues() should not fall back on request errors: %v, REST calls %v", err, restT.calls)
+	}
+}
+
+func TestGetIssuesKBAndLegacy(t *testing.T) {
+	files := []dtos.SemgrepFileIssues{{File: "abc123", Path: "lib/index.js", Issues: []dtos.IssueItem{{RuleID: "js.rule", From: "1", To: "2"}}}}
+	kb := &dtos.KBInfo{Version: "2025.10"}
+	c := newTestClient(Config{ChunkSize: 1}, &fakeTransport{label: "gRPC", files: files, kb: kb})
+	output, err := c.GetIssues(context.Background(), testComponents(2))
+	if err != nil {
+		t.Fatalf("GetIssues() error = %v", err)
+	}
+	if output.KB != kb || c.legacy {
+		t.Errorf("GetIssues() kb = %+v, legacy = %v, want the streamed KB", output.KB, c.legacy)
+	}
+	// The standard API doesn't fingerprint the findings, so the client does
+	c = newTestClient(Config{ChunkSize: 1}, &fakeTransport{label: "gRPC", files: files, legacy: true})
+	if output, err = c.GetIssues(context.Background(), testComponents(2)); err != nil {
+		t.Fatalf("GetIssues() error = %v", err)
+	}
+	for _, item := range output.Purls {
+		want := dtos.Fingerprint(item.Purl, "lib/index.js", "js.rule", 0)
+		if got := item.Files[0].Issues[0].Fingerprint; got != want {
+			t.Errorf("GetIssues() %v fingerprint = %v, want %v", item.Purl, got, want)
+		}
+	}
+	if !c.legacy || output.KB != nil {
+		t.Errorf("GetIssues() legacy = %v, kb = %+v, want a legacy lookup without a KB", c.legacy, output.KB)
+	}
+}
+
+func TestNewClientConfig(t *testing.T) {
+	if _, err := NewClient(zlog.S, Config{}); err == nil {
+		t.Errorf("NewClient() with no server should fail")
+	}
+	if _, err := NewClient(zlog.S, Config{RESTURL: "localhost:40055"}); err == nil {
+		t.Errorf("NewClient() with an invalid REST URL should fail")
+	}
+	if _, err := NewClient(zlog.S, Config{RESTURL: "http://localhost:40055", CACertFile: "missing.pem"}); err == nil {
+		t.Errorf("NewClient() with a missing CA bundle should fail")
+	}
+	c, err := NewClient(zlog.S, Config{RESTURL: "https://localhost:40055/", APIKey: "key", MaxRetries: -1})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer func() { _ = c.Close() }()
+	if c.config.MaxRetries != 0 || c.config.ChunkSize != DefaultChunkSize || c.config.Timeout != DefaultTimeout {
+		t.Errorf("NewClient() defaults not applied: %+v", c.config)
+	}
+}
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package client contains all the logic required to query a running Semgrep service remotely.
+// Requests are streamed using the SemgrepIssues (extension) gRPC API, falling back to the NDJSON REST endpoint if gRPC is unavailable.
+// The streamed components carry the annotations of the service lookup (fingerprints, triage, rule metadata, risk score, peer rank,
+// provenance and KB snapshot). Services without the extension API are queried using the standard semgrepv2 API instead,
+// whose findings are fingerprinted by the client but carry no triage or rule details.
+// Large component lists are split into chunks and failed requests are retried with exponential backoff.
+package client
@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/semgrepv2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	pbx "scanoss.com/semgrep/api/semgrepextv2"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+const maxGRPCMessageSize = 100 * 1024 * 1024 // Largest gRPC response accepted (100MB)
+
+// grpcTransport sends requests using the SemgrepIssues (extension) gRPC API,
+// falling back to the standard semgrepv2 API if the service doesn't provide it.
+type grpcTransport struct {
+	conn      *grpc.ClientConn
+	client    pbx.SemgrepIssuesClient
+	legacy    pb.SemgrepClient
+	apiKey    string
+	project   string
+	useLegacy bool // the extension API is not available, so only use the standard one
+}
+
+// newGRPCTransport creates a (lazily connected) gRPC transport to the given server.
+func newGRPCTransport(config Config, tlsConfig *tls.Config) (*grpcTransport, error) {
+	creds := insecure.NewCredentials()
+	if config.UseTLS || len(config.CACertFile) > 0 {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+	conn, err := grpc.NewClient(config.GRPCAddress,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxGRPCMessageSize)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for %v: %v", config.GRPCAddress, err)
+	}
+	return &grpcTransport{conn: conn, client: pbx.NewSemgrepIssuesClient(conn), legacy: pb.NewSemgrepClient(conn), apiKey: config.APIKey, project: config.Project}, nil
+}
+
+func (t *grpcTransport) name() string {
+	return "gRPC"
+}
+
+func (t *grpcTransport) close() error {
+	if t.conn == nil {
+		return nil
+	}
+	return t.conn.Close()
+}
+
+// componentsIssues sends a StreamComponentsIssues request and converts the streamed components back to the internal format.
+// If the service doesn't implement the stream, a standard GetComponentsIssues request is sent instead (and for all later requests).
+func (t *grpcTransport) componentsIssues(ctx context.Context, components []dtos.ComponentDTO) (remoteIssues, error) {
+	if len(t.apiKey) > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, apiKeyHeader, t.apiKey)
+	}
+	if len(t.project) > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, projectHeader, t.project)
+	}
+	if !t.useLegacy {
+		issues, err := t.streamIssues(ctx, components)
+		if !isUnimplemented(err) {
+			return issues, err
+		}
+		t.useLegacy = true
+	}
+	return t.legacyIssues(ctx, components)
+}
+
+// streamIssues sends a StreamComponentsIssues request and collects the streamed components.
+func (t *grpcTransport) streamIssues(ctx context.Context, components []dtos.ComponentDTO) (remoteIssues, error) {
+	request := &pbx.ComponentsIssuesStreamRequest{Components: make([]*pbx.ComponentRequest, 0, len(components))}
+	for _, c := range components {
+		request.Components = append(request.Components, &pbx.ComponentRequest{Purl: c.Purl, Requirement: c.Requirement})
+	}
+	stream, err := t.client.StreamComponentsIssues(ctx, request)
+	if err != nil {
+		return remoteIssues{}, grpcError(err)
+	}
+	return readIssueStream(t.name(), func() (*pbx.ComponentsIssuesStreamResponse, error) {
+		message, err := stream.Recv()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, grpcError(err)
+		}
+		return message, err
+	})
+}
+
+// legacyIssues sends a standard GetComponentsIssues request and converts the response back to the internal format.
+func (t *grpcTransport) legacyIssues(ctx context.Context, components []dtos.ComponentDTO) (remoteIssues, error) {
+	request := &common.ComponentsRequest{Components: make([]*common.ComponentRequest, 0, len(components))}
+	for _, c := range components {
+		request.Components = append(request.Components, &common.ComponentRequest{Purl: c.Purl, Requirement: c.Requirement})
+	}
+	response, err := t.legacy.GetComponentsIssues(ctx, request)
+	if err != nil {
+		return remoteIssues{}, grpcError(err)
+	}
+	if st := response.GetStatus(); st != nil && st.GetStatus() != common.StatusCode_SUCCESS &&
+		st.GetStatus() != common.StatusCode_SUCCEEDED_WITH_WARNINGS {
+		return remoteIssues{}, fmt.Errorf("gRPC request failed: %v (%v)", st.GetMessage(), st.GetStatus())
+	}
+	issues := remoteIssues{items: make([]dtos.SemgrepOutputItem, 0, len(response.GetComponents())), legacy: true}
+	for _, c := range response.GetComponents() {
+		item := dtos.SemgrepOutputItem{Purl: c.Purl, Version: c.Version}
+		for _, f := range c.Files {
+			file := dtos.SemgrepFileIssues{File: f.FileMD5, Path: f.Path, Issues: make([]dtos.IssueItem, 0, len(f.Issues))}
+			for _, i := range f.Issues {
+				file.Issues = append(file.Issues, dtos.IssueItem{RuleID: i.RuleID, From: i.From, To: i.To, Severity: i.Severity})
+			}
+			item.Files = append(item.Files, file)
+		}
+		issues.items = append(issues.items, item)
+	}
+	return issues, nil
+}
+
+// grpcError classifies a gRPC error as retryable and/or unavailable.
+func grpcError(err error) error {
+	re := &remoteError{err: fmt.Errorf("gRPC request failed: %v", err)}
+	switch status.Code(err) {
+	case codes.Unavailable:
+		re.retryable, re.unavailable = true, true
+	case codes.Unimplemented:
+		re.unavailable, re.unimplemented = true, true
+	case codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		re.retryable = true
+	}
+	return re
+}
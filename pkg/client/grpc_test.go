@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/semgrepv2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	pbx "scanoss.com/semgrep/api/semgrepextv2"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// fakeIssuesClient implements the StreamComponentsIssues call of the SemgrepIssues gRPC client.
+type fakeIssuesClient struct {
+	pbx.SemgrepIssuesClient
+	messages []*pbx.ComponentsIssuesStreamResponse
+	err      error // returned when opening the stream
+	recvErr  error // returned once all the messages have been received (io.EOF if not set)
+	request  *pbx.ComponentsIssuesStreamRequest
+	md       metadata.MD
+}
+
+func (f *fakeIssuesClient) StreamComponentsIssues(ctx context.Context, in *pbx.ComponentsIssuesStreamRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[pbx.ComponentsIssuesStreamResponse], error) {
+	f.request = in
+	f.md, _ = metadata.FromOutgoingContext(ctx)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &fakeIssuesStream{messages: f.messages, err: f.recvErr}, nil
+}
+
+// fakeIssuesStream returns the given messages, followed by the error.
+type fakeIssuesStream struct {
+	grpc.ClientStream
+	messages []*pbx.ComponentsIssuesStreamResponse
+	err      error
+}
+
+func (f *fakeIssuesStream) Recv() (*pbx.ComponentsIssuesStreamResponse, error) {
+	if len(f.messages) == 0 {
+		if f.err != nil {
+			return nil, f.err
+		}
+		return nil, io.EOF
+	}
+	m := f.messages[0]
+	f.messages = f.messages[1:]
+	return m, nil
+}
+
+// fakeSemgrepClient implements the GetComponentsIssues call of the standard semgrepv2 gRPC client.
+type fakeSemgrepClient struct {
+	pb.SemgrepClient
+	response *pb.ComponentsIssueResponse
+	err      error
+	request  *common.ComponentsRequest
+}
+
+func (f *fakeSemgrepClient) GetComponentsIssues(_ context.Context, in *common.ComponentsRequest, _ ...grpc.CallOption) (*pb.ComponentsIssueResponse, error) {
+	f.request = in
+	return f.response, f.err
+}
+
+func streamComponent(c *pbx.ComponentIssueInfo) *pbx.ComponentsIssuesStreamResponse {
+	return &pbx.ComponentsIssuesStreamResponse{Message: &pbx.ComponentsIssuesStreamResponse_Component{Component: c}}
+}
+
+func streamStatus(code pbx.StatusCode) *pbx.ComponentsIssuesStreamResponse {
+	return &pbx.ComponentsIssuesStreamResponse{Message: &pbx.ComponentsIssuesStreamResponse_Status{Status: &pbx.StatusResponse{Status: code, Message: code.String()}}}
+}
+
+func TestGRPCComponentsIssues(t *testing.T) {
+	fake := &fakeIssuesClient{messages: []*pbx.ComponentsIssuesStreamResponse{
+		streamComponent(&pbx.ComponentIssueInfo{Purl: "pkg:npm/lodash", Version: "4.17.21", Requirement: "4.17.21", AnalysedFiles: 4, RiskScore: 250,
+			Presence:   &pbx.Presence{Files: 3, Percent: 75},
+			Peer:       &pbx.PeerRank{Ecosystem: "npm", Density: 0.25, WorseThan: 60, Components: 5, Summary: "worse than 60% of npm packages"},
+			Provenance: &pbx.Provenance{Source: "kb", ScanDate: "2025-01-01"},
+			Files: []*pbx.FileIssues{{FileMd5: "abc123", Path: "lodash.js", Issues: []*pbx.IssueItem{{RuleId: "js.rule", From: "10", To: "15", Severity: "ERROR",
+				Fingerprint: "fp1", Triage: &pbx.IssueTriage{Id: "3", State: "confirmed", Project: "acme", Author: "bob"},
+				Rule: &pbx.RuleMetadata{Title: "Prototype pollution", Cwe: []string{"CWE-1321"}}}}}}}),
+		{Message: &pbx.ComponentsIssuesStreamResponse_Progress{Progress: &pbx.StreamProgress{Resolved: 1, Total: 2, Kb: &pbx.KBInfo{Version: "2025.10", SnapshotDate: "2025-10-01"}}}},
+		streamComponent(&pbx.ComponentIssueInfo{Purl: "pkg:npm/left-pad", Version: "1.3.0"}),
+		streamStatus(pbx.StatusCode_SUCCESS),
+	}}
+	gt := &grpcTransport{client: fake, apiKey: "secret", project: "acme"}
+	issues, err := gt.componentsIssues(context.Background(), []dtos.ComponentDTO{{Purl: "pkg:npm/lodash", Requirement: "4.17.21"}, {Purl: "pkg:npm/left-pad"}})
+	if err != nil {
+		t.Fatalf("componentsIssues() error = %v", err)
+	}
+	if len(fake.request.Components) != 2 || fake.request.Components[0].Requirement != "4.17.21" {
+		t.Errorf("componentsIssues() request = %+v", fake.request)
+	}
+	if !reflect.DeepEqual(fake.md.Get(apiKeyHeader), []string{"secret"}) || !reflect.DeepEqual(fake.md.Get(projectHeader), []string{"acme"}) {
+		t.Errorf("componentsIssues() metadata = %v", fake.md)
+	}
+	want := []dtos.SemgrepOutputItem{
+		{Purl: "pkg:npm/lodash", Version: "4.17.21", AnalysedFiles: 4, RiskScore: 250, Presence: &dtos.Presence{Files: 3, Percent: 75},
+			Peer:       &dtos.PeerRank{Ecosystem: "npm", Density: 0.25, WorseThan: 60, Components: 5, Summary: "worse than 60% of npm packages"},
+			Provenance: &dtos.Provenance{Source: "kb", ScanDate: "2025-01-01"},
+			Files: []dtos.SemgrepFileIssues{{File: "abc123", Path: "lodash.js", Issues: []dtos.IssueItem{{RuleID: "js.rule", From: "10", To: "15", Severity: "ERROR",
+				Fingerprint: "fp1", Triage: &dtos.IssueTriage{ID: "3", State: "confirmed", Project: "acme", Author: "bob"},
+				Rule: &dtos.RuleMetadata{Title: "Prototype pollution", CWE: []string{"CWE-1321"}}}}}}},
+		{Purl: "pkg:npm/left-pad", Version: "1.3.0"},
+	}
+	if !reflect.DeepEqual(issues.items, want) {
+		t.Errorf("componentsIssues() items = %+v, want %+v", issues.items, want)
+	}
+	if wantKB := (&dtos.KBInfo{Version: "2025.10", SnapshotDate: "2025-10-01"}); !reflect.DeepEqual(issues.kb, wantKB) || issues.legacy {
+		t.Errorf("componentsIssues() kb = %+v, legacy = %v, want %+v", issues.kb, issues.legacy, wantKB)
+	}
+}
+
+func TestGRPCLegacyFallback(t *testing.T) {
+	fake := &fakeIssuesClient{err: status.Error(codes.Unimplemented, "unknown method StreamComponentsIssues")}
+	legacy := &fakeSemgrepClient{response: &pb.ComponentsIssueResponse{
+		Components: []*pb.ComponentIssueInfo{
+			{Purl: "pkg:npm/lodash", Version: "4.17.21", Files: []*pb.File{
+				{FileMD5: "abc123", Path: "lodash.js", Issues: []*pb.Issue{{RuleID: "js.rule", From: "10", To: "15", Severity: "ERROR"}}},
+			}},
+			{Purl: "pkg:npm/left-pad", Version: "1.3.0"},
+		},
+		Status: &common.StatusResponse{Status: common.StatusCode_SUCCESS, Message: "Success"},
+	}}
+	gt := &grpcTransport{client: fake, legacy: legacy}
+	for i := 0; i < 2; i++ {
+		fake.request = nil
+		issues, err := gt.componentsIssues(context.Background(), []dtos.ComponentDTO{{Purl: "pkg:npm/lodash", Requirement: "4.17.21"}, {Purl: "pkg:npm/left-pad"}})
+		if err != nil {
+			t.Fatalf("componentsIssues() error = %v", err)
+		}
+		if len(legacy.request.Components) != 2 || legacy.request.Components[0].Requirement != "4.17.21" {
+			t.Errorf("componentsIssues() legacy request = %+v", legacy.request)
+		}
+		want := []dtos.SemgrepOutputItem{
+			{Purl: "pkg:npm/lodash", Version: "4.17.21", Files: []dtos.SemgrepFileIssues{{File: "abc123", Path: "lodash.js",
+				Issues: []dtos.IssueItem{{RuleID: "js.rule", From: "10", To: "15", Severity: "ERROR"}}}}},
+			{Purl: "pkg:npm/left-pad", Version: "1.3.0"},
+		}
+		if !reflect.DeepEqual(issues.items, want) || !issues.legacy {
+			t.Errorf("componentsIssues() items = %+v, legacy = %v, want %+v", issues.items, issues.legacy, want)
+		}
+		if i > 0 && fake.request != nil {
+			t.Errorf("componentsIssues() should stick with the standard API once the stream is unimplemented")
+		}
+	}
+}
+
+func TestGRPCErrors(t *testing.T) {
+	tests := []struct {
+		name            string
+		client          *fakeIssuesClient
+		wantRetryable   bool
+		wantUnavailable bool
+	}{
+		{name: "unavailable", client: &fakeIssuesClient{err: status.Error(codes.Unavailable, "down")}, wantRetryable: true, wantUnavailable: true},
+		{name: "unimplemented", client: &fakeIssuesClient{err: status.Error(codes.Unimplemented, "no")}, wantUnavailable: true},
+		{name: "unimplemented recv", client: &fakeIssuesClient{recvErr: status.Error(codes.Unimplemented, "no")}, wantUnavailable: true},
+		{name: "deadline", client: &fakeIssuesClient{recvErr: status.Error(codes.DeadlineExceeded, "slow")}, wantRetryable: true},
+		{name: "invalid", client: &fakeIssuesClient{err: status.Error(codes.InvalidArgument, "bad")}},
+		{name: "failed status", client: &fakeIssuesClient{messages: []*pbx.ComponentsIssuesStreamResponse{streamStatus(pbx.StatusCode_FAILED)}}},
+		{name: "interrupted", client: &fakeIssuesClient{messages: []*pbx.ComponentsIssuesStreamResponse{
+			streamComponent(&pbx.ComponentIssueInfo{Purl: "pkg:npm/component-0"})}}, wantRetryable: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gt := &grpcTransport{client: tt.client, legacy: &fakeSemgrepClient{err: status.Error(codes.Unimplemented, "no")}}
+			_, err := gt.componentsIssues(context.Background(), testComponents(1))
+			if err == nil {
+				t.Fatalf("componentsIssues() expected an error")
+			}
+			if isRetryable(err) != tt.wantRetryable || isUnavailable(err) != tt.wantUnavailable {
+				t.Errorf("componentsIssues() error %v retryable = %v, unavailable = %v", err, isRetryable(err), isUnavailable(err))
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	pbx "scanoss.com/semgrep/api/semgrepextv2"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+const (
+	componentsIssuesPath       = "/v2/semgrep/issues/components/stream"
+	legacyComponentsIssuesPath = "/v2/semgrep/issues/components"
+	maxRESTMessageSize         = 100 * 1024 * 1024 // Largest REST stream message accepted (100MB)
+	ndjsonContentType          = "application/x-ndjson"
+)
+
+// restTransport sends requests using the REST API (NDJSON components issues stream),
+// falling back to the standard (grpc-gateway) components issues endpoint if the service doesn't provide it.
+type restTransport struct {
+	httpClient     *http.Client
+	endpoint       string
+	legacyEndpoint string
+	apiKey         string
+	project        string
+	useLegacy      bool // the stream endpoint is not available, so only use the standard one
+}
+
+// restComponentsRequest is the JSON body of a components issues request.
+type restComponentsRequest struct {
+	Components []dtos.ComponentDTO `json:"components"`
+}
+
+// restErrorResponse is the JSON body of a failed request.
+type restErrorResponse struct {
+	Status *restStatus `json:"status"`
+}
+
+// restComponentsResponse is the JSON body of a standard components issues response.
+type restComponentsResponse struct {
+	Components []dtos.SemgrepOutputItem `json:"components"`
+	Status     *restStatus              `json:"status"`
+}
+
+// restStatus is the status block of a REST response. The status code can be rendered as a name or number.
+type restStatus struct {
+	Status  json.RawMessage `json:"status"`
+	Message string          `json:"message"`
+}
+
+// succeeded checks if the status reports a successful request.
+func (s *restStatus) succeeded() bool {
+	if s == nil || len(s.Status) == 0 {
+		return true
+	}
+	switch strings.Trim(string(s.Status), `"`) {
+	case "SUCCESS", "SUCCEEDED_WITH_WARNINGS", "1", "2":
+		return true
+	}
+	return false
+}
+
+// newRESTTransport creates a REST transport to the given base URL.
+func newRESTTransport(config Config, tlsConfig *tls.Config) (*restTransport, error) {
+	base, err := url.Parse(strings.TrimSuffix(config.RESTURL, "/"))
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || len(base.Host) == 0 {
+		return nil, fmt.Errorf("invalid REST URL '%v'. Expected http(s)://host[:port]", config.RESTURL)
+	}
+	httpTransport := http.DefaultTransport.(*http.Transport).Clone()
+	httpTransport.TLSClientConfig = tlsConfig
+	return &restTransport{
+		httpClient:     &http.Client{Transport: httpTransport},
+		endpoint:       base.String() + componentsIssuesPath,
+		legacyEndpoint: base.String() + legacyComponentsIssuesPath,
+		apiKey:         config.APIKey,
+		project:        config.Project,
+	}, nil
+}
+
+func (t *restTransport) name() string {
+	return "REST"
+}
+
+func (t *restTransport) close() error {
+	t.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// componentsIssues sends a POST components issues stream request and converts the streamed components back to the internal format.
+// If the service doesn't provide the stream endpoint, the standard endpoint is used instead (and for all later requests).
+func (t *restTransport) componentsIssues(ctx context.Context, components []dtos.ComponentDTO) (remoteIssues, error) {
+	if !t.useLegacy {
+		issues, err := t.streamIssues(ctx, components)
+		if !isUnimplemented(err) {
+			return issues, err
+		}
+		t.useLegacy = true
+	}
+	return t.legacyIssues(ctx, components)
+}
+
+// post sends the components to the given endpoint, returning the (successful) response.
+func (t *restTransport) post(ctx context.Context, endpoint, accept string, components []dtos.ComponentDTO) (*http.Response, error) {
+	body, err := json.Marshal(restComponentsRequest{Components: components})
+	if err != nil {
+		return nil, fmt.Errorf("problem marshalling REST request: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create REST request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", accept)
+	if len(t.apiKey) > 0 {
+		req.Header.Set(apiKeyHeader, t.apiKey)
+	}
+	if len(t.project) > 0 {
+		req.Header.Set(projectHeader, t.project)
+	}
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) {
+			return nil, fmt.Errorf("REST request failed: %v", err) // retrying won't fix an untrusted certificate
+		}
+		// Connection problems (refused, reset, timeouts) are worth retrying and/or switching transport
+		return nil, &remoteError{err: fmt.Errorf("REST request failed: %v", err), retryable: true, unavailable: !errors.Is(err, context.DeadlineExceeded)}
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer func() { _ = resp.Body.Close() }()
+		var response restErrorResponse
+		if data, err := io.ReadAll(io.LimitReader(resp.Body, maxRESTMessageSize)); err == nil {
+			_ = json.Unmarshal(data, &response)
+		}
+		return nil, restError(resp.StatusCode, response.Status)
+	}
+	return resp, nil
+}
+
+// streamIssues sends a POST components issues stream request and collects the streamed components.
+func (t *restTransport) streamIssues(ctx context.Context, components []dtos.ComponentDTO) (remoteIssues, error) {
+	resp, err := t.post(ctx, t.endpoint, ndjsonContentType, components)
+	if err != nil {
+		return remoteIssues{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRESTMessageSize)
+	return readIssueStream(t.name(), func() (*pbx.ComponentsIssuesStreamResponse, error) {
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			message := &pbx.ComponentsIssuesStreamResponse{}
+			if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(line, message); err != nil {
+				return nil, fmt.Errorf("failed to parse REST stream message: %v", err)
+			}
+			return message, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, &remoteError{err: fmt.Errorf("failed to read REST stream: %v", err), retryable: true}
+		}
+		return nil, io.EOF
+	})
+}
+
+// legacyIssues sends a standard POST components issues request and converts the response back to the internal format.
+func (t *restTransport) legacyIssues(ctx context.Context, components []dtos.ComponentDTO) (remoteIssues, error) {
+	resp, err := t.post(ctx, t.legacyEndpoint, "application/json", components)
+	if err != nil {
+		return remoteIssues{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRESTMessageSize))
+	if err != nil {
+		return remoteIssues{}, &remoteError{err: fmt.Errorf("failed to read REST response: %v", err), retryable: true}
+	}
+	var response restComponentsResponse
+	if err = json.Unmarshal(data, &response); err != nil {
+		return remoteIssues{}, fmt.Errorf("failed to parse REST response: %v", err)
+	}
+	if !response.Status.succeeded() {
+		return remoteIssues{}, fmt.Errorf("REST request failed: %v (%v)", response.Status.Message, string(response.Status.Status))
+	}
+	for i := range response.Components {
+		if len(response.Components[i].Files) == 0 {
+			response.Components[i].Files = nil // match the local output for components without issues
+		}
+	}
+	return remoteIssues{items: response.Components, legacy: true}, nil
+}
+
+// restError classifies a failed REST response as retryable and/or unavailable.
+func restError(code int, status *restStatus) error {
+	message := http.StatusText(code)
+	if status != nil && len(status.Message) > 0 {
+		message = status.Message
+	}
+	re := &remoteError{err: fmt.Errorf("REST request failed with HTTP %d: %v", code, message)}
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		re.retryable = true
+	case http.StatusNotFound:
+		re.unavailable, re.unimplemented = true, true
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		re.unavailable = true
+	}
+	return re
+}
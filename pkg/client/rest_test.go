@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+const restResponse = `{"component":{"purl":"pkg:npm/lodash","version":"4.17.21","requirement":"4.17.21","analysedFiles":4,"riskScore":250,"presence":{"files":3,"percent":75},"files":[{"fileMd5":"abc123","path":"lodash.js","issues":[{"ruleId":"js.rule","from":"10","to":"15","severity":"ERROR","fingerprint":"fp1","triage":{"id":"3","state":"confirmed","project":"acme","author":"bob"},"rule":{"title":"Prototype pollution","cwe":["CWE-1321"]}}]}]}}
+{"progress":{"resolved":1,"total":2,"kb":{"version":"2025.10","snapshotDate":"2025-10-01"}}}
+
+{"component":{"purl":"pkg:npm/left-pad","version":"1.3.0","requirement":"1.3.0","provenance":{"source":"kb"}}}
+{"progress":{"resolved":2,"total":2}}
+{"status":{"status":"SUCCESS","message":"Success"}}
+`
+
+func TestRESTComponentsIssues(t *testing.T) {
+	var request restComponentsRequest
+	var apiKey, project string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != componentsIssuesPath {
+			http.NotFound(w, r)
+			return
+		}
+		apiKey, project = r.Header.Get(apiKeyHeader), r.Header.Get(projectHeader)
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		w.Header().Set("Content-Type", ndjsonContentType)
+		_, _ = w.Write([]byte(restResponse))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(zlog.S, Config{RESTURL: srv.URL + "/", APIKey: "secret", Project: "acme"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer func() { _ = c.Close() }()
+	components := []dtos.ComponentDTO{{Purl: "pkg:npm/lodash", Requirement: "4.17.21"}, {Purl: "pkg:npm/left-pad@1.3.0"}}
+	output, err := c.GetIssues(context.Background(), components)
+	if err != nil {
+		t.Fatalf("GetIssues() error = %v", err)
+	}
+	if apiKey != "secret" || project != "acme" || !reflect.DeepEqual(request.Components, components) {
+		t.Errorf("GetIssues() sent key %q, project %q, components %+v", apiKey, project, request.Components)
+	}
+	want := dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{
+		{Purl: "pkg:npm/lodash", Version: "4.17.21", AnalysedFiles: 4, RiskScore: 250, Presence: &dtos.Presence{Files: 3, Percent: 75}, Files: []dtos.SemgrepFileIssues{
+			{File: "abc123", Path: "lodash.js", Issues: []dtos.IssueItem{{RuleID: "js.rule", From: "10", To: "15", Severity: "ERROR",
+				Fingerprint: "fp1", Triage: &dtos.IssueTriage{ID: "3", State: "confirmed", Project: "acme", Author: "bob"},
+				Rule: &dtos.RuleMetadata{Title: "Prototype pollution", CWE: []string{"CWE-1321"}}}}},
+		}},
+		{Purl: "pkg:npm/left-pad", Version: "1.3.0", Provenance: &dtos.Provenance{Source: "kb"}},
+	}, KB: &dtos.KBInfo{Version: "2025.10", SnapshotDate: "2025-10-01"}}
+	if !reflect.DeepEqual(output, want) {
+		t.Errorf("GetIssues() = %+v, want %+v", output, want)
+	}
+}
+
+func TestRESTLegacyFallback(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.Method != http.MethodPost || r.URL.Path != legacyComponentsIssuesPath {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"components":[{"purl":"pkg:npm/lodash","version":"4.17.21","files":[{"fileMD5":"abc123","path":"lodash.js",` +
+			`"issues":[{"ruleID":"js.rule","from":"10","to":"15","severity":"ERROR"}]}]},{"purl":"pkg:npm/left-pad","version":"1.3.0","files":[]}],` +
+			`"status":{"status":"SUCCESS","message":"Success"}}`))
+	}))
+	defer srv.Close()
+
+	rt, err := newRESTTransport(Config{RESTURL: srv.URL}, nil)
+	if err != nil {
+		t.Fatalf("newRESTTransport() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		issues, err := rt.componentsIssues(context.Background(), testComponents(2))
+		if err != nil {
+			t.Fatalf("componentsIssues() error = %v", err)
+		}
+		want := []dtos.SemgrepOutputItem{
+			{Purl: "pkg:npm/lodash", Version: "4.17.21", Files: []dtos.SemgrepFileIssues{{File: "abc123", Path: "lodash.js",
+				Issues: []dtos.IssueItem{{RuleID: "js.rule", From: "10", To: "15", Severity: "ERROR"}}}}},
+			{Purl: "pkg:npm/left-pad", Version: "1.3.0"},
+		}
+		if !reflect.DeepEqual(issues.items, want) || !issues.legacy {
+			t.Errorf("componentsIssues() items = %+v, legacy = %v, want %+v", issues.items, issues.legacy, want)
+		}
+	}
+	// The stream endpoint is only tried once
+	if want := []string{componentsIssuesPath, legacyComponentsIssuesPath, legacyComponentsIssuesPath}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("componentsIssues() requested %v, want %v", paths, want)
+	}
+}
+
+func TestRESTErrors(t *testing.T) {
+	tests := []struct {
+		name            string
+		code            int
+		body            string
+		wantRetryable   bool
+		wantUnavailable bool
+	}{
+		{name: "bad request", code: http.StatusBadRequest, body: `{"status":{"status":"FAILED","message":"invalid purl"}}`},
+		{name: "overloaded", code: http.StatusServiceUnavailable, wantRetryable: true},
+		{name: "rate limited", code: http.StatusTooManyRequests, wantRetryable: true},
+		{name: "no endpoint", code: http.StatusNotFound, wantUnavailable: true},
+		{name: "failed status", code: http.StatusOK, body: `{"status":{"status":4,"message":"failed"}}`},
+		{name: "interrupted", code: http.StatusOK, body: `{"component":{"purl":"pkg:npm/component-0"}}`, wantRetryable: true},
+		{name: "invalid message", code: http.StatusOK, body: `{"component":`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.code)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+			rt, err := newRESTTransport(Config{RESTURL: srv.URL}, nil)
+			if err != nil {
+				t.Fatalf("newRESTTransport() error = %v", err)
+			}
+			_, err = rt.componentsIssues(context.Background(), testComponents(1))
+			if err == nil {
+				t.Fatalf("componentsIssues() expected an error")
+			}
+			if isRetryable(err) != tt.wantRetryable || isUnavailable(err) != tt.wantUnavailable {
+				t.Errorf("componentsIssues() error %v retryable = %v, unavailable = %v", err, isRetryable(err), isUnavailable(err))
+			}
+		})
+	}
+	// Connection failures can be retried and/or handed over to another transport
+	rt, _ := newRESTTransport(Config{RESTURL: "http://127.0.0.1:1"}, nil)
+	if _, err := rt.componentsIssues(context.Background(), testComponents(1)); !isRetryable(err) || !isUnavailable(err) {
+		t.Errorf("componentsIssues() connection error = %v", err)
+	}
+}
+
+func TestRESTWithCABundle(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(restResponse))
+	}))
+	defer srv.Close()
+	// Without the CA bundle the self-signed test certificate must be rejected
+	c, err := NewClient(zlog.S, Config{RESTURL: srv.URL, MaxRetries: 3})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err = c.GetIssues(context.Background(), testComponents(1)); err == nil || isRetryable(err) {
+		t.Errorf("GetIssues() should fail (without retrying) to verify the server certificate: %v", err)
+	}
+	_ = c.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err = os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c, err = NewClient(zlog.S, Config{RESTURL: srv.URL, CACertFile: caFile})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer func() { _ = c.Close() }()
+	if output, err := c.GetIssues(context.Background(), testComponents(1)); err != nil || len(output.Purls) != 2 {
+		t.Errorf("GetIssues() with CA bundle = %+v, %v", output, err)
+	}
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	pbx "scanoss.com/semgrep/api/semgrepextv2"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// readIssueStream collects the components of a components issues stream (in request order), reading messages using next
+// until it reports io.EOF. A stream ending without a status message was interrupted, so it's worth retrying.
+// The KB snapshot is taken from the progress messages.
+func readIssueStream(transport string, next func() (*pbx.ComponentsIssuesStreamResponse, error)) (remoteIssues, error) {
+	var issues remoteIssues
+	for {
+		message, err := next()
+		if errors.Is(err, io.EOF) {
+			return remoteIssues{}, &remoteError{err: fmt.Errorf("%v stream ended without a status", transport), retryable: true}
+		}
+		if err != nil {
+			return remoteIssues{}, err
+		}
+		switch {
+		case message.GetComponent() != nil:
+			issues.items = append(issues.items, componentFromPb(message.GetComponent()))
+		case message.GetProgress() != nil:
+			if kb := message.GetProgress().GetKb(); kb != nil {
+				issues.kb = &dtos.KBInfo{Version: kb.GetVersion(), SnapshotDate: kb.GetSnapshotDate(), EngineVersion: kb.GetEngineVersion(),
+					RulesetVersion: kb.GetRulesetVersion()}
+			}
+		case message.GetStatus() != nil:
+			if st := message.GetStatus(); st.GetStatus() != pbx.StatusCode_SUCCESS && st.GetStatus() != pbx.StatusCode_SUCCEEDED_WITH_WARNINGS {
+				return remoteIssues{}, fmt.Errorf("%v request failed: %v (%v)", transport, st.GetMessage(), st.GetStatus())
+			}
+			return issues, nil
+		}
+	}
+}
+
+// componentFromPb converts a streamed component (and all its annotations) back to the internal format.
+func componentFromPb(c *pbx.ComponentIssueInfo) dtos.SemgrepOutputItem {
+	item := dtos.SemgrepOutputItem{Purl: c.GetPurl(), Version: c.GetVersion(), AnalysedFiles: int(c.GetAnalysedFiles()), RiskScore: c.GetRiskScore()}
+	for _, f := range c.GetFiles() {
+		file := dtos.SemgrepFileIssues{File: f.GetFileMd5(), Path: f.GetPath(), IssueCount: int(f.GetIssueCount()),
+			Issues: make([]dtos.IssueItem, 0, len(f.GetIssues()))}
+		for _, i := range f.GetIssues() {
+			file.Issues = append(file.Issues, dtos.IssueItem{RuleID: i.GetRuleId(), From: i.GetFrom(), To: i.GetTo(), Severity: i.GetSeverity(),
+				Fingerprint: i.GetFingerprint(), Triage: issueTriageFromPb(i.GetTriage()), Rule: ruleMetadataFromPb(i.GetRule())})
+		}
+		item.Files = append(item.Files, file)
+	}
+	if p := c.GetPeer(); p != nil {
+		item.Peer = &dtos.PeerRank{Ecosystem: p.GetEcosystem(), Density: p.GetDensity(), WorseThan: int(p.GetWorseThan()),
+			Components: int(p.GetComponents()), Summary: p.GetSummary()}
+	}
+	if p := c.GetProvenance(); p != nil {
+		item.Provenance = &dtos.Provenance{Source: p.GetSource(), ScanDate: p.GetScanDate(), EngineVersion: p.GetEngineVersion(),
+			RulesetVersion: p.GetRulesetVersion()}
+	}
+	if p := c.GetPresence(); p != nil {
+		item.Presence = &dtos.Presence{Files: int(p.GetFiles()), Percent: p.GetPercent()}
+	}
+	return item
+}
+
+// issueTriageFromPb converts the triage decision of a streamed finding (if any) back to the internal format.
+func issueTriageFromPb(t *pbx.IssueTriage) *dtos.IssueTriage {
+	if t == nil {
+		return nil
+	}
+	return &dtos.IssueTriage{ID: t.GetId(), State: t.GetState(), Project: t.GetProject(), Author: t.GetAuthor(),
+		Comment: t.GetComment(), UpdatedAt: t.GetUpdatedAt()}
+}
+
+// ruleMetadataFromPb converts the rule details of a streamed finding (if any) back to the internal format.
+func ruleMetadataFromPb(r *pbx.RuleMetadata) *dtos.RuleMetadata {
+	if r == nil {
+		return nil
+	}
+	return &dtos.RuleMetadata{Title: r.GetTitle(), Message: r.GetMessage(), CWE: r.GetCwe(), OWASP: r.GetOwasp(),
+		Confidence: r.GetConfidence(), Likelihood: r.GetLikelihood(), Impact: r.GetImpact(), References: r.GetReferences()}
+}
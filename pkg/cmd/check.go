@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"scanoss.com/semgrep/pkg/dtos"
+	"scanoss.com/semgrep/pkg/gate"
+	"scanoss.com/semgrep/pkg/outputs"
+)
+
+const maxSummaryExamples = 5 // Maximum number of findings listed per violation in the check summary
+
+// checkOptions holds the command line options of the check command.
+type checkOptions struct {
+	scanOptions
+	policyFile  string
+	maxErrors   int
+	maxWarnings int
+	maxInfo     int
+	denyRules   stringList
+	sarifFile   string
+	junitFile   string
+}
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// newCheckFlags creates the flag set of the check command, storing the values in the supplied options.
+func newCheckFlags(opts *checkOptions, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addLookupFlags(fs, &opts.scanOptions)
+	fs.StringVar(&opts.policyFile, "policy", "", "YAML policy file with the thresholds (max_errors, max_warnings, max_info, deny_rules)")
+	fs.IntVar(&opts.maxErrors, "max-errors", 0, "Maximum number of ERROR findings allowed (-1 for no limit)")
+	fs.IntVar(&opts.maxWarnings, "max-warnings", -1, "Maximum number of WARNING findings allowed (-1 for no limit)")
+	fs.IntVar(&opts.maxInfo, "max-info", -1, "Maximum number of INFO findings allowed (-1 for no limit)")
+	fs.Var(&opts.denyRules, "deny-rule", "Rule ID glob that must not be present (can be repeated)")
+	fs.StringVar(&opts.sarifFile, "sarif", "", "Also write the results to this SARIF file")
+	fs.StringVar(&opts.junitFile, "junit", "", "Also write the results to this JUnit XML file")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: %v check [options] [purl ...]\n\n", cliName)
+		_, _ = fmt.Fprintf(stderr, "Looks up the Semgrep issues (like scan) and exits with %d if any threshold is breached (%d on errors).\n", exitViolation, exitError)
+		_, _ = fmt.Fprintf(stderr, "Without a policy, any ERROR finding fails the check. Threshold flags override the policy file.\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// runCheck looks up the Semgrep issues of the requested components and checks them against the gate thresholds.
+func runCheck(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	opts := checkOptions{}
+	fs := newCheckFlags(&opts, stderr)
+	var err error
+	if opts.purls, err = parseInterleaved(fs, args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+	policy, err := checkPolicy(fs, opts)
+	if err != nil {
+		return err
+	}
+	components, err := scanComponents(opts.scanOptions, stdin)
+	if err != nil {
+		return err
+	}
+	output, err := lookupIssues(opts.scanOptions, components)
+	if err != nil {
+		return err
+	}
+	if err = processOutput(opts.scanOptions, &output, stderr); err != nil {
+		return err
+	}
+	if err = writeCheckReports(opts, output); err != nil {
+		return err
+	}
+	result := policy.Evaluate(output)
+	if err = result.WriteSummary(stdout, maxSummaryExamples); err != nil {
+		return fmt.Errorf("failed to write check summary: %v", err)
+	}
+	if !result.Passed() {
+		return errPolicyViolation
+	}
+	return nil
+}
+
+// checkPolicy builds the gate policy from the policy file (or default), overriding it with any threshold flags set.
+func checkPolicy(fs *flag.FlagSet, opts checkOptions) (gate.Policy, error) {
+	policy := gate.DefaultPolicy()
+	if len(opts.policyFile) > 0 {
+		var err error
+		if policy, err = gate.LoadPolicy(opts.policyFile); err != nil {
+			return gate.Policy{}, err
+		}
+	}
+	limit := func(value int) *int {
+		if value < 0 {
+			return nil
+		}
+		return gate.IntPtr(value)
+	}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "max-errors":
+			policy.MaxErrors = limit(opts.maxErrors)
+		case "max-warnings":
+			policy.MaxWarnings = limit(opts.maxWarnings)
+		case "max-info":
+			policy.MaxInfo = limit(opts.maxInfo)
+		case "deny-rule":
+			policy.DenyRules = append(policy.DenyRules, opts.denyRules...)
+		}
+	})
+	if err := policy.Validate(); err != nil {
+		return gate.Policy{}, err
+	}
+	return policy, nil
+}
+
+// writeCheckReports writes the optional SARIF/JUnit reports requested alongside the check.
+func writeCheckReports(opts checkOptions, output dtos.SemgrepOutput) error {
+	for _, report := range []struct{ format, file string }{{outputs.FormatSARIF, opts.sarifFile}, {outputs.FormatJUnit, opts.junitFile}} {
+		if len(report.file) == 0 {
+			continue
+		}
+		if err := writeOutput(report.format, report.file, output, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
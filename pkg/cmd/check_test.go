@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newCheckServer starts a REST server returning one ERROR and two WARNING findings.
+func newCheckServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"component":{"purl":"pkg:npm/lodash","version":"4.17.21","files":[` +
+			`{"fileMd5":"abc123","path":"lodash.js","issues":[` +
+			`{"ruleId":"js.security.eval","from":"1","to":"2","severity":"ERROR"},` +
+			`{"ruleId":"js.correctness.assign","from":"5","to":"5","severity":"WARNING"},` +
+			`{"ruleId":"js.correctness.assign","from":"9","to":"9","severity":"WARNING"}]}]}}` + "\n" +
+			`{"status":{"status":"SUCCESS","message":"Success"}}` + "\n"))
+	}))
+}
+
+func TestRunCheck(t *testing.T) {
+	srv := newCheckServer()
+	defer srv.Close()
+	dir := t.TempDir()
+	policyFile := filepath.Join(dir, "policy.yml")
+	if err := os.WriteFile(policyFile, []byte("max_errors: 1\nmax_warnings: 1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	baselineFile := filepath.Join(dir, "baseline.json")
+	if err := os.WriteFile(baselineFile, []byte(`{"purls":[{"purl":"pkg:npm/lodash","version":"4.17.20","files":[{"fileMD5":"aaa111",`+
+		`"path":"lodash.js","issues":[{"ruleID":"js.security.eval","from":"3","to":"4","severity":"ERROR"}]}]}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		args        []string
+		wantCode    int
+		wantSummary string
+	}{
+		{name: "default fails on error", wantCode: exitViolation, wantSummary: "1 ERROR finding(s) exceed the limit of 0"},
+		{name: "no limits", args: []string{"-max-errors", "-1"}, wantCode: exitSuccess, wantSummary: "Semgrep check PASSED"},
+		{name: "warning limit", args: []string{"-max-errors", "1", "-max-warnings", "1"}, wantCode: exitViolation,
+			wantSummary: "2 WARNING finding(s) exceed the limit of 1"},
+		{name: "denied rule", args: []string{"-max-errors", "5", "-deny-rule", "js.security.*"}, wantCode: exitViolation,
+			wantSummary: "1 finding(s) match denied rule 'js.security.*'"},
+		{name: "policy file", args: []string{"-policy", policyFile}, wantCode: exitViolation, wantSummary: "2 WARNING finding(s) exceed the limit of 1"},
+		{name: "flags override policy", args: []string{"-policy", policyFile, "-max-warnings", "2"}, wantCode: exitSuccess},
+		{name: "invalid policy", args: []string{"-policy", filepath.Join(dir, "missing.yml")}, wantCode: exitError},
+		{name: "baseline", args: []string{"-baseline", baselineFile}, wantCode: exitSuccess,
+			wantSummary: "0 error, 2 warning, 0 info finding(s) new since baseline (1 unchanged, 0 fixed)"},
+		{name: "invalid baseline", args: []string{"-baseline", policyFile}, wantCode: exitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"check", "-rest-url", srv.URL, "-retries", "0", "pkg:npm/lodash@4.17.21"}, tt.args...)
+			err := runCli(args, nil, &stdout, &stderr)
+			if code := ExitCode(err); code != tt.wantCode {
+				t.Fatalf("runCli() check exit code = %v (%v), want %v\n%v", code, err, tt.wantCode, stdout.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantSummary) {
+				t.Errorf("runCli() check summary missing %q:\n%v", tt.wantSummary, stdout.String())
+			}
+		})
+	}
+}
+
+func TestRunCheckReports(t *testing.T) {
+	srv := newCheckServer()
+	defer srv.Close()
+	dir := t.TempDir()
+	sarifFile, junitFile := filepath.Join(dir, "results.sarif"), filepath.Join(dir, "results.xml")
+	var stdout, stderr bytes.Buffer
+	err := runCli([]string{"check", "-rest-url", srv.URL, "-sarif", sarifFile, "-junit", junitFile, "pkg:npm/lodash"}, nil, &stdout, &stderr)
+	if !IsPolicyViolation(err) {
+		t.Fatalf("runCli() check error = %v, want policy violation", err)
+	}
+	if data, err := os.ReadFile(sarifFile); err != nil || !bytes.Contains(data, []byte(`"ruleId": "js.security.eval"`)) {
+		t.Errorf("runCli() check SARIF report = %v, %v", string(data), err)
+	}
+	if data, err := os.ReadFile(junitFile); err != nil || !bytes.Contains(data, []byte(`<testsuites name="SCANOSS Semgrep" tests="3" failures="1" skipped="0">`)) {
+		t.Errorf("runCli() check JUnit report = %v, %v", string(data), err)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if ExitCode(nil) != exitSuccess || ExitCode(errPolicyViolation) != exitViolation ||
+		ExitCode(errUsage) != exitError || ExitCode(errors.New("failed")) != exitError {
+		t.Errorf("ExitCode() returned unexpected codes")
+	}
+}
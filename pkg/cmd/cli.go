@@ -16,4 +16,520 @@
 
 package cmd
 
-// TODO Add CLI code processing here
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.uber.org/zap/zapcore"
+	"scanoss.com/semgrep/pkg/baseline"
+	"scanoss.com/semgrep/pkg/client"
+	"scanoss.com/semgrep/pkg/dtos"
+	"scanoss.com/semgrep/pkg/ignore"
+	"scanoss.com/semgrep/pkg/inputs"
+	"scanoss.com/semgrep/pkg/outputs"
+	"scanoss.com/semgrep/pkg/risk"
+	"scanoss.com/semgrep/pkg/taxonomy"
+	"scanoss.com/semgrep/pkg/usecase"
+)
+
+const (
+	cliName   = "scanoss-semgrep"
+	apiKeyEnv = "SCANOSS_API_KEY" // Environment variable holding the remote service API key
+)
+
+// CLI process exit codes.
+const (
+	exitSuccess   = 0
+	exitViolation = 1 // check command thresholds breached
+	exitError     = 2 // invalid usage or failure to run the command
+)
+
+var (
+	// errUsage is returned when the CLI has been called incorrectly (usage has already been displayed).
+	errUsage = errors.New("invalid command line usage")
+	// errPolicyViolation is returned by the check command when the gate policy thresholds are breached.
+	errPolicyViolation = errors.New("semgrep check policy violated")
+)
+
+// IsUsageError checks if the CLI error was caused by invalid usage (in which case the usage has already been displayed).
+func IsUsageError(err error) bool {
+	return errors.Is(err, errUsage)
+}
+
+// IsPolicyViolation checks if the CLI error was caused by a breached check policy (the summary has already been displayed).
+func IsPolicyViolation(err error) bool {
+	return errors.Is(err, errPolicyViolation)
+}
+
+// ExitCode returns the process exit code matching the result of the CLI.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitSuccess
+	case IsPolicyViolation(err):
+		return exitViolation
+	default:
+		return exitError
+	}
+}
+
+// cliCommand describes a single CLI subcommand.
+type cliCommand struct {
+	description string
+	run         func(args []string, stdin io.Reader, stdout, stderr io.Writer) error
+}
+
+// cliCommands lists all the supported CLI subcommands.
+var cliCommands = map[string]cliCommand{
+	"check":          {description: "Check the Semgrep issues of a list of components against CI gate thresholds", run: runCheck},
+	"import-rules":   {description: "Import Semgrep rule YAML files into the rule metadata catalog", run: runImportRules},
+	"import-semgrep": {description: "Import semgrep --json results of a component version into the local issue store", run: runImportSemgrep},
+	"kb-info":        {description: "Display the KB provenance or load it from a manifest", run: runKBInfo},
+	"peer-stats":     {description: "Refresh the ecosystem finding density statistics used to rank components", run: runPeerStats},
+	"rule-index":     {description: "Rebuild the rule ID to component index used by rule searches", run: runRuleIndex},
+	"rule-search":    {description: "List the KB component versions with findings of a rule", run: runRuleSearch},
+	"scan":           {description: "Look up the Semgrep issues of a list of components", run: runScan},
+	"version":        {description: "Display the current version", run: runVersion},
+}
+
+// RunCli runs the Semgrep CLI using the supplied command line arguments.
+func RunCli(args []string) error {
+	return runCli(args, os.Stdin, os.Stdout, os.Stderr)
+}
+
+// runCli dispatches the command line arguments to the requested subcommand.
+func runCli(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		cliUsage(stderr)
+		return errUsage
+	}
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		cliUsage(stdout)
+		return nil
+	}
+	command, ok := cliCommands[args[0]]
+	if !ok {
+		_, _ = fmt.Fprintf(stderr, "Unknown command: %v\n\n", args[0])
+		cliUsage(stderr)
+		return errUsage
+	}
+	return command.run(args[1:], stdin, stdout, stderr)
+}
+
+// cliUsage displays the list of available subcommands.
+func cliUsage(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "Usage: %v <command> [options]\n\nCommands:\n", cliName)
+	names := make([]string, 0, len(cliCommands))
+	for name := range cliCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		_, _ = fmt.Fprintf(w, "  %-14v %v\n", name, cliCommands[name].description)
+	}
+	_, _ = fmt.Fprintf(w, "\nRun '%v <command> -h' for the options of each command.\n", cliName)
+}
+
+// runVersion displays the current version.
+func runVersion(_ []string, _ io.Reader, stdout, _ io.Writer) error {
+	_, _ = fmt.Fprintf(stdout, "Version: %v\n", strings.TrimSpace(version))
+	return nil
+}
+
+// scanOptions holds the command line options of the scan (and check) command.
+type scanOptions struct {
+	jsonConfig string
+	envConfig  string
+	debug      bool
+	input      string
+	format     string
+	output     string
+	ignoreFile string
+	baseline   string
+	inventory  string
+	image      string
+	project    string
+	sortRisk   bool
+	minRisk    float64
+	cwe        stringList
+	owasp      stringList
+	groupBy    string
+	purls      []string
+	remote     client.Config // Remote service details (local DB & LDB lookup if no server is specified)
+}
+
+// newScanFlags creates the flag set of the scan command, storing the values in the supplied options.
+func newScanFlags(opts *scanOptions, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("scan", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addLookupFlags(fs, opts)
+	fs.StringVar(&opts.format, "format", outputs.FormatJSON, fmt.Sprintf("Output format (%v)", strings.Join(outputs.Formats(), ", ")))
+	fs.StringVar(&opts.output, "output", "", "Write the results to this file instead of stdout")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: %v scan [options] [purl ...]\n\n", cliName)
+		_, _ = fmt.Fprintf(stderr, "Purls are taken from the arguments, the -input file, the -image packages or stdin (if nothing else is supplied).\n")
+		_, _ = fmt.Fprintf(stderr, "The DB & LDB are queried directly, unless a remote -server and/or -rest-url is supplied.\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// addLookupFlags adds the options required to select the components and where to look them up (locally or remotely).
+func addLookupFlags(fs *flag.FlagSet, opts *scanOptions) {
+	fs.StringVar(&opts.jsonConfig, "json-config", "", "Application JSON config")
+	fs.StringVar(&opts.envConfig, "env-config", "", "Application dot-ENV config")
+	fs.BoolVar(&opts.debug, "debug", false, "Enable debug")
+	fs.StringVar(&opts.input, "input", "", "File containing the purls to scan (JSON or one purl per line). Use '-' for stdin")
+	fs.StringVar(&opts.image, "image", "", "Also scan the packages installed in this container image (docker save or OCI layout tarball, or OCI layout directory)")
+	fs.StringVar(&opts.project, "project", "", "Project used to select project specific triage decisions (sent as X-Scanoss-Project to a remote service)")
+	fs.StringVar(&opts.ignoreFile, "ignore-file", "", fmt.Sprintf("Ignore file listing accepted findings (default %v, if present)", ignore.DefaultFilename))
+	fs.StringVar(&opts.baseline, "baseline", "", "Previous results (JSON or SARIF) to compare against. Only new findings are reported")
+	fs.StringVar(&opts.inventory, "inventory", "", "Only report findings in files present in this codebase (a directory to hash or a file of MD5s, i.e. md5sum output). Local lookups only")
+	fs.BoolVar(&opts.sortRisk, "sort-risk", false, "Sort the components by descending risk score")
+	fs.Float64Var(&opts.minRisk, "min-risk", 0, "Only report components with at least this risk score")
+	fs.Var(&opts.cwe, "cwe", "Only report findings of this CWE (i.e. CWE-78). Can be repeated or comma separated")
+	fs.Var(&opts.owasp, "owasp", "Only report findings of this OWASP category (i.e. A03 or A03:2021). Can be repeated or comma separated")
+	fs.StringVar(&opts.groupBy, "group-by", "", fmt.Sprintf("Also group the findings by category (%v, %v)", taxonomy.GroupCWE, taxonomy.GroupOWASP))
+	addRemoteFlags(fs, &opts.remote)
+}
+
+// addRemoteFlags adds the options required to query a remote Semgrep service.
+func addRemoteFlags(fs *flag.FlagSet, remote *client.Config) {
+	fs.StringVar(&remote.GRPCAddress, "server", "", "Query a remote Semgrep gRPC service (host:port)")
+	fs.StringVar(&remote.RESTURL, "rest-url", "", "Query a remote Semgrep REST service (http(s)://host:port). Used as a fallback if -server is also supplied")
+	fs.BoolVar(&remote.UseTLS, "tls", false, "Use TLS to connect to the remote gRPC service")
+	fs.StringVar(&remote.CACertFile, "ca-cert", "", "PEM CA bundle used to verify the remote service certificate (implies -tls)")
+	fs.StringVar(&remote.APIKey, "api-key", "", fmt.Sprintf("API key for the remote service (default $%v)", apiKeyEnv))
+	fs.DurationVar(&remote.Timeout, "timeout", client.DefaultTimeout, "Timeout for each remote request")
+	fs.IntVar(&remote.MaxRetries, "retries", client.DefaultRetries, "Number of times to retry a failed remote request")
+	fs.IntVar(&remote.ChunkSize, "chunk-size", client.DefaultChunkSize, "Maximum number of components sent in each remote request")
+}
+
+// parseInterleaved parses the flags of a subcommand, allowing them to be mixed with positional arguments.
+func parseInterleaved(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// runScan looks up the Semgrep issues of the requested components (locally or remotely) and writes the results.
+func runScan(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	opts := scanOptions{}
+	fs := newScanFlags(&opts, stderr)
+	var err error
+	if opts.purls, err = parseInterleaved(fs, args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+	if !outputs.IsSupported(opts.format) {
+		return fmt.Errorf("unsupported output format '%v'. Supported formats: %v", opts.format, strings.Join(outputs.Formats(), ", "))
+	}
+	components, err := scanComponents(opts, stdin)
+	if err != nil {
+		return err
+	}
+	output, err := lookupIssues(opts, components)
+	if err != nil {
+		return err
+	}
+	if err = processOutput(opts, &output, stderr); err != nil {
+		return err
+	}
+	return writeOutput(opts.format, opts.output, output, stdout)
+}
+
+// lookupIssues gets the Semgrep issues of the components, either from a remote service or the local DB & LDB.
+func lookupIssues(opts scanOptions, components []dtos.ComponentDTO) (dtos.SemgrepOutput, error) {
+	outputs.ToolVersion = strings.TrimSpace(version)
+	if len(opts.remote.GRPCAddress) > 0 || len(opts.remote.RESTURL) > 0 {
+		if len(opts.inventory) > 0 {
+			return dtos.SemgrepOutput{}, errors.New("an inventory is only supported for local lookups")
+		}
+		return lookupRemoteIssues(opts, components)
+	}
+	var inventory usecase.Inventory
+	if len(opts.inventory) > 0 {
+		var err error
+		if inventory, err = loadInventory(opts.inventory); err != nil {
+			return dtos.SemgrepOutput{}, err
+		}
+	}
+	cfg, err := loadConfig(opts.jsonConfig, opts.envConfig, opts.debug)
+	if err != nil {
+		return dtos.SemgrepOutput{}, fmt.Errorf("failed to load config: %v", err)
+	}
+	if err = setupCliLogger(cfg.App.Debug); err != nil {
+		return dtos.SemgrepOutput{}, err
+	}
+	defer zlog.SyncZap()
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return dtos.SemgrepOutput{}, err
+	}
+	defer closeDBConnection(db)
+	if err = setupLDB(cfg); err != nil {
+		return dtos.SemgrepOutput{}, err
+	}
+	zlog.S.Debugf("Scanning %v components", len(components))
+	ctx := usecase.ContextWithProject(context.Background(), opts.project)
+	output, err := usecase.NewSemgrep(db, usecase.StoresFromConfig(db, cfg)).GetIssuesInInventory(ctx, zlog.S, components, inventory)
+	if err != nil {
+		return dtos.SemgrepOutput{}, fmt.Errorf("failed to get semgrep issues: %v", err)
+	}
+	return output, nil
+}
+
+// lookupRemoteIssues gets the Semgrep issues of the components from a remote Semgrep service.
+func lookupRemoteIssues(opts scanOptions, components []dtos.ComponentDTO) (dtos.SemgrepOutput, error) {
+	if err := setupCliLogger(opts.debug); err != nil {
+		return dtos.SemgrepOutput{}, err
+	}
+	defer zlog.SyncZap()
+	if len(opts.remote.APIKey) == 0 {
+		opts.remote.APIKey = os.Getenv(apiKeyEnv)
+	}
+	opts.remote.Project = opts.project
+	c, err := client.NewClient(zlog.S, opts.remote)
+	if err != nil {
+		return dtos.SemgrepOutput{}, err
+	}
+	defer func() {
+		if closeErr := c.Close(); closeErr != nil {
+			zlog.S.Warnf("Problem closing remote connection: %v", closeErr)
+		}
+	}()
+	zlog.S.Debugf("Scanning %v components remotely", len(components))
+	output, err := c.GetIssues(context.Background(), components)
+	if err != nil {
+		return dtos.SemgrepOutput{}, fmt.Errorf("failed to get semgrep issues: %v", err)
+	}
+	return output, nil
+}
+
+// processOutput applies the ignore file, risk scoring, baseline, category filter, risk ranking and grouping options to the looked up issues.
+func processOutput(opts scanOptions, output *dtos.SemgrepOutput, stderr io.Writer) error {
+	categories, err := taxonomy.ParseFilter(opts.cwe, opts.owasp)
+	if err != nil {
+		return err
+	}
+	groupBy := strings.ToLower(opts.groupBy)
+	if err = taxonomy.ValidateGroupBy(groupBy); err != nil {
+		return err
+	}
+	if !categories.Empty() || len(groupBy) > 0 {
+		if err = taxonomy.CheckRuleMetadata(*output); err != nil {
+			return err
+		}
+	}
+	if err = applyIgnoreFile(opts.ignoreFile, *output, stderr); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(opts.jsonConfig, opts.envConfig, false)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	weights, err := risk.FromConfig(cfg)
+	if err != nil {
+		return err
+	}
+	weights.Apply(output)
+	if err = applyBaseline(opts.baseline, output); err != nil {
+		return err
+	}
+	categories.Apply(output)
+	if opts.minRisk > 0 {
+		risk.Filter(output, opts.minRisk)
+	}
+	if opts.sortRisk {
+		risk.Sort(output)
+	}
+	output.Groups, _ = taxonomy.Group(*output, groupBy) // grouping already validated
+	return nil
+}
+
+// applyIgnoreFile marks the findings accepted in the ignore file as suppressed, warning about any expired entries.
+// The default ignore file is only used if it's present in the current directory.
+func applyIgnoreFile(filename string, output dtos.SemgrepOutput, stderr io.Writer) error {
+	if len(filename) == 0 {
+		if _, err := os.Stat(ignore.DefaultFilename); err != nil {
+			return nil
+		}
+		filename = ignore.DefaultFilename
+	}
+	file, err := ignore.Load(filename)
+	if err != nil {
+		return err
+	}
+	summary := file.Apply(output, time.Now())
+	for _, entry := range summary.Entries {
+		_, _ = fmt.Fprintf(stderr, "WARNING: suppression for %v%v expired on %v (%v). Matching findings are reported again\n",
+			entry.Purl, ignoreEntryScope(entry), entry.Expires, entry.Reason)
+	}
+	return nil
+}
+
+// applyBaseline removes the findings already present in the baseline file (if supplied), leaving only the new ones.
+func applyBaseline(filename string, output *dtos.SemgrepOutput) error {
+	if len(filename) == 0 {
+		return nil
+	}
+	b, err := baseline.Load(filename)
+	if err != nil {
+		return err
+	}
+	b.Compare(output)
+	return nil
+}
+
+// ignoreEntryScope describes the rule and path an ignore file entry is restricted to (if any).
+func ignoreEntryScope(entry ignore.Entry) string {
+	var scope []string
+	if len(entry.Rule) > 0 {
+		scope = append(scope, "rule "+entry.Rule)
+	}
+	if len(entry.Path) > 0 {
+		scope = append(scope, "path "+entry.Path)
+	}
+	if len(scope) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(scope, ", ") + ")"
+}
+
+// setupCliLogger sets up a logger that only reports warnings and errors (to stderr), unless debug is requested.
+func setupCliLogger(debug bool) error {
+	var err error
+	if debug {
+		err = zlog.NewSugaredDevLogger()
+	} else {
+		err = zlog.NewSugaredProdLoggerLevel(zapcore.WarnLevel)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load logger: %v", err)
+	}
+	return nil
+}
+
+// collectComponents gathers the components to scan from the command line purls, an input file or stdin.
+// Stdin is only read if requested explicitly ('-') or if no other source is supplied and it's not a terminal.
+func collectComponents(purls []string, input string, stdin io.Reader) ([]dtos.ComponentDTO, error) {
+	var components []dtos.ComponentDTO
+	readStdin := input == "-"
+	for _, p := range purls {
+		if p == "-" {
+			readStdin = true
+			continue
+		}
+		if !strings.HasPrefix(p, "pkg:") {
+			return nil, fmt.Errorf("invalid purl supplied: %v", p)
+		}
+		components = append(components, dtos.ComponentDTO{Purl: p})
+	}
+	if len(input) > 0 && input != "-" {
+		data, err := os.ReadFile(input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read input file %v: %v", input, err)
+		}
+		fileComponents, err := inputs.ParsePurlList(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse input file %v: %v", input, err)
+		}
+		components = append(components, fileComponents...)
+	}
+	if !readStdin && len(components) == 0 && !isTerminal(stdin) {
+		readStdin = true
+	}
+	if readStdin {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stdin: %v", err)
+		}
+		stdinComponents, err := inputs.ParsePurlList(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse stdin: %v", err)
+		}
+		components = append(components, stdinComponents...)
+	}
+	if len(components) == 0 {
+		return nil, errors.New("no purls supplied. Pass them as arguments, with -input or via stdin")
+	}
+	return components, nil
+}
+
+// scanComponents gathers the components to scan from the command line purls, input file or stdin (see collectComponents),
+// along with the packages installed in the container image (if supplied). Stdin is not read implicitly when an image is supplied.
+func scanComponents(opts scanOptions, stdin io.Reader) ([]dtos.ComponentDTO, error) {
+	if len(opts.image) == 0 {
+		return collectComponents(opts.purls, opts.input, stdin)
+	}
+	inventory, err := inputs.ParseContainerImage(opts.image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read container image %v: %v", opts.image, err)
+	}
+	components := inventory.Components()
+	if len(opts.purls) > 0 || len(opts.input) > 0 {
+		others, err := collectComponents(opts.purls, opts.input, stdin)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, others...)
+	}
+	if len(components) == 0 {
+		return nil, fmt.Errorf("no packages found in container image %v", opts.image)
+	}
+	return components, nil
+}
+
+// isTerminal checks if the supplied reader is an interactive terminal (or not a file at all).
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return true
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// writeOutput exports the Semgrep output in the requested format, to the given file or stdout.
+func writeOutput(format, outputFile string, output dtos.SemgrepOutput, stdout io.Writer) error {
+	data, err := outputs.Export(format, output)
+	if err != nil {
+		return err
+	}
+	if len(outputFile) > 0 {
+		if err = os.WriteFile(outputFile, data, 0o600); err != nil {
+			return fmt.Errorf("failed to write output file %v: %v", outputFile, err)
+		}
+		return nil
+	}
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		data = append(data, '\n')
+	}
+	if _, err = stdout.Write(data); err != nil {
+		return fmt.Errorf("failed to write output: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,325 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+	"scanoss.com/semgrep/pkg/taxonomy"
+)
+
+func TestRunCliUsage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := runCli(nil, nil, &stdout, &stderr); !IsUsageError(err) {
+		t.Errorf("runCli() no args error = %v, want usage error", err)
+	}
+	if err := runCli([]string{"unknown"}, nil, &stdout, &stderr); !IsUsageError(err) || !strings.Contains(stderr.String(), "Unknown command") {
+		t.Errorf("runCli() unknown command error = %v", err)
+	}
+	if err := runCli([]string{"help"}, nil, &stdout, &stderr); err != nil || !strings.Contains(stdout.String(), "scan") {
+		t.Errorf("runCli() help error = %v, output = %v", err, stdout.String())
+	}
+	if err := runCli([]string{"scan", "-format", "xml", "pkg:npm/lodash"}, nil, &stdout, &stderr); err == nil || IsUsageError(err) {
+		t.Errorf("runCli() scan with bad format error = %v", err)
+	}
+	if err := runCli([]string{"scan", "-bogus"}, nil, &stdout, &stderr); !IsUsageError(err) {
+		t.Errorf("runCli() scan with bad flag error = %v", err)
+	}
+	if err := runCli([]string{"import-rules"}, nil, &stdout, &stderr); !IsUsageError(err) {
+		t.Errorf("runCli() import-rules without rules error = %v", err)
+	}
+	if err := runCli([]string{"import-rules", "missing-rules.yml"}, nil, &stdout, &stderr); err == nil || IsUsageError(err) {
+		t.Errorf("runCli() import-rules with a missing file error = %v", err)
+	}
+	if err := runCli([]string{"import-semgrep", "semgrep.json"}, nil, &stdout, &stderr); !IsUsageError(err) {
+		t.Errorf("runCli() import-semgrep without a purl error = %v", err)
+	}
+	if err := runCli([]string{"import-semgrep", "-purl", "pkg:npm/a@1.0.0", "missing.json"}, nil, &stdout, &stderr); err == nil || IsUsageError(err) {
+		t.Errorf("runCli() import-semgrep with a missing file error = %v", err)
+	}
+	if err := runCli([]string{"rule-search", "pkg:npm/lodash"}, nil, &stdout, &stderr); !IsUsageError(err) {
+		t.Errorf("runCli() rule-search without a rule error = %v", err)
+	}
+	if err := runCli([]string{"rule-search", "-rule", "js.eval", "lodash"}, nil, &stdout, &stderr); err == nil || IsUsageError(err) {
+		t.Errorf("runCli() rule-search with a bad purl error = %v", err)
+	}
+}
+
+func TestRunImportSemgrepLDB(t *testing.T) {
+	dir := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	err := runCli([]string{"import-semgrep", "-purl", "pkg:github/acme/tool@1.0.0", "-root", "../ingest/tests/checkout", "-target", "ldb",
+		"-output", dir, "../ingest/tests/semgrep.json"}, nil, &stdout, &stderr)
+	if err != nil || !strings.Contains(stdout.String(), "Wrote 3 file(s) and 1 issue(s)") || !strings.Contains(stderr.String(), "1 finding(s)") {
+		t.Fatalf("runCli() import-semgrep = %v (%v), %v", stdout.String(), stderr.String(), err)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "semgrep.csv")); err != nil {
+		t.Errorf("runCli() import-semgrep did not write the semgrep CSV: %v", err)
+	}
+}
+
+func TestRunKBInfo(t *testing.T) {
+	t.Setenv("DB_DRIVER", "sqlite3")
+	t.Setenv("DB_DSN", filepath.Join(t.TempDir(), "kb.db"))
+	t.Setenv("SEMGREP_KB_INFO_DB", "true")
+	var stdout, stderr bytes.Buffer
+	if err := runCli([]string{"kb-info"}, nil, &stdout, &stderr); err != nil || !strings.HasSuffix(stdout.String(), "KB version: unknown\n") {
+		t.Errorf("runCli() kb-info without a KB = %v, %v", stdout.String(), err)
+	}
+	stdout.Reset()
+	err := runCli([]string{"kb-info", "-import", "../models/tests/semgrep-manifest.json"}, nil, &stdout, &stderr)
+	if err != nil || stdout.String() != "Imported KB 25.09 with 1 component scan(s)\n" {
+		t.Fatalf("runCli() kb-info -import = %v (%v), %v", stdout.String(), stderr.String(), err)
+	}
+	stdout.Reset()
+	err = runCli([]string{"kb-info"}, nil, &stdout, &stderr)
+	if err != nil || !strings.Contains(stdout.String(), "KB version: 25.09\nSnapshot date: 2025-09-01\n") {
+		t.Errorf("runCli() kb-info = %v, %v", stdout.String(), err)
+	}
+}
+
+func TestLoadInventory(t *testing.T) {
+	dir := t.TempDir()
+	listing := filepath.Join(dir, "inventory.md5")
+	if err := os.WriteFile(listing, []byte("# md5sum output\n2a07664f2245515afff5900355343083  src/main.py\n\n06CC59C6D133CFF5257DB0975D5B6817\n"), 0o600); err != nil {
+		t.Fatalf("failed to write inventory: %v", err)
+	}
+	invalid := filepath.Join(dir, "invalid.md5")
+	if err := os.WriteFile(invalid, []byte("src/main.py\n"), 0o600); err != nil {
+		t.Fatalf("failed to write inventory: %v", err)
+	}
+	tests := []struct {
+		name    string
+		path    string
+		want    int
+		wantErr bool
+	}{
+		{name: "md5 listing", path: listing, want: 2},
+		{name: "directory", path: "../ingest/tests/checkout", want: 3},
+		{name: "invalid md5", path: invalid, wantErr: true},
+		{name: "missing", path: filepath.Join(dir, "missing"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inventory, err := loadInventory(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadInventory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (len(inventory) != tt.want || !inventory["2a07664f2245515afff5900355343083"]) {
+				t.Errorf("loadInventory() = %v, want %v entries", inventory, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInterleaved(t *testing.T) {
+	opts := scanOptions{}
+	fs := newScanFlags(&opts, &bytes.Buffer{})
+	args, err := parseInterleaved(fs, []string{"pkg:npm/a", "-format", "table", "pkg:npm/b", "-debug"})
+	if err != nil {
+		t.Fatalf("parseInterleaved() error = %v", err)
+	}
+	if !reflect.DeepEqual(args, []string{"pkg:npm/a", "pkg:npm/b"}) || opts.format != "table" || !opts.debug {
+		t.Errorf("parseInterleaved() = %v, opts = %+v", args, opts)
+	}
+}
+
+func TestCollectComponents(t *testing.T) {
+	dir := t.TempDir()
+	inputFile := filepath.Join(dir, "purls.json")
+	if err := os.WriteFile(inputFile, []byte(`{"purls":[{"purl":"pkg:npm/lodash","requirement":"4.17.21"}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	lodash := dtos.ComponentDTO{Purl: "pkg:npm/lodash", Requirement: "4.17.21"}
+	zlib := dtos.ComponentDTO{Purl: "pkg:github/madler/zlib@1.2.13"}
+	tests := []struct {
+		name    string
+		purls   []string
+		input   string
+		stdin   string
+		want    []dtos.ComponentDTO
+		wantErr bool
+	}{
+		{name: "args", purls: []string{"pkg:github/madler/zlib@1.2.13"}, stdin: "pkg:npm/ignored", want: []dtos.ComponentDTO{zlib}},
+		{name: "file", input: inputFile, want: []dtos.ComponentDTO{lodash}},
+		{name: "args and file", purls: []string{"pkg:github/madler/zlib@1.2.13"}, input: inputFile, want: []dtos.ComponentDTO{zlib, lodash}},
+		{name: "implicit stdin", stdin: "pkg:github/madler/zlib@1.2.13\n", want: []dtos.ComponentDTO{zlib}},
+		{name: "explicit stdin", purls: []string{"-", "pkg:github/madler/zlib@1.2.13"}, stdin: `["pkg:npm/lodash"]`, want: []dtos.ComponentDTO{zlib, {Purl: "pkg:npm/lodash"}}},
+		{name: "bad purl", purls: []string{"lodash"}, wantErr: true},
+		{name: "missing file", input: filepath.Join(dir, "missing.json"), wantErr: true},
+		{name: "nothing", stdin: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := collectComponents(tt.purls, tt.input, strings.NewReader(tt.stdin))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("collectComponents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("collectComponents() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanComponents(t *testing.T) {
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	musl := dtos.ComponentDTO{Purl: "pkg:apk/alpine/musl", Requirement: "1.2.4_git20230717-r4"}
+	tests := []struct {
+		name    string
+		opts    scanOptions
+		stdin   string
+		want    []dtos.ComponentDTO
+		wantErr bool
+	}{
+		{name: "purls only", opts: scanOptions{purls: []string{"pkg:npm/lodash"}}, want: []dtos.ComponentDTO{{Purl: "pkg:npm/lodash"}}},
+		{name: "image", opts: scanOptions{image: "../inputs/tests/alpine-oci.tar"}, stdin: "pkg:npm/ignored"},
+		{name: "image and purls", opts: scanOptions{image: "../inputs/tests/alpine-oci.tar", purls: []string{"pkg:npm/lodash"}}},
+		{name: "missing image", opts: scanOptions{image: "../inputs/tests/missing.tar"}, wantErr: true},
+		{name: "image and bad purl", opts: scanOptions{image: "../inputs/tests/alpine-oci.tar", purls: []string{"lodash"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scanComponents(tt.opts, strings.NewReader(tt.stdin))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("scanComponents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			switch {
+			case tt.wantErr:
+			case tt.want != nil:
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("scanComponents() = %+v, want %+v", got, tt.want)
+				}
+			case !slices.Contains(got, musl) || slices.Contains(got, dtos.ComponentDTO{Purl: "pkg:npm/ignored"}):
+				t.Errorf("scanComponents() = %+v, want the image packages", got)
+			case len(tt.opts.purls) > 0 && got[len(got)-1].Purl != tt.opts.purls[0]:
+				t.Errorf("scanComponents() = %+v, want %v last", got, tt.opts.purls[0])
+			}
+		})
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	var stdout bytes.Buffer
+	output := dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{{Purl: "pkg:npm/lodash", Version: "4.17.21"}}}
+	if err := writeOutput("json", "", output, &stdout); err != nil || !strings.HasSuffix(stdout.String(), "}\n") {
+		t.Errorf("writeOutput() error = %v, output = %q", err, stdout.String())
+	}
+	file := filepath.Join(t.TempDir(), "report.sarif")
+	if err := writeOutput("sarif", file, output, &stdout); err != nil {
+		t.Fatalf("writeOutput() error = %v", err)
+	}
+	if data, err := os.ReadFile(file); err != nil || !bytes.Contains(data, []byte(`"version": "2.1.0"`)) {
+		t.Errorf("writeOutput() file = %v, %v", string(data), err)
+	}
+}
+
+func TestApplyIgnoreFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".scanoss-semgrep.yml")
+	data := "suppressions:\n  - purl: pkg:npm/lodash\n    rule: '*.prototype-pollution'\n    reason: accepted\n" +
+		"  - purl: pkg:npm/lodash\n    rule: '*.useless-assign'\n    reason: style\n    expires: 2020-01-31\n"
+	if err := os.WriteFile(file, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	output := dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{{Purl: "pkg:npm/lodash", Version: "4.17.21",
+		Files: []dtos.SemgrepFileIssues{{File: "abc123", Path: "lodash.js", Issues: []dtos.IssueItem{
+			{RuleID: "javascript.lang.security.audit.prototype-pollution", Severity: "ERROR"},
+			{RuleID: "javascript.lang.correctness.useless-assign", Severity: "INFO"},
+		}}}}}}
+	var stderr bytes.Buffer
+	if err := applyIgnoreFile(file, output, &stderr); err != nil {
+		t.Fatalf("applyIgnoreFile() error = %v", err)
+	}
+	issues := output.Purls[0].Files[0].Issues
+	if !issues[0].Suppressed || issues[1].Suppressed || !issues[1].SuppressionExpired {
+		t.Errorf("applyIgnoreFile() issues = %+v", issues)
+	}
+	if !strings.Contains(stderr.String(), "expired on 2020-01-31") {
+		t.Errorf("applyIgnoreFile() warnings = %q", stderr.String())
+	}
+	if err := applyIgnoreFile(filepath.Join(t.TempDir(), "missing.yml"), output, &stderr); err == nil {
+		t.Errorf("applyIgnoreFile() expected an error for a missing file")
+	}
+}
+
+func TestRunScanRemote(t *testing.T) {
+	var apiKey, project string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey, project = r.Header.Get("x-api-key"), r.Header.Get("x-scanoss-project")
+		_, _ = w.Write([]byte(`{"component":{"purl":"pkg:npm/lodash","version":"4.17.21","files":[` +
+			`{"fileMd5":"abc123","path":"lodash.js","issues":[{"ruleId":"js.rule","from":"1","to":"2","severity":"ERROR",` +
+			`"fingerprint":"bcd4253820315ae4edf779c3a05463a9","triage":{"id":"1","state":"confirmed","project":"acme","author":"jane"}}]}]}}` + "\n" +
+			`{"status":{"status":"SUCCESS","message":"Success"}}` + "\n"))
+	}))
+	defer srv.Close()
+	t.Setenv(apiKeyEnv, "env-key")
+
+	var stdout, stderr bytes.Buffer
+	err := runCli([]string{"scan", "-rest-url", srv.URL, "-retries", "0", "-project", "acme", "pkg:npm/lodash@4.17.21"}, nil, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("runCli() scan remote error = %v (%v)", err, stderr.String())
+	}
+	if project != "acme" {
+		t.Errorf("runCli() scan remote project = %q, want acme", project)
+	}
+	want := `{"purls":[{"purl":"pkg:npm/lodash","version":"4.17.21","files":[{"fileMD5":"abc123","path":"lodash.js",` +
+		`"issues":[{"ruleID":"js.rule","from":"1","to":"2","severity":"ERROR","fingerprint":"bcd4253820315ae4edf779c3a05463a9",` +
+		`"triage":{"id":"1","state":"confirmed","project":"acme","author":"jane"}}]}],"riskScore":1000}],"riskScore":1000}` + "\n"
+	if stdout.String() != want {
+		t.Errorf("runCli() scan remote output = %v, want %v", stdout.String(), want)
+	}
+	stdout.Reset()
+	err = runCli([]string{"scan", "-rest-url", srv.URL, "-retries", "0", "-min-risk", "1000.5", "pkg:npm/lodash@4.17.21"}, nil, &stdout, &stderr)
+	if want = `{"purls":[],"riskScore":1000}` + "\n"; err != nil || stdout.String() != want {
+		t.Errorf("runCli() scan remote -min-risk output = %v (%v), want %v", stdout.String(), err, want)
+	}
+	stdout.Reset()
+	// Without any rule metadata, filtering or grouping by category would silently drop (or not categorise) every finding
+	for _, flags := range [][]string{{"-group-by", "cwe"}, {"-cwe", "CWE-78"}, {"-owasp", "A03"}} {
+		args := append([]string{"scan", "-rest-url", srv.URL, "-retries", "0"}, append(flags, "pkg:npm/lodash@4.17.21")...)
+		if err = runCli(args, nil, &stdout, &stderr); !errors.Is(err, taxonomy.ErrNoRuleMetadata) {
+			t.Errorf("runCli() scan remote %v without rule metadata error = %v", flags, err)
+		}
+	}
+	if err = runCli([]string{"scan", "-rest-url", srv.URL, "-retries", "0", "-cwe", "injection", "pkg:npm/lodash"}, nil, &stdout, &stderr); err == nil || IsUsageError(err) {
+		t.Errorf("runCli() scan remote with a bad -cwe error = %v", err)
+	}
+	output := dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{{Purl: "pkg:npm/lodash", Files: []dtos.SemgrepFileIssues{{File: "abc123",
+		Issues: []dtos.IssueItem{{RuleID: "js.eval", Rule: &dtos.RuleMetadata{CWE: []string{"CWE-95"}}}, {RuleID: "js.style"}}}}}}}
+	if err = processOutput(scanOptions{cwe: stringList{"CWE-95"}, groupBy: "cwe"}, &output, &stderr); err != nil ||
+		len(output.Purls[0].Files[0].Issues) != 1 || len(output.Groups) != 1 || output.Groups[0].Category != "CWE-95" {
+		t.Errorf("processOutput() with rule metadata = %+v, %v", output, err)
+	}
+	if apiKey != "env-key" {
+		t.Errorf("runCli() scan remote api key = %q, want env-key", apiKey)
+	}
+}
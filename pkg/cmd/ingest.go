@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/ingest"
+	"scanoss.com/semgrep/pkg/models"
+)
+
+// Import targets supported by the import-semgrep command.
+const (
+	targetDB  = "db"
+	targetLDB = "ldb"
+)
+
+// runImportSemgrep imports the `semgrep --json` results of a checked-out component version into the local issue store
+// (or writes them as CSV files ready for an LDB import).
+func runImportSemgrep(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	var jsonConfig, envConfig, target, outputDir, root string
+	var component ingest.Component
+	var debug bool
+	fs := flag.NewFlagSet("import-semgrep", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&jsonConfig, "json-config", "", "Application JSON config")
+	fs.StringVar(&envConfig, "env-config", "", "Application dot-ENV config")
+	fs.BoolVar(&debug, "debug", false, "Enable debug")
+	fs.StringVar(&component.Purl, "purl", "", "Purl of the scanned component (i.e. pkg:github/acme/tool@1.0.0)")
+	fs.StringVar(&component.Version, "version", "", "Version of the scanned component (if not part of the purl)")
+	fs.StringVar(&component.URL, "url", "", "Download URL of the scanned component version (defaults to purl@version)")
+	fs.StringVar(&component.Ruleset, "ruleset", "", "Version of the ruleset Semgrep was run with (recorded as the provenance of the results)")
+	fs.StringVar(&root, "root", ".", "Directory holding the checkout that was scanned")
+	fs.StringVar(&target, "target", targetDB, "Import target: db (local issue store) or ldb (CSV files for an LDB import)")
+	fs.StringVar(&outputDir, "output", ".", "Directory to write the CSV files to (ldb target only)")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: %v import-semgrep [options] -purl <purl> <semgrep json file>\n\n", cliName)
+		_, _ = fmt.Fprintf(stderr, "Imports the results of 'semgrep --json' run over a checked-out component version.\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	files, err := parseInterleaved(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+	if len(files) != 1 || len(component.Purl) == 0 || (target != targetDB && target != targetLDB) {
+		fs.Usage()
+		return errUsage
+	}
+	report, err := ingest.LoadReport(files[0])
+	if err != nil {
+		return err
+	}
+	result, err := ingest.Build(root, component, report)
+	if err != nil {
+		return err
+	}
+	if result.Unmatched > 0 {
+		_, _ = fmt.Fprintf(stderr, "Warning: %d finding(s) reference files not found under %v\n", result.Unmatched, root)
+	}
+	if target == targetLDB {
+		if err = ingest.WriteLDB(outputDir, result); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(stdout, "Wrote %d file(s) and %d issue(s) for URL %v to %v\n", len(result.Files), len(result.Issues), result.URL.URLHash, outputDir)
+		return nil
+	}
+	cfg, err := loadConfig(jsonConfig, envConfig, debug)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	if err = setupCliLogger(cfg.App.Debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDBConnection(db)
+	ctx := context.Background()
+	local := models.NewLocalStoreModel(db)
+	if err = local.CreateTables(ctx); err != nil {
+		return err
+	}
+	if err = local.Import(ctx, result.URL, result.Files, result.Issues); err != nil {
+		return fmt.Errorf("failed to import semgrep results: %v", err)
+	}
+	_, _ = fmt.Fprintf(stdout, "Imported %d file(s) and %d issue(s) for URL %v\n", len(result.Files), len(result.Issues), result.URL.URLHash)
+	return nil
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"scanoss.com/semgrep/pkg/ingest"
+	"scanoss.com/semgrep/pkg/usecase"
+)
+
+// loadInventory builds a file inventory from either a directory (whose files are hashed)
+// or a file listing one MD5 per line (md5sum output is accepted; blank and '#' lines are skipped).
+func loadInventory(path string) (usecase.Inventory, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inventory %v: %v", path, err)
+	}
+	var md5s []string
+	if info.IsDir() {
+		hashes, err := ingest.HashFiles(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash inventory directory %v: %v", path, err)
+		}
+		for _, md5 := range hashes {
+			md5s = append(md5s, md5)
+		}
+	} else {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read inventory %v: %v", path, err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+				continue
+			}
+			md5s = append(md5s, fields[0])
+		}
+	}
+	inventory, err := usecase.NewInventory(md5s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid inventory %v: %v", path, err)
+	}
+	return inventory, nil
+}
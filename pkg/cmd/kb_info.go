@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/models"
+	"scanoss.com/semgrep/pkg/usecase"
+)
+
+// runKBInfo displays the build version along with the provenance of the configured KB,
+// or loads a KB provenance manifest into the KB info tables.
+func runKBInfo(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	var jsonConfig, envConfig, manifestFile string
+	var debug bool
+	fs := flag.NewFlagSet("kb-info", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&jsonConfig, "json-config", "", "Application JSON config")
+	fs.StringVar(&envConfig, "env-config", "", "Application dot-ENV config")
+	fs.BoolVar(&debug, "debug", false, "Enable debug")
+	fs.StringVar(&manifestFile, "import", "", "KB provenance manifest (JSON) to load into the KB info tables")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: %v kb-info [options]\n\n", cliName)
+		_, _ = fmt.Fprintf(stderr, "Displays the provenance (version, snapshot date, engine & ruleset) of the KB, or loads it from a manifest.\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+	var manifest *models.KBManifest
+	if len(manifestFile) > 0 {
+		var err error
+		if manifest, err = models.LoadKBManifest(manifestFile); err != nil {
+			return err
+		}
+	}
+	cfg, err := loadConfig(jsonConfig, envConfig, debug)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	if err = setupCliLogger(cfg.App.Debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDBConnection(db)
+	ctx := context.Background()
+	if manifest != nil {
+		model := models.NewKBInfoModel(db)
+		if err = model.CreateTables(ctx); err != nil {
+			return err
+		}
+		if err = model.Import(ctx, *manifest); err != nil {
+			return fmt.Errorf("failed to import KB manifest: %v", err)
+		}
+		_, _ = fmt.Fprintf(stdout, "Imported KB %v with %d component scan(s)\n", manifest.Version, len(manifest.Components))
+		return nil
+	}
+	_, _ = fmt.Fprintf(stdout, "Version: %v\n", strings.TrimSpace(version))
+	info := usecase.NewSemgrep(db, usecase.StoresFromConfig(db, cfg)).KBInfo(ctx, zlog.S)
+	if info == nil {
+		_, _ = fmt.Fprintf(stdout, "KB version: unknown\n")
+		return nil
+	}
+	_, _ = fmt.Fprintf(stdout, "KB version: %v\nSnapshot date: %v\nEngine version: %v\nRuleset version: %v\n",
+		info.Version, info.SnapshotDate, info.EngineVersion, info.RulesetVersion)
+	return nil
+}
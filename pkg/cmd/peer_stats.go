@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/models"
+	"scanoss.com/semgrep/pkg/usecase"
+)
+
+// runPeerStats recomputes the finding density percentiles of every ecosystem in the KB. It should be run whenever the KB changes.
+func runPeerStats(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	var jsonConfig, envConfig string
+	var debug bool
+	var batchSize int
+	fs := flag.NewFlagSet("peer-stats", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&jsonConfig, "json-config", "", "Application JSON config")
+	fs.StringVar(&envConfig, "env-config", "", "Application dot-ENV config")
+	fs.BoolVar(&debug, "debug", false, "Enable debug")
+	fs.IntVar(&batchSize, "batch-size", usecase.DefaultPeerBatchSize, "Number of KB URLs looked up in the LDB at a time")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: %v peer-stats [options]\n\n", cliName)
+		_, _ = fmt.Fprintf(stderr, "Walks all the analysed components in the KB and stores the finding density percentiles of each ecosystem.\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+	cfg, err := loadConfig(jsonConfig, envConfig, debug)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	if err = setupCliLogger(cfg.App.Debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDBConnection(db)
+	if err = setupLDB(cfg); err != nil {
+		return err
+	}
+	ctx := context.Background()
+	if err = models.NewPeerStatsModel(db).CreateTable(ctx); err != nil {
+		return err
+	}
+	counts, err := usecase.NewPeerStats(db).Refresh(ctx, zlog.S, batchSize)
+	if err != nil {
+		return fmt.Errorf("failed to refresh peer stats: %v", err)
+	}
+	purlTypes := make([]string, 0, len(counts))
+	for purlType := range counts {
+		purlTypes = append(purlTypes, purlType)
+	}
+	sort.Strings(purlTypes)
+	for _, purlType := range purlTypes {
+		_, _ = fmt.Fprintf(stdout, "%v: %d analysed component(s)\n", purlType, counts[purlType])
+	}
+	return nil
+}
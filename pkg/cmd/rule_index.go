@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+	"scanoss.com/semgrep/pkg/models"
+	"scanoss.com/semgrep/pkg/usecase"
+)
+
+// runRuleIndex rebuilds the reverse index of rule IDs to the KB component versions with findings of them.
+// It should be run whenever the KB changes.
+func runRuleIndex(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	var jsonConfig, envConfig string
+	var debug bool
+	var batchSize int
+	fs := flag.NewFlagSet("rule-index", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&jsonConfig, "json-config", "", "Application JSON config")
+	fs.StringVar(&envConfig, "env-config", "", "Application dot-ENV config")
+	fs.BoolVar(&debug, "debug", false, "Enable debug")
+	fs.IntVar(&batchSize, "batch-size", usecase.DefaultRuleIndexBatchSize, "Number of KB URLs looked up in the LDB at a time")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: %v rule-index [options]\n\n", cliName)
+		_, _ = fmt.Fprintf(stderr, "Walks all the analysed components in the KB and rebuilds the rule ID to component index used by rule searches.\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+	cfg, err := loadConfig(jsonConfig, envConfig, debug)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	if err = setupCliLogger(cfg.App.Debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDBConnection(db)
+	if err = setupLDB(cfg); err != nil {
+		return err
+	}
+	ctx := context.Background()
+	if err = models.NewRuleIndexModel(db).CreateTable(ctx); err != nil {
+		return err
+	}
+	summary, err := usecase.NewRuleIndex(db).Rebuild(ctx, zlog.S, batchSize)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild the rule index: %v", err)
+	}
+	_, _ = fmt.Fprintf(stdout, "Indexed %d rule(s) across %d component version(s) (%d file hit(s))\n", summary.Rules, summary.URLs, summary.Hits)
+	return nil
+}
+
+// runRuleSearch lists the KB component versions (optionally restricted to the given purls) with findings of a rule.
+func runRuleSearch(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var jsonConfig, envConfig, input string
+	var debug bool
+	search := dtos.RuleSearch{}
+	fs := flag.NewFlagSet("rule-search", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&jsonConfig, "json-config", "", "Application JSON config")
+	fs.StringVar(&envConfig, "env-config", "", "Application dot-ENV config")
+	fs.BoolVar(&debug, "debug", false, "Enable debug")
+	fs.StringVar(&search.RuleID, "rule", "", "Rule ID to search for (required)")
+	fs.StringVar(&input, "input", "", "File containing the purls to restrict the search to (JSON or one purl per line). Use '-' for stdin")
+	fs.StringVar(&search.Cursor, "cursor", "", "Cursor of the page to return (nextCursor of the previous page)")
+	fs.IntVar(&search.Limit, "limit", usecase.DefaultRuleSearchLimit, "Maximum number of component versions returned")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: %v rule-search -rule <rule ID> [options] [purl ...]\n\n", cliName)
+		_, _ = fmt.Fprintf(stderr, "Lists the component versions with findings of a rule, searching the whole KB unless purls are supplied.\n"+
+			"The rule index must have been built using the rule-index command.\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	purls, err := parseInterleaved(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+	if len(search.RuleID) == 0 {
+		_, _ = fmt.Fprintf(stderr, "A rule ID is required\n\n")
+		fs.Usage()
+		return errUsage
+	}
+	if len(purls) > 0 || len(input) > 0 {
+		if search.Components, err = collectComponents(purls, input, stdin); err != nil {
+			return err
+		}
+	}
+	cfg, err := loadConfig(jsonConfig, envConfig, debug)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	if err = setupCliLogger(cfg.App.Debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDBConnection(db)
+	if err = setupLDB(cfg); err != nil {
+		return err
+	}
+	output, err := usecase.NewRuleIndex(db).Search(context.Background(), zlog.S, search)
+	if err != nil {
+		return fmt.Errorf("failed to search the rule index: %v", err)
+	}
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the rule search results: %v", err)
+	}
+	_, err = fmt.Fprintln(stdout, string(data))
+	return err
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/models"
+	"scanoss.com/semgrep/pkg/rules"
+	"scanoss.com/semgrep/pkg/usecase"
+)
+
+// runImportRules parses the supplied Semgrep rule files/directories and adds their rules to the rule metadata catalog.
+func runImportRules(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	var jsonConfig, envConfig string
+	var debug, prefixPath bool
+	fs := flag.NewFlagSet("import-rules", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&jsonConfig, "json-config", "", "Application JSON config")
+	fs.StringVar(&envConfig, "env-config", "", "Application dot-ENV config")
+	fs.BoolVar(&debug, "debug", false, "Enable debug")
+	fs.BoolVar(&prefixPath, "prefix-path", true, "Prefix the rule IDs found in a directory with their dotted file path (as Semgrep does)")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: %v import-rules [options] <rule file or directory> ...\n\n", cliName)
+		_, _ = fmt.Fprintf(stderr, "Imports the metadata (title, message, CWE, OWASP, confidence, likelihood, impact & references) of Semgrep rules.\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	paths, err := parseInterleaved(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+	if len(paths) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+	var catalog []rules.Rule
+	for _, path := range paths {
+		found, err := rules.Load(path, prefixPath)
+		if err != nil {
+			return err
+		}
+		catalog = append(catalog, found...)
+	}
+	cfg, err := loadConfig(jsonConfig, envConfig, debug)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	if err = setupCliLogger(cfg.App.Debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDBConnection(db)
+	ctx := context.Background()
+	if err = models.NewRuleModel(db).CreateTable(ctx); err != nil {
+		return err
+	}
+	imported, err := usecase.NewRules(db).Import(ctx, zlog.S, catalog)
+	if err != nil {
+		return fmt.Errorf("failed to import rules: %v", err)
+	}
+	_, _ = fmt.Fprintf(stdout, "Imported %d rule(s)\n", imported)
+	return nil
+}
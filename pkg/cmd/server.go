@@ -35,11 +35,14 @@ import (
 
 	"github.com/scanoss/go-grpc-helper/pkg/files"
 	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	pbx "scanoss.com/semgrep/api/semgrepextv2"
 	myconfig "scanoss.com/semgrep/pkg/config"
 	m "scanoss.com/semgrep/pkg/models"
+	"scanoss.com/semgrep/pkg/outputs"
 	"scanoss.com/semgrep/pkg/protocol/grpc"
 	"scanoss.com/semgrep/pkg/protocol/rest"
 	"scanoss.com/semgrep/pkg/service"
+	"scanoss.com/semgrep/pkg/usecase"
 )
 
 //go:generate bash ../../get_version.sh
@@ -58,6 +61,11 @@ func getConfig() (*myconfig.ServerConfig, error) {
 		fmt.Printf("Version: %v", version)
 		os.Exit(1)
 	}
+	return loadConfig(jsonConfig, envConfig, *debug)
+}
+
+// loadConfig loads the server config from the (optional) JSON/dot-ENV files and environment.
+func loadConfig(jsonConfig, envConfig string, debug bool) (*myconfig.ServerConfig, error) {
 	var feeders []config.Feeder
 	if len(jsonConfig) > 0 {
 		feeders = append(feeders, feeder.Json{Path: jsonConfig})
@@ -65,7 +73,7 @@ func getConfig() (*myconfig.ServerConfig, error) {
 	if len(envConfig) > 0 {
 		feeders = append(feeders, feeder.DotEnv{Path: envConfig})
 	}
-	if *debug {
+	if debug {
 		err := os.Setenv("APP_DEBUG", "1")
 		if err != nil {
 			fmt.Printf("Warning: Failed to set env APP_DEBUG to 1: %v", err)
@@ -84,6 +92,67 @@ func closeDBConnection(db *sqlx.DB) {
 	}
 }
 
+// openDatabase sets up the database connection pool described in the config.
+func openDatabase(cfg *myconfig.ServerConfig) (*sqlx.DB, error) {
+	var dsn string
+	if len(cfg.Database.Dsn) > 0 {
+		dsn = cfg.Database.Dsn
+	} else {
+		dsn = fmt.Sprintf("%s://%s:%s@%s/%s?sslmode=%s",
+			cfg.Database.Driver,
+			cfg.Database.User,
+			cfg.Database.Passwd,
+			cfg.Database.Host,
+			cfg.Database.Schema,
+			cfg.Database.SslMode)
+	}
+	zlog.S.Debug("Connecting to Database...")
+	db, err := sqlx.Open(cfg.Database.Driver, dsn)
+	if err != nil {
+		zlog.S.Errorf("Failed to open database: %v", err)
+		return nil, fmt.Errorf("failed to open database: %v", err)
+	}
+	db.SetConnMaxIdleTime(30 * time.Minute) // TODO add to app config
+	db.SetConnMaxLifetime(time.Hour)
+	db.SetMaxIdleConns(20)
+	db.SetMaxOpenConns(100)
+	err = db.Ping()
+	if err != nil {
+		zlog.S.Errorf("Failed to ping database: %v", err)
+		return nil, fmt.Errorf("failed to ping database: %v", err)
+	}
+	return db, nil
+}
+
+// setupLDB configures the LDB models and checks that all the required tables are available.
+func setupLDB(cfg *myconfig.ServerConfig) error {
+	m.LDBBinPath = cfg.LDB.BinPath
+	m.LDBEncBinPath = cfg.LDB.EncBinPath
+
+	m.LDBPivotTableName = cfg.LDB.PivotName
+	m.LDBFileTableName = cfg.LDB.FileName
+	m.LDBSemgreptTableName = cfg.LDB.SemgrepName
+
+	tables, errLDB := m.PingLDB("oss")
+	if errLDB != nil {
+		zlog.S.Errorf("Failed to ping LDB: %v", errLDB)
+		return fmt.Errorf("failed to ping LDB: %v", errLDB)
+	}
+	if !m.ContainsTable(tables, cfg.LDB.SemgrepName) {
+		zlog.S.Error("semgrep LDB table not found")
+		//	return fmt.Errorf("%s", "semgrep LDB table not found")
+	}
+	if !m.ContainsTable(tables, cfg.LDB.FileName) {
+		zlog.S.Errorf("File LDB table not found: %v", cfg.LDB.FileName)
+		return fmt.Errorf("%s", "file LDB table not found")
+	}
+	if !m.ContainsTable(tables, cfg.LDB.PivotName) {
+		zlog.S.Error("Pivot LDB table not found")
+		return fmt.Errorf("%s", "Pivot LDB table not found")
+	}
+	return nil
+}
+
 // RunServer runs the gRPC semgrep Server.
 func RunServer() error {
 	// Load command line options and config
@@ -128,73 +197,82 @@ func RunServer() error {
 	}
 	defer zlog.SyncZap()
 	zlog.S.Infof("Starting SCANOSS semgrep Service: %v", strings.TrimSpace(version))
+	outputs.ToolVersion = strings.TrimSpace(version)
+	service.Version = strings.TrimSpace(version)
 	// Setup database connection pool
-	var dsn string
-	if len(cfg.Database.Dsn) > 0 {
-		dsn = cfg.Database.Dsn
-	} else {
-		dsn = fmt.Sprintf("%s://%s:%s@%s/%s?sslmode=%s",
-			cfg.Database.Driver,
-			cfg.Database.User,
-			cfg.Database.Passwd,
-			cfg.Database.Host,
-			cfg.Database.Schema,
-			cfg.Database.SslMode)
-	}
-	zlog.S.Debug("Connecting to Database...")
-	db, err := sqlx.Open(cfg.Database.Driver, dsn)
+	db, err := openDatabase(cfg)
 	if err != nil {
-		zlog.S.Errorf("Failed to open database: %v", err)
-		return fmt.Errorf("failed to open database: %v", err)
+		return err
 	}
-	db.SetConnMaxIdleTime(30 * time.Minute) // TODO add to app config
-	db.SetConnMaxLifetime(time.Hour)
-	db.SetMaxIdleConns(20)
-	db.SetMaxOpenConns(100)
-	err = db.Ping()
-	if err != nil {
-		zlog.S.Errorf("Failed to ping database: %v", err)
-		return fmt.Errorf("failed to ping database: %v", err)
+	defer closeDBConnection(db)
+	if err = setupLDB(cfg); err != nil {
+		return err
 	}
-	m.LDBBinPath = cfg.LDB.BinPath
-	m.LDBEncBinPath = cfg.LDB.EncBinPath
-
-	m.LDBPivotTableName = cfg.LDB.PivotName
-	m.LDBFileTableName = cfg.LDB.FileName
-	m.LDBSemgreptTableName = cfg.LDB.SemgrepName
-
-	tables, errLDB := m.PingLDB("oss")
-	if errLDB != nil {
-		zlog.S.Errorf("Failed to ping LDB: %v", errLDB)
-		return fmt.Errorf("failed to ping LDB: %v", errLDB)
+	ctx := context.Background()
+	var triageAPI pbx.SemgrepTriageServer
+	if cfg.Triage.Enabled {
+		if err = m.NewTriageModel(db).CreateTable(ctx); err != nil {
+			return err
+		}
+		triageAPI = service.NewSemgrepTriageServer(db)
 	}
-	if !m.ContainsTable(tables, cfg.LDB.SemgrepName) {
-		zlog.S.Error("semgrep LDB table not found")
-		//	return fmt.Errorf("%s", "semgrep LDB table not found")
+	if cfg.PeerStats.Enabled {
+		if err = m.NewPeerStatsModel(db).CreateTable(ctx); err != nil {
+			return err
+		}
 	}
-	if !m.ContainsTable(tables, cfg.LDB.FileName) {
-		fmt.Println(cfg.LDB.FileName)
-		zlog.S.Error("File LDB table not found")
-		return fmt.Errorf("%s", "file LDB table not found")
+	if cfg.RuleCatalog.Enabled {
+		if err = m.NewRuleModel(db).CreateTable(ctx); err != nil {
+			return err
+		}
 	}
-	if !m.ContainsTable(tables, cfg.LDB.PivotName) {
-		zlog.S.Error("Pivot LDB table not found")
-		return fmt.Errorf("%s", "Pivot LDB table not found")
+	if cfg.LocalStore.Enabled {
+		if err = m.NewLocalStoreModel(db).CreateTables(ctx); err != nil {
+			return err
+		}
+	}
+	if cfg.KB.InfoDB {
+		if err = m.NewKBInfoModel(db).CreateTables(ctx); err != nil {
+			return err
+		}
+	}
+	if cfg.RuleIndex.Enabled {
+		if err = m.NewRuleIndexModel(db).CreateTable(ctx); err != nil {
+			return err
+		}
+	}
+	if cfg.Jobs.Enabled {
+		if err = m.NewJobModel(db).CreateTables(ctx); err != nil {
+			return err
+		}
+		var runner *usecase.JobRunner
+		if runner, err = usecase.NewJobRunner(db, usecase.NewSemgrep(db, usecase.StoresFromConfig(db, cfg)), cfg); err != nil {
+			return err
+		}
+		if err = runner.Start(ctx); err != nil {
+			return err
+		}
+		defer runner.Stop() // return any jobs in progress to the queue before the DB is closed
 	}
-
-	defer closeDBConnection(db)
 	v2API := service.NewSemgrepServer(db, cfg)
-	ctx := context.Background()
+	httpAPI, err := service.NewSemgrepHTTPServer(db, cfg)
+	if err != nil {
+		return err
+	}
 
 	// Start the REST grpc-gateway if requested
 	var srv *http.Server
 	if len(cfg.App.RESTPort) > 0 {
-		if srv, err = rest.RunServer(cfg, ctx, cfg.App.GRPCPort, cfg.App.RESTPort, allowedIPs, deniedIPs, startTLS); err != nil {
+		if srv, err = rest.RunServer(cfg, ctx, cfg.App.GRPCPort, cfg.App.RESTPort, allowedIPs, deniedIPs, startTLS, httpAPI); err != nil {
 			return err
 		}
 	}
 	// Start the gRPC service
-	server, err := grpc.RunServer(cfg, v2API, cfg.App.GRPCPort, allowedIPs, deniedIPs, startTLS, version)
+	issuesAPI, err := service.NewSemgrepIssuesServer(db, cfg)
+	if err != nil {
+		return err
+	}
+	server, err := grpc.RunServer(cfg, v2API, issuesAPI, triageAPI, cfg.App.GRPCPort, allowedIPs, deniedIPs, startTLS, version)
 	if err != nil {
 		return err
 	}
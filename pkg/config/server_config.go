@@ -75,6 +75,42 @@ type ServerConfig struct {
 	Components struct {
 		CommitMissing bool `env:"COMP_COMMIT_MISSING"` // Write component details to the DB if they are looked up live
 	}
+	Triage struct {
+		Enabled bool `env:"SEMGREP_TRIAGE_ENABLED"` // Enable the central triage store (creates the triage tables if missing)
+	}
+	Policy struct {
+		File string `env:"SEMGREP_POLICY_FILE"` // YAML component acceptance policy (enables the policy endpoint)
+	}
+	Risk struct {
+		ErrorWeight   float64 `env:"SEMGREP_RISK_ERROR_WEIGHT"`   // Risk score weight of an ERROR finding
+		WarningWeight float64 `env:"SEMGREP_RISK_WARNING_WEIGHT"` // Risk score weight of a WARNING finding
+		InfoWeight    float64 `env:"SEMGREP_RISK_INFO_WEIGHT"`    // Risk score weight of an INFO finding
+	}
+	PeerStats struct {
+		Enabled bool `env:"SEMGREP_PEER_STATS_ENABLED"` // Rank components against their ecosystem (creates the peer stats table if missing)
+	}
+	RuleCatalog struct {
+		Enabled bool `env:"SEMGREP_RULE_CATALOG_ENABLED"` // Attach rule metadata to findings (creates the rules table if missing)
+	}
+	LocalStore struct {
+		Enabled bool `env:"SEMGREP_LOCAL_STORE_ENABLED"` // Answer from locally imported Semgrep results (creates the local store tables if missing)
+	}
+	RuleIndex struct {
+		Enabled bool `env:"SEMGREP_RULE_INDEX_ENABLED"` // Serve reverse rule lookups (creates the rule index table if missing)
+	}
+	Stream struct {
+		BatchSize int `env:"SEMGREP_STREAM_BATCH_SIZE"` // Number of components resolved at a time when streaming component issues
+	}
+	Jobs struct {
+		Enabled   bool   `env:"SEMGREP_JOBS_ENABLED"`   // Accept asynchronous batch jobs (creates the job tables if missing)
+		Workers   int    `env:"SEMGREP_JOBS_WORKERS"`   // Number of jobs processed at the same time
+		Retention string `env:"SEMGREP_JOBS_RETENTION"` // How long finished jobs and their results are kept (i.e. 168h). Empty keeps them forever
+		Lease     string `env:"SEMGREP_JOBS_LEASE"`     // How long a running job is held by a worker without a heartbeat before it's queued again (i.e. 5m)
+	}
+	KB struct {
+		ManifestFile string `env:"SEMGREP_KB_MANIFEST"` // KB provenance manifest (JSON) stored next to the LDB tables
+		InfoDB       bool   `env:"SEMGREP_KB_INFO_DB"`  // Read the KB provenance from the DB instead of the manifest (creates the KB info tables if missing)
+	}
 }
 
 // NewServerConfig loads all config options and return a struct for use.
@@ -108,6 +144,21 @@ func setServerConfigDefaults(cfg *ServerConfig) {
 	cfg.Database.Schema = "scanoss"
 	cfg.Database.SslMode = "disable"
 	cfg.Components.CommitMissing = false
+	cfg.Triage.Enabled = false
+	cfg.Risk.ErrorWeight = 10
+	cfg.Risk.WarningWeight = 3
+	cfg.Risk.InfoWeight = 1
+	cfg.PeerStats.Enabled = false
+	cfg.RuleCatalog.Enabled = false
+	cfg.LocalStore.Enabled = false
+	cfg.RuleIndex.Enabled = false
+	cfg.Stream.BatchSize = 100
+	cfg.Jobs.Enabled = false
+	cfg.Jobs.Workers = 2
+	cfg.Jobs.Retention = "168h"
+	cfg.Jobs.Lease = "5m"
+	cfg.KB.ManifestFile = "/var/lib/ldb/oss/semgrep-manifest.json"
+	cfg.KB.InfoDB = false
 	cfg.Logging.DynamicLogging = true
 	cfg.Logging.DynamicPort = "localhost:60055"
 	cfg.Telemetry.Enabled = false
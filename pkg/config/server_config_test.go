@@ -79,3 +79,14 @@ func TestServerConfigJson(t *testing.T) {
 	}
 	fmt.Printf("Server Config3: %+v\n", cfg)
 }
+
+func TestServerConfigRiskWeights(t *testing.T) {
+	t.Setenv("SEMGREP_RISK_ERROR_WEIGHT", "7.5")
+	cfg, err := NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating new config instance", err)
+	}
+	if cfg.Risk.ErrorWeight != 7.5 || cfg.Risk.WarningWeight != 3 || cfg.Risk.InfoWeight != 1 {
+		t.Errorf("Risk weights = %+v, want 7.5/3/1", cfg.Risk)
+	}
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fingerprintVersion is included in the fingerprint hash, so the algorithm can be changed without clashing.
+const fingerprintVersion = "v1"
+
+var (
+	// versionedDir matches archive root folders named after a release (i.e. lodash-4.17.21, zlib_v1.2.13, pkg-1.0.0-rc1).
+	versionedDir = regexp.MustCompile(`^.+[-_.]v?\d+(\.\d+)+([-_.+~][0-9A-Za-z.]+)*$`)
+	// commitDir matches archive root folders named after a commit (i.e. madler-zlib-04f42ce).
+	commitDir = regexp.MustCompile(`^.+-[0-9a-f]{7,40}$`)
+)
+
+// Fingerprint builds a deterministic identifier for a finding that survives re-queries, version bumps and KB rebuilds.
+// It's based on the purl name (no version), the file path (without any versioned root folder), the rule ID and
+// the occurrence of the rule within the file (ordered by line), so it does not change when code moves up or down.
+func Fingerprint(purl, path, ruleID string, occurrence int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%v|%v|%v|%v|%d", fingerprintVersion, PurlName(purl), NormalisePath(path), ruleID, occurrence)))
+	return hex.EncodeToString(sum[:16])
+}
+
+// AssignFingerprints sets the fingerprint of every finding in the output (updating it in place).
+func AssignFingerprints(output SemgrepOutput) {
+	for _, item := range output.Purls {
+		type occurrence struct {
+			issue *IssueItem
+			from  int
+			to    int
+		}
+		groups := make(map[string][]occurrence) // findings grouped by normalised path and rule
+		var keys []string
+		for fi := range item.Files {
+			file := item.Files[fi]
+			path := file.Path
+			if len(path) == 0 {
+				path = file.File // no path available, so fall back to the file MD5
+			}
+			for ii := range file.Issues {
+				issue := &file.Issues[ii]
+				key := NormalisePath(path) + "\x00" + issue.RuleID
+				if _, ok := groups[key]; !ok {
+					keys = append(keys, key)
+				}
+				groups[key] = append(groups[key], occurrence{issue: issue, from: lineNumber(issue.From), to: lineNumber(issue.To)})
+			}
+		}
+		for _, key := range keys {
+			found := groups[key]
+			sort.SliceStable(found, func(i, j int) bool {
+				if found[i].from != found[j].from {
+					return found[i].from < found[j].from
+				}
+				return found[i].to < found[j].to
+			})
+			path, ruleID, _ := strings.Cut(key, "\x00")
+			for i, o := range found {
+				o.issue.Fingerprint = Fingerprint(item.Purl, path, ruleID, i)
+			}
+		}
+	}
+}
+
+// PurlName returns the purl without any version, qualifiers or subpath (i.e. pkg:npm/lodash).
+func PurlName(purl string) string {
+	if i := strings.IndexAny(purl, "?#"); i >= 0 {
+		purl = purl[:i]
+	}
+	if at := strings.LastIndex(purl, "@"); at > strings.LastIndex(purl, "/") {
+		purl = purl[:at]
+	}
+	return purl
+}
+
+// NormalisePath strips the versioned root folder of an archive path (i.e. lodash-4.17.21/lodash.js -> lodash.js),
+// along with the npm 'package' root folder, so the path is stable across versions.
+func NormalisePath(path string) string {
+	path = strings.Trim(strings.ReplaceAll(path, "\\", "/"), "/")
+	root, rest, found := strings.Cut(path, "/")
+	if found && (root == "package" || versionedDir.MatchString(root) || commitDir.MatchString(root)) {
+		return rest
+	}
+	return path
+}
+
+// lineNumber converts a From/To line into an integer (0 if it's not a valid line).
+func lineNumber(line string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestNormalisePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "lodash-4.17.21/lodash.js", want: "lodash.js"},
+		{path: "/zlib-1.2.13/contrib/inflate.c", want: "contrib/inflate.c"},
+		{path: "requests_v2.31.0/requests/api.py", want: "requests/api.py"},
+		{path: "openssl-3.0.0-beta1/ssl/ssl_lib.c", want: "ssl/ssl_lib.c"},
+		{path: "madler-zlib-04f42ce/inflate.c", want: "inflate.c"},
+		{path: "package/index.js", want: "index.js"},
+		{path: "src/main.go", want: "src/main.go"},
+		{path: "python3.11/os.py", want: "python3.11/os.py"},
+		{path: "lib\\win\\file.c", want: "lib/win/file.c"},
+		{path: "README.md", want: "README.md"},
+	}
+	for _, tt := range tests {
+		if got := NormalisePath(tt.path); got != tt.want {
+			t.Errorf("NormalisePath(%v) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestAssignFingerprints(t *testing.T) {
+	build := func(purl, root string, offset int) SemgrepOutput {
+		line := func(n int) string { return strconv.Itoa(n + offset) }
+		return SemgrepOutput{Purls: []SemgrepOutputItem{{Purl: purl, Files: []SemgrepFileIssues{
+			{File: "md5-" + root, Path: root + "/lib/a.js", Issues: []IssueItem{
+				{RuleID: "rule.a", From: line(30), To: line(31)},
+				{RuleID: "rule.a", From: line(10), To: line(12)},
+				{RuleID: "rule.b", From: line(10), To: line(12)},
+			}},
+		}}}}
+	}
+	v1 := build("pkg:npm/lodash@4.17.20", "lodash-4.17.20", 0)
+	v2 := build("pkg:npm/lodash@4.17.21?repository_url=x", "lodash-4.17.21", 5)
+	AssignFingerprints(v1)
+	AssignFingerprints(v2)
+	issues1, issues2 := v1.Purls[0].Files[0].Issues, v2.Purls[0].Files[0].Issues
+	seen := map[string]bool{}
+	for i := range issues1 {
+		if len(issues1[i].Fingerprint) != 32 {
+			t.Errorf("AssignFingerprints() fingerprint = %q, want 32 hex characters", issues1[i].Fingerprint)
+		}
+		if issues1[i].Fingerprint != issues2[i].Fingerprint {
+			t.Errorf("AssignFingerprints() finding %d changed across versions: %v != %v", i, issues1[i].Fingerprint, issues2[i].Fingerprint)
+		}
+		seen[issues1[i].Fingerprint] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("AssignFingerprints() fingerprints are not unique: %v", seen)
+	}
+	if issues1[1].Fingerprint != Fingerprint("pkg:npm/lodash", "lib/a.js", "rule.a", 0) {
+		t.Errorf("AssignFingerprints() first occurrence should be the lowest line")
+	}
+	other := build("pkg:npm/underscore@1.0.0", "underscore-1.0.0", 0)
+	AssignFingerprints(other)
+	if other.Purls[0].Files[0].Issues[0].Fingerprint == issues1[0].Fingerprint {
+		t.Errorf("AssignFingerprints() fingerprint should depend on the purl name")
+	}
+}
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+// Job is an asynchronous batch lookup of the issues of a (large) list of components.
+// Its state is queued, running, completed, failed or cancelled.
+type Job struct {
+	ID         string `json:"id"`
+	Project    string `json:"project,omitempty"`
+	State      string `json:"state"`
+	Resolved   int    `json:"resolved"` // Number of components resolved so far
+	Total      int    `json:"total"`
+	Message    string `json:"message,omitempty"` // Reason the job failed
+	CreatedAt  string `json:"createdAt"`
+	StartedAt  string `json:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+	ExpiresAt  string `json:"expiresAt,omitempty"` // When the job and its results will be removed (if finished)
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Fields that can be left out of the issue responses (see FieldMask).
+const (
+	FieldPaths  = "paths"
+	FieldIssues = "issues"
+)
+
+// FieldMask selects the parts of the issue responses to leave out, to keep the responses of large components small.
+type FieldMask struct {
+	OmitPaths  bool // Leave out the file paths
+	OmitIssues bool // Leave out the issue details, keeping the number of issues of each file
+}
+
+// ParseFieldMask builds a field mask from a list of fields to omit (each entry may hold a comma separated list).
+func ParseFieldMask(omit []string) (FieldMask, error) {
+	mask := FieldMask{}
+	for _, entry := range omit {
+		for _, field := range strings.Split(entry, ",") {
+			switch strings.ToLower(strings.TrimSpace(field)) {
+			case "":
+			case FieldPaths:
+				mask.OmitPaths = true
+			case FieldIssues:
+				mask.OmitIssues = true
+			default:
+				return FieldMask{}, fmt.Errorf("unknown field '%v' (expected %v or %v)", field, FieldPaths, FieldIssues)
+			}
+		}
+	}
+	return mask, nil
+}
+
+// Apply leaves the masked fields out of the given files (updating them in place).
+func (m FieldMask) Apply(files []SemgrepFileIssues) {
+	for i := range files {
+		if m.OmitPaths {
+			files[i].Path, files[i].pathOmitted = "", true
+		}
+		if m.OmitIssues {
+			files[i].IssueCount = len(files[i].Issues)
+			files[i].Issues = []IssueItem{}
+		}
+	}
+}
+
+// ApplyOutput leaves the masked fields out of all the component files of the output (updating it in place).
+func (m FieldMask) ApplyOutput(output SemgrepOutput) {
+	for _, item := range output.Purls {
+		m.Apply(item.Files)
+	}
+}
+
+// PageRequest selects a page of the files and issues of a single component.
+type PageRequest struct {
+	Cursor string    `json:"cursor,omitempty"` // nextCursor of the previous page (empty for the first page)
+	Limit  int       `json:"limit,omitempty"`  // Maximum number of issues (or files, if the issue details are omitted) per page
+	Mask   FieldMask `json:"-"`
+}
+
+// ComponentIssuesPage is a page of the files and issues of a single component, in path and fingerprint order.
+// A file with more issues than fit in a page is split across consecutive pages.
+type ComponentIssuesPage struct {
+	Component   SemgrepOutputItem `json:"component"`
+	TotalFiles  int               `json:"totalFiles"`           // Files with issues in the whole component
+	TotalIssues int               `json:"totalIssues"`          // Issues in the whole component
+	NextCursor  string            `json:"nextCursor,omitempty"` // Set when there are more pages
+}
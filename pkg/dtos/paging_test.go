@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFieldMask(t *testing.T) {
+	tests := []struct {
+		omit    []string
+		want    FieldMask
+		wantErr bool
+	}{
+		{omit: nil, want: FieldMask{}},
+		{omit: []string{"paths"}, want: FieldMask{OmitPaths: true}},
+		{omit: []string{"Paths, issues"}, want: FieldMask{OmitPaths: true, OmitIssues: true}},
+		{omit: []string{"issues", ""}, want: FieldMask{OmitIssues: true}},
+		{omit: []string{"severity"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFieldMask(tt.omit)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFieldMask(%v) = %+v, %v, want %+v", tt.omit, got, err, tt.want)
+		}
+	}
+	output := SemgrepOutput{Purls: []SemgrepOutputItem{{Files: []SemgrepFileIssues{{File: "aa", Path: "a.js", Issues: []IssueItem{{RuleID: "r1"}, {RuleID: "r2"}}}}}}}
+	FieldMask{OmitPaths: true, OmitIssues: true}.ApplyOutput(output)
+	want := []SemgrepFileIssues{{File: "aa", Issues: []IssueItem{}, IssueCount: 2, pathOmitted: true}}
+	if !reflect.DeepEqual(output.Purls[0].Files, want) {
+		t.Errorf("ApplyOutput() = %+v, want %+v", output.Purls[0].Files, want)
+	}
+}
+
+func TestFileIssuesJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		file SemgrepFileIssues
+		mask FieldMask
+		want string
+	}{
+		{name: "path", file: SemgrepFileIssues{File: "aa", Path: "a.js"}, want: `{"fileMD5":"aa","path":"a.js","issues":null}`},
+		{name: "unknown path", file: SemgrepFileIssues{File: "aa"}, want: `{"fileMD5":"aa","path":"","issues":null}`},
+		{name: "masked path", file: SemgrepFileIssues{File: "aa", Path: "a.js", Issues: []IssueItem{{RuleID: "r1"}}}, mask: FieldMask{OmitPaths: true},
+			want: `{"fileMD5":"aa","issues":[{"ruleID":"r1","from":"","to":"","severity":""}]}`},
+		{name: "masked issues", file: SemgrepFileIssues{File: "aa", Path: "a.js", Issues: []IssueItem{{RuleID: "r1"}}}, mask: FieldMask{OmitIssues: true},
+			want: `{"fileMD5":"aa","path":"a.js","issues":[],"issueCount":1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := []SemgrepFileIssues{tt.file}
+			tt.mask.Apply(files)
+			data, err := json.Marshal(files[0])
+			if err != nil || string(data) != tt.want {
+				t.Errorf("json.Marshal() = %s, %v, want %s", data, err, tt.want)
+			}
+		})
+	}
+}
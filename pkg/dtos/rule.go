@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+// RuleMetadata holds the catalog details of a Semgrep rule (imported from the Semgrep rule YAML).
+type RuleMetadata struct {
+	Title      string   `json:"title,omitempty"`
+	Message    string   `json:"message,omitempty"`
+	CWE        []string `json:"cwe,omitempty"`   // i.e. CWE-78: Improper Neutralization of Special Elements used in an OS Command
+	OWASP      []string `json:"owasp,omitempty"` // i.e. A03:2021 - Injection
+	Confidence string   `json:"confidence,omitempty"`
+	Likelihood string   `json:"likelihood,omitempty"`
+	Impact     string   `json:"impact,omitempty"`
+	References []string `json:"references,omitempty"`
+}
+
+// RuleSearch is a reverse lookup of the component versions with findings of a rule.
+// An empty component list searches the whole KB. A component version (in the purl or requirement) restricts it to that version.
+type RuleSearch struct {
+	RuleID     string         `json:"ruleId"`
+	Components []ComponentDTO `json:"components,omitempty"`
+	Cursor     string         `json:"cursor,omitempty"` // nextCursor of the previous page (empty for the first page)
+	Limit      int            `json:"limit,omitempty"`  // Maximum number of component versions returned
+}
+
+// RuleSearchOutput is a page of the component versions with findings of a rule.
+type RuleSearchOutput struct {
+	RuleID     string          `json:"ruleId"`
+	Components []RuleComponent `json:"components"`
+	NextCursor string          `json:"nextCursor,omitempty"` // Set when there may be more component versions
+}
+
+// RuleComponent is a component version (KB URL) with findings of a rule, along with the files containing them.
+type RuleComponent struct {
+	Purl    string     `json:"purl"`
+	Version string     `json:"version"`
+	URLHash string     `json:"urlHash"`
+	Files   []RuleFile `json:"files"`
+}
+
+// RuleFile is a component file with findings of a rule.
+type RuleFile struct {
+	FileMD5  string `json:"fileMD5"`
+	Path     string `json:"path,omitempty"`
+	Severity string `json:"severity"`
+	Findings int    `json:"findings"`
+}
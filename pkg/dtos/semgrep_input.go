@@ -34,7 +34,6 @@ type SemgrepInputItem struct {
 
 // ParseSemgrepInput converts the input byte array to a SemgrepInput structure.
 func ParseSemgrepInput(input []byte) (SemgrepInput, error) {
-	fmt.Println(string(input))
 	if len(input) == 0 {
 		return SemgrepInput{}, fmt.Errorf("no purl info data supplied to parse")
 	}
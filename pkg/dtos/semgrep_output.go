@@ -20,31 +20,190 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
 )
 
 type SemgrepOutput struct {
-	Purls []SemgrepOutputItem `json:"purls"`
+	Purls     []SemgrepOutputItem `json:"purls"`
+	RiskScore float64             `json:"riskScore,omitempty"` // Risk score of the whole request (see risk.Weights)
+	Baseline  *BaselineSummary    `json:"baseline,omitempty"`  // Set when the output has been compared against a baseline
+	Groups    []CategoryGroup     `json:"groups,omitempty"`    // Findings grouped by CWE or OWASP category (when requested)
+	KB        *KBInfo             `json:"kb,omitempty"`        // Knowledge base snapshot the findings were read from (if known)
+}
+
+// CategoryGroup lists the findings of a single CWE or OWASP category. A finding with several categories is listed in each of them.
+type CategoryGroup struct {
+	Category string            `json:"category"`       // i.e. CWE-78 or A03:2021 (uncategorised for findings without any)
+	Name     string            `json:"name,omitempty"` // Full category name, as found in the rule metadata
+	Count    int               `json:"count"`
+	Findings []CategoryFinding `json:"findings"`
+}
+
+// CategoryFinding is a single finding listed in a category group.
+type CategoryFinding struct {
+	Purl    string    `json:"purl"`
+	Version string    `json:"version"`
+	FileMD5 string    `json:"fileMD5"`
+	Path    string    `json:"path"`
+	Issue   IssueItem `json:"issue"`
+}
+
+// BaselineSummary reports the outcome of comparing an output against a baseline (only new findings are kept).
+type BaselineSummary struct {
+	New       int `json:"new"`       // Findings not present in the baseline (still reported)
+	Unchanged int `json:"unchanged"` // Findings already present in the baseline (removed from the output)
+	Fixed     int `json:"fixed"`     // Baseline findings of the reported components that have disappeared
 }
 
 type SemgrepOutputItem struct {
-	Purl    string              `json:"purl"`
-	Version string              `json:"version"`
-	Files   []SemgrepFileIssues `json:"files"`
+	Purl          string              `json:"purl"`
+	Version       string              `json:"version"`
+	Files         []SemgrepFileIssues `json:"files"`
+	AnalysedFiles int                 `json:"analysedFiles,omitempty"` // Number of files analysed (from the pivot table), restricted to the inventory (if any)
+	RiskScore     float64             `json:"riskScore,omitempty"`     // Severity weighted findings per 100 analysed files
+	Peer          *PeerRank           `json:"peer,omitempty"`          // Rank within the components of the same ecosystem (if available)
+	Provenance    *Provenance         `json:"provenance,omitempty"`    // When and how the selected version was analysed (if known)
+	Presence      *Presence           `json:"presence,omitempty"`      // How much of the component is in the supplied file inventory (if any)
+}
+
+// Presence reports how many of the analysed files of a component were found in a file MD5 inventory.
+type Presence struct {
+	Files   int     `json:"files"`   // Distinct component files found in the inventory
+	Percent float64 `json:"percent"` // Share of the distinct analysed files found in the inventory
+}
+
+// KBInfo identifies the knowledge base snapshot, Semgrep engine and ruleset that produced the findings.
+type KBInfo struct {
+	Version        string `json:"version,omitempty"`
+	SnapshotDate   string `json:"snapshotDate,omitempty"`
+	EngineVersion  string `json:"engineVersion,omitempty"`
+	RulesetVersion string `json:"rulesetVersion,omitempty"`
+}
+
+// Provenance sources.
+const (
+	ProvenanceKB    = "kb"    // Analysed as part of the KB
+	ProvenanceLocal = "local" // Imported into the local store (see the import-semgrep command)
+)
+
+// Provenance describes when and how a component version was analysed.
+type Provenance struct {
+	Source         string `json:"source"`             // kb or local (imported Semgrep results)
+	ScanDate       string `json:"scanDate,omitempty"` // Falls back to the KB snapshot date if not recorded for the component
+	EngineVersion  string `json:"engineVersion,omitempty"`
+	RulesetVersion string `json:"rulesetVersion,omitempty"`
+}
+
+// ServiceInfo is the response of the service information endpoint.
+type ServiceInfo struct {
+	Name    string  `json:"name"`
+	Version string  `json:"version"`
+	KB      *KBInfo `json:"kb,omitempty"`
+}
+
+// PeerRank places a component within the finding density distribution of its ecosystem (purl type) in the KB.
+type PeerRank struct {
+	Ecosystem  string  `json:"ecosystem"`
+	Density    float64 `json:"density"`    // Findings per analysed file
+	WorseThan  int     `json:"worseThan"`  // Percentage of the ecosystem components with a lower finding density
+	Components int     `json:"components"` // Number of analysed ecosystem components in the distribution
+	Summary    string  `json:"summary"`    // Human-readable rank (i.e. worse than 85% of npm packages)
+}
+
+// FileQuery is a single file looked up by MD5. The optional URL hash selects the URL its path is read from.
+type FileQuery struct {
+	FileMD5 string `json:"fileMD5"`
+	URLHash string `json:"urlHash,omitempty"`
+}
+
+// FileIssuesOutput holds the Semgrep issues of a list of files looked up by MD5 (in request order).
+type FileIssuesOutput struct {
+	Files []SemgrepFileIssues `json:"files"`
 }
 
 type SemgrepFileIssues struct {
-	File   string      `json:"fileMD5"`
-	Path   string      `json:"path"`
-	Issues []IssueItem `json:"issues"`
+	File        string      `json:"fileMD5"`
+	Path        string      `json:"path"`
+	Issues      []IssueItem `json:"issues"`
+	IssueCount  int         `json:"issueCount,omitempty"` // Number of issues in the file (set when the issue details are omitted, see FieldMask)
+	pathOmitted bool        // The path has been left out by a field mask (so it's not rendered at all)
+}
+
+// MarshalJSON renders the file issues, leaving the path out entirely if a field mask asked for it.
+func (f SemgrepFileIssues) MarshalJSON() ([]byte, error) {
+	type fileIssues SemgrepFileIssues // same fields, without this method
+	if !f.pathOmitted {
+		return json.Marshal(fileIssues(f))
+	}
+	return json.Marshal(struct {
+		fileIssues
+		Path string `json:"path,omitempty"` // shadows the embedded path
+	}{fileIssues: fileIssues(f)})
 }
 
 type IssueItem struct {
-	RuleID   string `json:"ruleID"`
-	From     string `json:"from"`
-	To       string `json:"to"`
-	Severity string `json:"severity"`
+	RuleID             string        `json:"ruleID"`
+	From               string        `json:"from"`
+	To                 string        `json:"to"`
+	Severity           string        `json:"severity"`
+	Fingerprint        string        `json:"fingerprint,omitempty"`        // Stable identifier of the finding (see Fingerprint)
+	Suppressed         bool          `json:"suppressed,omitempty"`         // Accepted via an ignore file entry
+	SuppressionReason  string        `json:"suppressionReason,omitempty"`  // Reason recorded in the (possibly expired) ignore file entry
+	SuppressionExpired bool          `json:"suppressionExpired,omitempty"` // Matched an ignore file entry that has expired (not suppressed)
+	Triage             *IssueTriage  `json:"triage,omitempty"`             // Central triage decision recorded for this finding (if any)
+	Rule               *RuleMetadata `json:"rule,omitempty"`               // Rule catalog details (if the rule has been imported)
+}
+
+// Accepted checks if the finding has been accepted, either suppressed by an ignore file or triaged as a false positive or accepted risk.
+func (i IssueItem) Accepted() bool {
+	return i.Suppressed || (i.Triage != nil && (i.Triage.State == TriageFalsePositive || i.Triage.State == TriageAcceptedRisk))
+}
+
+// AcceptanceReason returns why an accepted finding was accepted: the ignore file entry reason, or the triage decision.
+func (i IssueItem) AcceptanceReason() string {
+	switch {
+	case i.Suppressed:
+		return i.SuppressionReason
+	case !i.Accepted():
+		return ""
+	case len(i.Triage.Comment) > 0:
+		return fmt.Sprintf("triaged as %v by %v: %v", i.Triage.State, i.Triage.Author, i.Triage.Comment)
+	default:
+		return fmt.Sprintf("triaged as %v by %v", i.Triage.State, i.Triage.Author)
+	}
+}
+
+// ComponentName returns the purl@version name of the output item (any version requested in the purl is replaced).
+func (i SemgrepOutputItem) ComponentName() string {
+	purl := i.Purl
+	if at := strings.LastIndex(purl, "@"); at > strings.LastIndex(purl, "/") {
+		purl = purl[:at]
+	}
+	if len(i.Version) == 0 {
+		return purl
+	}
+	return purl + "@" + i.Version
+}
+
+// Normalised severities used by all report formats and policies.
+const (
+	SeverityError   = "ERROR"
+	SeverityWarning = "WARNING"
+	SeverityInfo    = "INFO"
+)
+
+// NormaliseSeverity maps the various severity names found in the KB onto ERROR, WARNING or INFO.
+func NormaliseSeverity(severity string) string {
+	switch strings.ToUpper(strings.TrimSpace(severity)) {
+	case "ERROR", "CRITICAL", "HIGH":
+		return SeverityError
+	case "INFO", "LOW", "NOTE", "INVENTORY", "EXPERIMENT":
+		return SeverityInfo
+	default:
+		return SeverityWarning
+	}
 }
 
 // ExportSemgrepOutput converts the SemgrepOutput structure to a byte array.
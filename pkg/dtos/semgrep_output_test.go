@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+import "testing"
+
+func TestNormaliseSeverity(t *testing.T) {
+	tests := map[string]string{
+		"ERROR": SeverityError, "high": SeverityError, "Critical": SeverityError,
+		"WARNING": SeverityWarning, "medium": SeverityWarning, "": SeverityWarning,
+		"INFO": SeverityInfo, "low": SeverityInfo,
+	}
+	for in, want := range tests {
+		if got := NormaliseSeverity(in); got != want {
+			t.Errorf("NormaliseSeverity(%v) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestComponentName(t *testing.T) {
+	tests := []struct {
+		item SemgrepOutputItem
+		want string
+	}{
+		{item: SemgrepOutputItem{Purl: "pkg:npm/lodash", Version: "4.17.21"}, want: "pkg:npm/lodash@4.17.21"},
+		{item: SemgrepOutputItem{Purl: "pkg:npm/lodash@^4.0.0", Version: "4.17.21"}, want: "pkg:npm/lodash@4.17.21"},
+		{item: SemgrepOutputItem{Purl: "pkg:npm/%40babel/core", Version: ""}, want: "pkg:npm/%40babel/core"},
+		{item: SemgrepOutputItem{Purl: "pkg:npm/@babel/core@7.0.0", Version: "7.0.0"}, want: "pkg:npm/@babel/core@7.0.0"},
+	}
+	for _, tt := range tests {
+		if got := tt.item.ComponentName(); got != tt.want {
+			t.Errorf("ComponentName(%v) = %v, want %v", tt.item.Purl, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+import "slices"
+
+// Supported triage states.
+const (
+	TriageFalsePositive = "false_positive"
+	TriageAcceptedRisk  = "accepted_risk"
+	TriageConfirmed     = "confirmed"
+)
+
+// TriageStates lists all the supported triage states.
+var TriageStates = []string{TriageFalsePositive, TriageAcceptedRisk, TriageConfirmed}
+
+// IsTriageState checks if the given state is a supported triage state.
+func IsTriageState(state string) bool {
+	return slices.Contains(TriageStates, state)
+}
+
+// TriageDecision is a central decision on a Semgrep finding.
+// Findings are identified by file MD5 and rule ID, optionally restricted to a line range.
+// Decisions without a project apply globally; project decisions take precedence over global ones.
+type TriageDecision struct {
+	ID        string `json:"id"`
+	Project   string `json:"project,omitempty"`
+	FileMD5   string `json:"fileMd5"`
+	RuleID    string `json:"ruleId"`
+	From      string `json:"from,omitempty"`
+	To        string `json:"to,omitempty"`
+	State     string `json:"state"`
+	Author    string `json:"author"`
+	Comment   string `json:"comment,omitempty"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// TriageFilter restricts the list of triage decisions returned.
+type TriageFilter struct {
+	Project    string // Only decisions for this project
+	GlobalOnly bool   // Only global decisions
+	FileMD5    string
+	RuleID     string
+	State      string
+}
+
+// IssueTriage is the triage state attached to a finding.
+type IssueTriage struct {
+	ID        string `json:"id"`
+	State     string `json:"state"`
+	Project   string `json:"project,omitempty"` // Empty for a global decision
+	Author    string `json:"author"`
+	Comment   string `json:"comment,omitempty"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
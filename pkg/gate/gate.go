@@ -0,0 +1,202 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package gate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// Policy holds the CI gate thresholds. A nil limit means the severity is not limited.
+type Policy struct {
+	MaxErrors   *int     `yaml:"max_errors"`   // Maximum number of ERROR findings allowed
+	MaxWarnings *int     `yaml:"max_warnings"` // Maximum number of WARNING findings allowed
+	MaxInfo     *int     `yaml:"max_info"`     // Maximum number of INFO findings allowed
+	DenyRules   []string `yaml:"deny_rules"`   // Rule ID globs that must not be present (i.e. go.lang.security.*)
+}
+
+// Finding is a single finding, as reported in a violation.
+type Finding struct {
+	Component string
+	RuleID    string
+	Severity  string
+	Path      string
+	From      string
+}
+
+// Violation describes a single breached threshold along with the findings that caused it.
+type Violation struct {
+	Message  string
+	Findings []Finding
+}
+
+// Result is the outcome of checking a Semgrep output against a gate policy.
+type Result struct {
+	Components int
+	Errors     int
+	Warnings   int
+	Info       int
+	Suppressed int                   // Findings accepted via an ignore file or triage (excluded from the checks)
+	Baseline   *dtos.BaselineSummary // Set when only the findings new since a baseline are checked
+	Violations []Violation
+}
+
+// IntPtr returns a pointer to the given limit (convenience for building policies).
+func IntPtr(limit int) *int {
+	return &limit
+}
+
+// DefaultPolicy returns the policy used when nothing else is specified: fail on any ERROR finding.
+func DefaultPolicy() Policy {
+	return Policy{MaxErrors: IntPtr(0)}
+}
+
+// LoadPolicy loads a gate policy from the given YAML file.
+func LoadPolicy(filename string) (Policy, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return Policy{}, fmt.Errorf("failed to read policy file %v: %v", filename, err)
+	}
+	return ParsePolicy(data)
+}
+
+// ParsePolicy parses a YAML gate policy, rejecting unknown settings and invalid values.
+func ParsePolicy(data []byte) (Policy, error) {
+	var policy Policy
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&policy); err != nil && err != io.EOF {
+		return Policy{}, fmt.Errorf("failed to parse policy: %v", err)
+	}
+	if err := policy.Validate(); err != nil {
+		return Policy{}, err
+	}
+	return policy, nil
+}
+
+// Validate checks that the policy limits and rule globs are valid.
+func (p Policy) Validate() error {
+	for name, limit := range map[string]*int{"max_errors": p.MaxErrors, "max_warnings": p.MaxWarnings, "max_info": p.MaxInfo} {
+		if limit != nil && *limit < 0 {
+			return fmt.Errorf("invalid policy: %v cannot be negative (%d)", name, *limit)
+		}
+	}
+	for _, glob := range p.DenyRules {
+		if _, err := path.Match(glob, ""); err != nil {
+			return fmt.Errorf("invalid policy: bad deny_rules glob '%v': %v", glob, err)
+		}
+	}
+	return nil
+}
+
+// Evaluate checks the Semgrep output against the policy. Suppressed findings, and findings triaged as
+// false positives or accepted risks, are not checked.
+func (p Policy) Evaluate(output dtos.SemgrepOutput) Result {
+	result := Result{Components: len(output.Purls), Baseline: output.Baseline}
+	bySeverity := make(map[string][]Finding)
+	denied := make(map[string][]Finding)
+	for _, item := range output.Purls {
+		component := item.ComponentName()
+		for _, file := range item.Files {
+			for _, issue := range file.Issues {
+				if issue.Accepted() {
+					result.Suppressed++
+					continue
+				}
+				severity := dtos.NormaliseSeverity(issue.Severity)
+				f := Finding{Component: component, RuleID: issue.RuleID, Severity: severity, Path: file.Path, From: issue.From}
+				bySeverity[severity] = append(bySeverity[severity], f)
+				for _, glob := range p.DenyRules {
+					if matched, _ := path.Match(glob, issue.RuleID); matched {
+						denied[glob] = append(denied[glob], f)
+						break
+					}
+				}
+			}
+		}
+	}
+	result.Errors = len(bySeverity[dtos.SeverityError])
+	result.Warnings = len(bySeverity[dtos.SeverityWarning])
+	result.Info = len(bySeverity[dtos.SeverityInfo])
+	for _, limit := range []struct {
+		severity string
+		max      *int
+	}{{dtos.SeverityError, p.MaxErrors}, {dtos.SeverityWarning, p.MaxWarnings}, {dtos.SeverityInfo, p.MaxInfo}} {
+		found := bySeverity[limit.severity]
+		if limit.max != nil && len(found) > *limit.max {
+			result.Violations = append(result.Violations, Violation{
+				Message:  fmt.Sprintf("%d %v finding(s) exceed the limit of %d", len(found), limit.severity, *limit.max),
+				Findings: found,
+			})
+		}
+	}
+	for _, glob := range p.DenyRules {
+		if found := denied[glob]; len(found) > 0 {
+			result.Violations = append(result.Violations, Violation{
+				Message:  fmt.Sprintf("%d finding(s) match denied rule '%v'", len(found), glob),
+				Findings: found,
+			})
+		}
+	}
+	return result
+}
+
+// Passed checks if no thresholds were breached.
+func (r Result) Passed() bool {
+	return len(r.Violations) == 0
+}
+
+// WriteSummary writes a concise summary of the result, listing up to maxExamples findings per violation.
+func (r Result) WriteSummary(w io.Writer, maxExamples int) error {
+	var sb strings.Builder
+	verdict := "PASSED"
+	if !r.Passed() {
+		verdict = "FAILED"
+	}
+	fmt.Fprintf(&sb, "Semgrep check %v: %d component(s), %d error, %d warning, %d info finding(s)",
+		verdict, r.Components, r.Errors, r.Warnings, r.Info)
+	if r.Suppressed > 0 {
+		fmt.Fprintf(&sb, ", %d suppressed", r.Suppressed)
+	}
+	if r.Baseline != nil {
+		fmt.Fprintf(&sb, " new since baseline (%d unchanged, %d fixed)", r.Baseline.Unchanged, r.Baseline.Fixed)
+	}
+	sb.WriteString("\n")
+	for _, v := range r.Violations {
+		fmt.Fprintf(&sb, "  - %v\n", v.Message)
+		for i, f := range v.Findings {
+			if i >= maxExamples {
+				fmt.Fprintf(&sb, "      ... and %d more\n", len(v.Findings)-maxExamples)
+				break
+			}
+			location := f.Path
+			if len(f.From) > 0 {
+				location = fmt.Sprintf("%v:%v", f.Path, f.From)
+			}
+			fmt.Fprintf(&sb, "      %v %v %v (%v)\n", f.Severity, f.RuleID, location, f.Component)
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package gate
+
+import (
+	"strings"
+	"testing"
+
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// checkOutput returns 1 error, 2 warning and 1 info findings; only the lodash ones carry a summary location.
+func checkOutput() dtos.SemgrepOutput {
+	return dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{
+		{Purl: "pkg:npm/lodash", Version: "4.17.21", Files: []dtos.SemgrepFileIssues{
+			{Path: "lodash.js", Issues: []dtos.IssueItem{
+				{RuleID: "javascript.lang.security.audit.prototype-pollution", From: "10", Severity: "ERROR"},
+				{RuleID: "javascript.lang.correctness.useless-assign", Severity: "INFO"},
+				{RuleID: "javascript.lang.security.audit.prototype-pollution", From: "30", Severity: "WARNING"},
+			}},
+		}},
+		{Purl: "pkg:github/madler/zlib", Files: []dtos.SemgrepFileIssues{
+			{Issues: []dtos.IssueItem{{RuleID: "c.lang.security.insecure-use-memset", Severity: "WARNING"}}},
+		}},
+	}}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	policy, err := LoadPolicy("./tests/policy.yml")
+	if err != nil {
+		t.Fatalf("LoadPolicy() error = %v", err)
+	}
+	if policy.MaxErrors == nil || *policy.MaxErrors != 0 || policy.MaxWarnings == nil || *policy.MaxWarnings != 1 ||
+		policy.MaxInfo != nil || len(policy.DenyRules) != 1 {
+		t.Errorf("LoadPolicy() = %+v", policy)
+	}
+	if _, err = LoadPolicy("./tests/missing.yml"); err == nil {
+		t.Errorf("LoadPolicy() missing file should fail")
+	}
+}
+
+func TestParsePolicyErrors(t *testing.T) {
+	for _, input := range []string{"max_errors: -1", "max_findings: 3", "deny_rules: ['[bad']", "max_errors: lots"} {
+		if _, err := ParsePolicy([]byte(input)); err == nil {
+			t.Errorf("ParsePolicy(%v) should fail", input)
+		}
+	}
+	if policy, err := ParsePolicy(nil); err != nil || policy.MaxErrors != nil {
+		t.Errorf("ParsePolicy(empty) = %+v, %v", policy, err)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         Policy
+		wantViolations []string
+	}{
+		{name: "default", policy: DefaultPolicy(), wantViolations: []string{"1 ERROR finding(s) exceed the limit of 0"}},
+		{name: "no limits", policy: Policy{}},
+		{name: "generous", policy: Policy{MaxErrors: IntPtr(1), MaxWarnings: IntPtr(2), MaxInfo: IntPtr(1)}},
+		{name: "warnings and rules", policy: Policy{MaxWarnings: IntPtr(1), DenyRules: []string{"c.lang.security.*", "*.useless-assign", "go.*"}},
+			wantViolations: []string{
+				"2 WARNING finding(s) exceed the limit of 1",
+				"1 finding(s) match denied rule 'c.lang.security.*'",
+				"1 finding(s) match denied rule '*.useless-assign'",
+			}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.policy.Evaluate(checkOutput())
+			if result.Components != 2 || result.Errors != 1 || result.Warnings != 2 || result.Info != 1 {
+				t.Errorf("Evaluate() counts = %+v", result)
+			}
+			if len(result.Violations) != len(tt.wantViolations) || result.Passed() != (len(tt.wantViolations) == 0) {
+				t.Fatalf("Evaluate() violations = %+v, want %v", result.Violations, tt.wantViolations)
+			}
+			for i, v := range result.Violations {
+				if v.Message != tt.wantViolations[i] {
+					t.Errorf("Evaluate() violation %d = %v, want %v", i, v.Message, tt.wantViolations[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateSuppressed(t *testing.T) {
+	output := checkOutput()
+	output.Purls[0].Files[0].Issues[0].Suppressed = true
+	output.Purls[0].Files[0].Issues[2].Triage = &dtos.IssueTriage{State: dtos.TriageConfirmed} // still checked
+	output.Purls[1].Files[0].Issues[0].Triage = &dtos.IssueTriage{State: dtos.TriageFalsePositive}
+	policy := Policy{MaxErrors: IntPtr(0), DenyRules: []string{"c.lang.security.*"}}
+	result := policy.Evaluate(output)
+	if !result.Passed() || result.Errors != 0 || result.Warnings != 1 || result.Suppressed != 2 {
+		t.Errorf("Evaluate() with suppressed and triaged findings = %+v", result)
+	}
+	var sb strings.Builder
+	if err := result.WriteSummary(&sb, 5); err != nil || !strings.Contains(sb.String(), ", 2 suppressed") {
+		t.Errorf("WriteSummary() = %v, %v", sb.String(), err)
+	}
+}
+
+func TestEvaluateBaseline(t *testing.T) {
+	output := checkOutput()
+	output.Baseline = &dtos.BaselineSummary{New: 4, Unchanged: 3, Fixed: 2}
+	result := DefaultPolicy().Evaluate(output)
+	var sb strings.Builder
+	if err := result.WriteSummary(&sb, 5); err != nil || !strings.Contains(sb.String(), "finding(s) new since baseline (3 unchanged, 2 fixed)") {
+		t.Errorf("WriteSummary() = %v, %v", sb.String(), err)
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	result := Policy{MaxErrors: IntPtr(0), MaxWarnings: IntPtr(0)}.Evaluate(checkOutput())
+	var sb strings.Builder
+	if err := result.WriteSummary(&sb, 1); err != nil {
+		t.Fatalf("WriteSummary() error = %v", err)
+	}
+	want := `Semgrep check FAILED: 2 component(s), 1 error, 2 warning, 1 info finding(s)
+  - 1 ERROR finding(s) exceed the limit of 0
+      ERROR javascript.lang.security.audit.prototype-pollution lodash.js:10 (pkg:npm/lodash@4.17.21)
+  - 2 WARNING finding(s) exceed the limit of 0
+      WARNING javascript.lang.security.audit.prototype-pollution lodash.js:30 (pkg:npm/lodash@4.17.21)
+      ... and 1 more
+`
+	if sb.String() != want {
+		t.Errorf("WriteSummary() =\n%v\nwant\n%v", sb.String(), want)
+	}
+}
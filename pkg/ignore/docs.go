@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package ignore contains all the logic required to apply a repository ignore file (.scanoss-semgrep.yml)
+// to Semgrep lookup results. Each entry records an accepted finding by purl (with an optional version range),
+// rule ID glob and file path glob, along with a reason and an optional expiry date.
+// Matching findings are marked as suppressed (not removed), and findings matching expired entries are flagged.
+package ignore
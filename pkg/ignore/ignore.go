@@ -0,0 +1,275 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package ignore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	semver "github.com/Masterminds/semver/v3"
+	"gopkg.in/yaml.v3"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// DefaultFilename is the name of the ignore file looked for in the current directory.
+const DefaultFilename = ".scanoss-semgrep.yml"
+
+const expiryFormat = "2006-01-02"
+
+// Entry is a single accepted finding (or set of findings). All the supplied criteria must match.
+// Either a purl or a finding fingerprint is required.
+type Entry struct {
+	Purl        string `yaml:"purl,omitempty" json:"purl,omitempty"`               // Component purl (glob). A version in the purl is treated as an exact version
+	Fingerprint string `yaml:"fingerprint,omitempty" json:"fingerprint,omitempty"` // Finding fingerprint (see dtos.Fingerprint)
+	Versions    string `yaml:"versions,omitempty" json:"versions,omitempty"`       // Optional semver range (i.e. ">=4.0.0, <4.17.21")
+	Rule        string `yaml:"rule,omitempty" json:"rule,omitempty"`               // Optional rule ID glob (i.e. "javascript.lang.security.*")
+	Path        string `yaml:"path,omitempty" json:"path,omitempty"`               // Optional file path glob (i.e. "**/test/**")
+	Reason      string `yaml:"reason" json:"reason"`                               // Why the finding has been accepted
+	Expires     string `yaml:"expires,omitempty" json:"expires,omitempty"`         // Optional last day the entry is valid (YYYY-MM-DD)
+
+	purl     *regexp.Regexp
+	rule     *regexp.Regexp
+	path     *regexp.Regexp
+	versions *semver.Constraints
+	expiry   time.Time
+}
+
+// File is the content of an ignore file.
+type File struct {
+	Suppressions []Entry `yaml:"suppressions" json:"suppressions"`
+}
+
+// Summary reports the outcome of applying an ignore file.
+type Summary struct {
+	Suppressed int     // Number of findings suppressed
+	Expired    int     // Number of findings only matching expired entries
+	Entries    []Entry // Expired entries that matched at least one finding
+}
+
+// Load reads and validates the given ignore file.
+func Load(filename string) (*File, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ignore file %v: %v", filename, err)
+	}
+	f, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ignore file %v: %v", filename, err)
+	}
+	return f, nil
+}
+
+// Parse parses and validates the contents of an ignore file.
+func Parse(data []byte) (*File, error) {
+	var f File
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse ignore file: %v", err)
+	}
+	if err := f.Compile(); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// Compile validates all the entries and prepares them for matching. It must be called on files not loaded using Parse/Load.
+func (f *File) Compile() error {
+	for i := range f.Suppressions {
+		if err := f.Suppressions[i].compile(); err != nil {
+			return fmt.Errorf("suppression %d: %v", i+1, err)
+		}
+	}
+	return nil
+}
+
+// compile validates the entry and builds its matchers.
+func (e *Entry) compile() error {
+	if len(strings.TrimSpace(e.Reason)) == 0 {
+		return errors.New("a reason is required")
+	}
+	e.Fingerprint = strings.ToLower(strings.TrimSpace(e.Fingerprint))
+	name, version := splitPurl(strings.TrimSpace(e.Purl))
+	switch {
+	case len(name) == 0 && len(e.Fingerprint) == 0:
+		return errors.New("a purl or fingerprint is required")
+	case len(name) > 0 && !strings.HasPrefix(name, "pkg:"):
+		return fmt.Errorf("invalid purl '%v'", e.Purl)
+	case len(name) > 0:
+		e.purl = globRegexp(name)
+	}
+	versions := e.Versions
+	if len(version) > 0 {
+		if len(versions) > 0 {
+			return fmt.Errorf("purl '%v' has a version and a version range", e.Purl)
+		}
+		versions = "=" + version
+	}
+	if len(versions) > 0 {
+		c, err := semver.NewConstraint(versions)
+		if err != nil {
+			return fmt.Errorf("invalid version range '%v': %v", versions, err)
+		}
+		e.versions = c
+	}
+	if len(e.Rule) > 0 {
+		e.rule = globRegexp(e.Rule)
+	}
+	if len(e.Path) > 0 {
+		e.path = globRegexp(strings.TrimPrefix(e.Path, "/"))
+	}
+	if len(e.Expires) > 0 {
+		expiry, err := time.Parse(expiryFormat, e.Expires)
+		if err != nil {
+			return fmt.Errorf("invalid expiry date '%v' (expected YYYY-MM-DD)", e.Expires)
+		}
+		e.expiry = expiry.AddDate(0, 0, 1) // valid until the end of the expiry day
+	}
+	return nil
+}
+
+// Expired checks if the entry has expired at the given time.
+func (e *Entry) Expired(now time.Time) bool {
+	return !e.expiry.IsZero() && !now.Before(e.expiry)
+}
+
+// matchesComponent checks if the entry applies to the given component.
+func (e *Entry) matchesComponent(item dtos.SemgrepOutputItem) bool {
+	name, version := splitPurl(item.Purl)
+	if e.purl != nil && !e.purl.MatchString(name) {
+		return false
+	}
+	if e.versions == nil {
+		return true
+	}
+	if len(item.Version) > 0 {
+		version = item.Version
+	}
+	v, err := semver.NewVersion(version)
+	return err == nil && e.versions.Check(v)
+}
+
+// matchesIssue checks if the entry applies to the given issue in the given file.
+func (e *Entry) matchesIssue(file dtos.SemgrepFileIssues, issue dtos.IssueItem) bool {
+	if len(e.Fingerprint) > 0 && e.Fingerprint != issue.Fingerprint {
+		return false
+	}
+	if e.rule != nil && !e.rule.MatchString(issue.RuleID) {
+		return false
+	}
+	return e.path == nil || e.path.MatchString(strings.TrimPrefix(file.Path, "/"))
+}
+
+// Apply marks the findings in the output matching an ignore file entry (updating the output in place).
+// Findings matching an active entry are suppressed. Findings only matching expired entries are flagged as expired.
+func (f *File) Apply(output dtos.SemgrepOutput, now time.Time) Summary {
+	summary := Summary{}
+	if f == nil || len(f.Suppressions) == 0 {
+		return summary
+	}
+	expiredSeen := make(map[int]bool)
+	for _, item := range output.Purls {
+		var entries []int // entries that apply to this component
+		for i := range f.Suppressions {
+			if f.Suppressions[i].matchesComponent(item) {
+				entries = append(entries, i)
+			}
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		for fi := range item.Files {
+			file := item.Files[fi]
+			for ii := range file.Issues {
+				issue := &file.Issues[ii]
+				expired := -1
+				for _, i := range entries {
+					entry := &f.Suppressions[i]
+					if !entry.matchesIssue(file, *issue) {
+						continue
+					}
+					if !entry.Expired(now) {
+						issue.Suppressed, issue.SuppressionReason, issue.SuppressionExpired = true, entry.Reason, false
+						expired = -1
+						break
+					}
+					if expired < 0 {
+						expired = i
+					}
+				}
+				switch {
+				case issue.Suppressed:
+					summary.Suppressed++
+				case expired >= 0:
+					issue.SuppressionReason, issue.SuppressionExpired = f.Suppressions[expired].Reason, true
+					summary.Expired++
+					if !expiredSeen[expired] {
+						expiredSeen[expired] = true
+						summary.Entries = append(summary.Entries, f.Suppressions[expired])
+					}
+				}
+			}
+		}
+	}
+	return summary
+}
+
+// splitPurl splits a purl into its name (without version, qualifiers or subpath) and version.
+func splitPurl(purl string) (string, string) {
+	if i := strings.IndexAny(purl, "?#"); i >= 0 {
+		purl = purl[:i]
+	}
+	if i := strings.LastIndex(purl, "@"); i > strings.LastIndex(purl, "/") {
+		return purl[:i], purl[i+1:]
+	}
+	return purl, ""
+}
+
+// globRegexp converts a glob into an anchored regular expression.
+// '*' matches within a path segment, '**' matches across segments ('**/' also matches no segment) and '?' a single character.
+func globRegexp(glob string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					i++
+					sb.WriteString("(?:.*/)?")
+				} else {
+					sb.WriteString(".*")
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
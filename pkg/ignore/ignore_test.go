@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package ignore
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// suppressOutput returns findings matched by tests/.scanoss-semgrep.yml on purl, version, rule and path.
+func suppressOutput() dtos.SemgrepOutput {
+	return dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{
+		{Purl: "pkg:npm/lodash", Version: "4.17.21", Files: []dtos.SemgrepFileIssues{
+			{Path: "lodash.js", Issues: []dtos.IssueItem{
+				{RuleID: "javascript.lang.security.audit.prototype-pollution"},
+				{RuleID: "javascript.lang.correctness.useless-assign"},
+			}},
+			{Path: "fp/_baseConvert.js", Issues: []dtos.IssueItem{
+				{RuleID: "javascript.lang.security.audit.prototype-pollution-loop"},
+			}},
+		}},
+		{Purl: "pkg:github/madler/zlib@1.2.13", Files: []dtos.SemgrepFileIssues{
+			{Issues: []dtos.IssueItem{{RuleID: "c.lang.security.insecure-use-memset"}}},
+		}},
+	}}
+}
+
+func TestLoadApply(t *testing.T) {
+	f, err := Load("./tests/.scanoss-semgrep.yml")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(f.Suppressions) != 3 {
+		t.Fatalf("Load() suppressions = %v, want 3", len(f.Suppressions))
+	}
+	output := suppressOutput()
+	summary := f.Apply(output, time.Date(2025, 6, 30, 23, 0, 0, 0, time.UTC))
+	if summary.Suppressed != 3 || summary.Expired != 0 {
+		t.Errorf("Apply() before expiry = %+v, want 3 suppressed", summary)
+	}
+	lodash := output.Purls[0].Files
+	if lodash[0].Issues[0].Suppressed {
+		t.Errorf("Apply() suppressed a finding outside of the path glob: %+v", lodash[0].Issues[0])
+	}
+	if issue := lodash[0].Issues[1]; !issue.Suppressed || issue.SuppressionReason != "Style only" {
+		t.Errorf("Apply() correctness finding = %+v", issue)
+	}
+	if issue := lodash[1].Issues[0]; !issue.Suppressed || issue.SuppressionReason != "Only reachable from trusted input" {
+		t.Errorf("Apply() fp finding = %+v", issue)
+	}
+
+	output = suppressOutput()
+	summary = f.Apply(output, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC))
+	if summary.Suppressed != 2 || summary.Expired != 1 || len(summary.Entries) != 1 {
+		t.Errorf("Apply() after expiry = %+v, want 2 suppressed & 1 expired", summary)
+	}
+	if issue := output.Purls[1].Files[0].Issues[0]; issue.Suppressed || !issue.SuppressionExpired || issue.SuppressionReason != "Vendored copy, not compiled" {
+		t.Errorf("Apply() expired finding = %+v", issue)
+	}
+}
+
+func TestApplyVersions(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   string
+		version string
+		want    int
+	}{
+		{name: "in range", entry: "purl: pkg:npm/lodash\nversions: '<4.17.21'\nreason: r", version: "4.17.20", want: 3},
+		{name: "out of range", entry: "purl: pkg:npm/lodash\nversions: '<4.17.21'\nreason: r", version: "4.17.21", want: 0},
+		{name: "exact purl version", entry: "purl: pkg:npm/lodash@4.17.21\nreason: r", version: "4.17.21", want: 3},
+		{name: "other purl version", entry: "purl: pkg:npm/lodash@4.17.20\nreason: r", version: "4.17.21", want: 0},
+		{name: "invalid version", entry: "purl: pkg:npm/lodash\nversions: '>1.0.0'\nreason: r", version: "latest", want: 0},
+		{name: "other purl", entry: "purl: pkg:npm/underscore\nreason: r", version: "4.17.21", want: 0},
+		{name: "fingerprint", entry: "fingerprint: FP1\nreason: r", version: "4.17.21", want: 1},
+		{name: "fingerprint and purl", entry: "purl: pkg:npm/lodash\nfingerprint: fp1\nreason: r", version: "4.17.21", want: 1},
+		{name: "other fingerprint", entry: "fingerprint: fp2\nreason: r", version: "4.17.21", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Parse([]byte("suppressions:\n  - " + strings.ReplaceAll(tt.entry, "\n", "\n    ")))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			output := suppressOutput()
+			output.Purls[0].Version = tt.version
+			output.Purls[0].Files[0].Issues[0].Fingerprint = "fp1"
+			if summary := f.Apply(output, time.Now()); summary.Suppressed != tt.want {
+				t.Errorf("Apply() suppressed = %v, want %v", summary.Suppressed, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "missing reason", data: "suppressions:\n  - purl: pkg:npm/lodash\n"},
+		{name: "missing purl", data: "suppressions:\n  - rule: a.b\n    reason: r\n"},
+		{name: "invalid purl", data: "suppressions:\n  - purl: lodash\n    reason: r\n"},
+		{name: "invalid range", data: "suppressions:\n  - purl: pkg:npm/lodash\n    versions: 'abc'\n    reason: r\n"},
+		{name: "version and range", data: "suppressions:\n  - purl: pkg:npm/lodash@1.0.0\n    versions: '>1.0.0'\n    reason: r\n"},
+		{name: "invalid expiry", data: "suppressions:\n  - purl: pkg:npm/lodash\n    expires: 30/06/2025\n    reason: r\n"},
+		{name: "unknown field", data: "suppressions:\n  - purl: pkg:npm/lodash\n    reason: r\n    owner: me\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.data)); err == nil {
+				t.Errorf("Parse() expected an error")
+			}
+		})
+	}
+	if f, err := Parse(nil); err != nil || len(f.Suppressions) != 0 {
+		t.Errorf("Parse() empty file = %v, %v", f, err)
+	}
+}
+
+func TestGlobRegexp(t *testing.T) {
+	tests := []struct {
+		glob  string
+		value string
+		want  bool
+	}{
+		{glob: "**/test/**", value: "test/a.js", want: true},
+		{glob: "**/test/**", value: "src/test/a/b.js", want: true},
+		{glob: "**/test/**", value: "src/tests/a.js", want: false},
+		{glob: "src/*.c", value: "src/a.c", want: true},
+		{glob: "src/*.c", value: "src/lib/a.c", want: false},
+		{glob: "javascript.*", value: "javascript.lang.security", want: true},
+		{glob: "file?.go", value: "file1.go", want: true},
+		{glob: "a+b", value: "aab", want: false},
+	}
+	for _, tt := range tests {
+		if got := globRegexp(tt.glob).MatchString(tt.value); got != tt.want {
+			t.Errorf("globRegexp(%v).MatchString(%v) = %v, want %v", tt.glob, tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package ingest
+
+import (
+	"crypto/md5"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	purlHelper "github.com/scanoss/go-purl-helper/pkg"
+	"scanoss.com/semgrep/pkg/models"
+)
+
+// LDB CSV files written by WriteLDB.
+const (
+	SemgrepCSV = "semgrep.csv" // md5,ruleID,from,to,severity
+	PivotCSV   = "pivot.csv"   // urlMD5,fileMD5
+	FileCSV    = "file.csv"    // fileMD5,urlMD5,path
+)
+
+// skipDirs are the version control directories not hashed when walking a checkout.
+var skipDirs = map[string]bool{".git": true, ".hg": true, ".svn": true}
+
+// Component identifies the checked-out component version the Semgrep results belong to.
+type Component struct {
+	Purl    string // Component purl
+	Version string // Component version (if not part of the purl)
+	URL     string // Download URL of the version. Defaults to purl@version
+	Ruleset string // Version of the ruleset Semgrep was run with (optional)
+}
+
+// Report is the subset of the `semgrep --json` output used for the import.
+type Report struct {
+	Version string `json:"version"`
+	Results []struct {
+		CheckID string `json:"check_id"`
+		Path    string `json:"path"`
+		Start   struct {
+			Line int `json:"line"`
+		} `json:"start"`
+		End struct {
+			Line int `json:"line"`
+		} `json:"end"`
+		Extra struct {
+			Severity string `json:"severity"`
+		} `json:"extra"`
+	} `json:"results"`
+}
+
+// Result holds the records built from a Semgrep report.
+type Result struct {
+	URL       models.LocalURL
+	Files     []models.LocalFile
+	Issues    []models.SemgrepItem
+	Unmatched int // Findings whose file could not be found in the checkout
+}
+
+// LoadReport loads a `semgrep --json` report from the given file.
+func LoadReport(filename string) (*Report, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read semgrep report %v: %v", filename, err)
+	}
+	report, err := ParseReport(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid semgrep report %v: %v", filename, err)
+	}
+	return report, nil
+}
+
+// ParseReport parses the contents of a `semgrep --json` report.
+func ParseReport(data []byte) (*Report, error) {
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse semgrep report: %v", err)
+	}
+	return &report, nil
+}
+
+// URLHash returns the URL hash (MD5 of the URL) identifying the component version.
+func (c Component) URLHash() string {
+	sum := md5.Sum([]byte(c.url()))
+	return hex.EncodeToString(sum[:])
+}
+
+// url returns the download URL of the component version, defaulting to its purl@version.
+func (c Component) url() string {
+	if len(c.URL) > 0 {
+		return c.URL
+	}
+	return c.Purl + "@" + c.Version
+}
+
+// Build hashes the files of the checkout under root and builds the records for the findings in the report.
+// The component version can either be supplied separately or as part of the purl.
+// Every file of the checkout is recorded (so that clean files are also known), not only the ones with findings.
+func Build(root string, component Component, report *Report) (Result, error) {
+	purl, err := purlHelper.PurlFromString(component.Purl)
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid component purl '%v': %v", component.Purl, err)
+	}
+	if len(component.Version) == 0 {
+		component.Version = purl.Version
+	}
+	if len(component.Version) == 0 {
+		return Result{}, errors.New("a component version is required")
+	}
+	purl.Version = ""
+	component.Purl = purl.ToString()
+	purlName, err := purlHelper.PurlNameFromString(component.Purl)
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid component purl '%v': %v", component.Purl, err)
+	}
+	hashes, err := HashFiles(root)
+	if err != nil {
+		return Result{}, err
+	}
+	result := Result{URL: models.LocalURL{URLHash: component.URLHash(), PurlName: purlName, PurlType: purl.Type,
+		Version: component.Version, URL: component.url(), EngineVersion: report.Version, RulesetVersion: component.Ruleset, ImportedAt: time.Now().UTC()}}
+	paths := make([]string, 0, len(hashes))
+	for path := range hashes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		result.Files = append(result.Files, models.LocalFile{URLHash: result.URL.URLHash, FileMD5: hashes[path], Path: path})
+	}
+	for _, r := range report.Results {
+		md5, found := hashes[relativePath(root, r.Path)]
+		if !found {
+			result.Unmatched++
+			continue
+		}
+		result.Issues = append(result.Issues, models.SemgrepItem{MD5: md5, RuleID: r.CheckID,
+			From: strconv.Itoa(r.Start.Line), To: strconv.Itoa(r.End.Line), Severity: r.Extra.Severity})
+	}
+	return result, nil
+}
+
+// HashFiles returns the MD5 of each regular file under root, keyed by its slash separated path relative to root.
+func HashFiles(root string) (map[string]string, error) {
+	hashes := make(map[string]string)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if skipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		hash, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		hashes[filepath.ToSlash(rel)] = hash
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash the files in %v: %v", root, err)
+	}
+	return hashes, nil
+}
+
+// hashFile returns the hex encoded MD5 of the given file.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := md5.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// relativePath converts a path reported by Semgrep into a path relative to the checkout root.
+// Semgrep reports paths as given on its command line, so they can be absolute, relative to the
+// current directory (i.e. including the checkout directory) or already relative to the checkout.
+func relativePath(root, path string) string {
+	path = filepath.Clean(filepath.FromSlash(path))
+	if filepath.IsAbs(path) {
+		if absRoot, err := filepath.Abs(root); err == nil {
+			if rel, err := filepath.Rel(absRoot, path); err == nil {
+				return filepath.ToSlash(rel)
+			}
+		}
+		return filepath.ToSlash(path)
+	}
+	if prefix := filepath.Clean(root) + string(filepath.Separator); root != "." && strings.HasPrefix(path, prefix) {
+		path = strings.TrimPrefix(path, prefix)
+	}
+	return filepath.ToSlash(path)
+}
+
+// WriteLDB writes the records as CSV files (SemgrepCSV, PivotCSV and FileCSV) into dir, ready for an LDB bulk import.
+// The file table is stored encrypted in the KB, so FileCSV has to go through the usual encoding before import.
+// The component version must also be present in the all_urls table (with the same URL hash) to be found.
+func WriteLDB(dir string, result Result) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory %v: %v", dir, err)
+	}
+	semgrep := make([][]string, 0, len(result.Issues))
+	for _, i := range result.Issues {
+		semgrep = append(semgrep, []string{i.MD5, i.RuleID, i.From, i.To, i.Severity})
+	}
+	pivot := make([][]string, 0, len(result.Files))
+	files := make([][]string, 0, len(result.Files))
+	for _, f := range result.Files {
+		pivot = append(pivot, []string{f.URLHash, f.FileMD5})
+		files = append(files, []string{f.FileMD5, f.URLHash, f.Path})
+	}
+	for name, records := range map[string][][]string{SemgrepCSV: semgrep, PivotCSV: pivot, FileCSV: files} {
+		if err := writeCSV(filepath.Join(dir, name), records); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCSV writes the given records to a CSV file.
+func writeCSV(filename string, records [][]string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create %v: %v", filename, err)
+	}
+	w := csv.NewWriter(f)
+	if err = w.WriteAll(records); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write %v: %v", filename, err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("failed to write %v: %v", filename, err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package ingest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	report, err := LoadReport("tests/semgrep.json")
+	if err != nil {
+		t.Fatalf("LoadReport() error = %v", err)
+	}
+	component := Component{Purl: "pkg:github/acme/tool", Version: "1.0.0"}
+	result, err := Build("tests/checkout", component, report)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if result.URL.URLHash != component.URLHash() || result.URL.PurlName != "acme/tool" || result.URL.PurlType != "github" || result.URL.EngineVersion != "1.85.0" ||
+		result.URL.URL != "pkg:github/acme/tool@1.0.0" {
+		t.Errorf("Build() url = %+v", result.URL)
+	}
+	if len(result.Files) != 3 || result.Files[1].Path != "src/main.py" || result.Files[1].FileMD5 != "2a07664f2245515afff5900355343083" {
+		t.Errorf("Build() files = %+v", result.Files)
+	}
+	if len(result.Issues) != 1 || result.Unmatched != 1 || result.Issues[0].MD5 != result.Files[1].FileMD5 ||
+		result.Issues[0].From != "5" || result.Issues[0].Severity != "ERROR" {
+		t.Errorf("Build() issues = %+v, unmatched = %v", result.Issues, result.Unmatched)
+	}
+	if versioned, err := Build("tests/checkout", Component{Purl: "pkg:github/acme/tool@1.0.0"}, report); err != nil || versioned.URL.URLHash != result.URL.URLHash || versioned.URL.Version != "1.0.0" {
+		t.Errorf("Build() with a versioned purl = %+v, %v", versioned.URL, err)
+	}
+	if _, err = Build("tests/checkout", Component{Purl: "pkg:github/acme/tool"}, report); err == nil {
+		t.Errorf("Build() expected an error without a version")
+	}
+	if _, err = Build("tests/checkout", Component{Purl: "acme/tool", Version: "1.0.0"}, report); err == nil {
+		t.Errorf("Build() expected an error for a bad purl")
+	}
+
+	dir := t.TempDir()
+	if err = WriteLDB(dir, result); err != nil {
+		t.Fatalf("WriteLDB() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, SemgrepCSV))
+	if want := result.Files[1].FileMD5 + ",python.lang.security.audit.dangerous-system-call,5,5,ERROR\n"; err != nil || string(data) != want {
+		t.Errorf("WriteLDB() %v = %q, want %q (%v)", SemgrepCSV, data, want, err)
+	}
+	if data, err = os.ReadFile(filepath.Join(dir, FileCSV)); err != nil || !strings.Contains(string(data), ","+result.URL.URLHash+",src/util.py\n") {
+		t.Errorf("WriteLDB() %v = %q (%v)", FileCSV, data, err)
+	}
+}
+
+func TestRelativePath(t *testing.T) {
+	abs, err := filepath.Abs("tests/checkout/src/main.py")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		root string
+		path string
+		want string
+	}{
+		{root: "tests/checkout", path: "tests/checkout/src/main.py", want: "src/main.py"},
+		{root: "tests/checkout", path: "src/main.py", want: "src/main.py"},
+		{root: "tests/checkout", path: abs, want: "src/main.py"},
+		{root: ".", path: "./src/main.py", want: "src/main.py"},
+	}
+	for _, tt := range tests {
+		if got := relativePath(tt.root, tt.path); got != tt.want {
+			t.Errorf("relativePath(%v, %v) = %v, want %v", tt.root, tt.path, got, tt.want)
+		}
+	}
+}
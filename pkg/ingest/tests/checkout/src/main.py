@@ -0,0 +1,5 @@
+import os
+
+
+def run(cmd):
+    os.system(cmd)
@@ -0,0 +1,428 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file handles reading the layers of a local container image (docker save or OCI layout)
+
+package inputs
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+const (
+	maxImageJSONSize    = 4 * 1024 * 1024  // Largest manifest/index/config blob we're willing to load
+	maxMetadataFileSize = 64 * 1024 * 1024 // Largest package metadata file we're willing to load from a layer
+	whiteoutPrefix      = ".wh."
+	whiteoutOpaque      = ".wh..wh..opq"
+)
+
+// ImagePackage describes a single package found installed inside a container image.
+type ImagePackage struct {
+	Purl    string `json:"purl"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Source  string `json:"source"` // Path of the metadata file (inside the image) the package was read from
+}
+
+// ImageInventory holds the list of packages installed in a container image.
+type ImageInventory struct {
+	Distro   string         `json:"distro,omitempty"`
+	Packages []ImagePackage `json:"packages"`
+}
+
+// dockerManifest represents a single entry of the manifest.json written by 'docker save'.
+type dockerManifest struct {
+	Config string   `json:"Config"`
+	Layers []string `json:"Layers"`
+}
+
+// ociDescriptor represents an OCI content descriptor.
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+}
+
+// ociManifest represents an OCI image index or image manifest (only the fields we need).
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Manifests []ociDescriptor `json:"manifests"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+// imageSource abstracts access to the content of an image, whether it's a tarball or an unpacked OCI layout.
+type imageSource interface {
+	readFile(name string) ([]byte, error)
+	walkLayers(layers []string, fn func(index int, r io.Reader) error) error
+}
+
+// layerFile is a package metadata file extracted from a specific layer.
+type layerFile struct {
+	layer int
+	data  []byte
+}
+
+// imageFiles is the merged view of the package metadata files across all image layers.
+type imageFiles struct {
+	files     map[string]layerFile
+	whiteouts map[string]int // path -> highest layer that removed it
+	opaques   map[string]int // directory -> highest layer that made it opaque
+}
+
+// ParseContainerImage reads the supplied container image (a 'docker save' tarball, an OCI layout tarball
+// or an unpacked OCI layout directory) and returns the inventory of packages installed in it.
+func ParseContainerImage(imagePath string) (ImageInventory, error) {
+	if len(imagePath) == 0 {
+		return ImageInventory{}, errors.New("please specify a container image to parse")
+	}
+	info, err := os.Stat(imagePath)
+	if err != nil {
+		return ImageInventory{}, fmt.Errorf("failed to access container image: %v", err)
+	}
+	var src imageSource
+	if info.IsDir() {
+		src = dirImageSource{root: imagePath}
+	} else {
+		src, err = newTarImageSource(imagePath)
+		if err != nil {
+			return ImageInventory{}, err
+		}
+	}
+	layers, err := imageLayers(src)
+	if err != nil {
+		return ImageInventory{}, err
+	}
+	zlog.S.Debugf("Found %v layers in image %v", len(layers), imagePath)
+	files := &imageFiles{files: make(map[string]layerFile), whiteouts: make(map[string]int), opaques: make(map[string]int)}
+	err = src.walkLayers(layers, files.addLayer)
+	if err != nil {
+		return ImageInventory{}, err
+	}
+	return buildInventory(files.merged())
+}
+
+// Components converts the image inventory into the list of components to query for Semgrep issues.
+// The version is passed as the requirement, as package versions are not necessarily purl safe.
+func (i ImageInventory) Components() []dtos.ComponentDTO {
+	var components []dtos.ComponentDTO
+	seen := make(map[string]bool)
+	for _, p := range i.Packages {
+		purl := stripPurlVersion(p.Purl)
+		if seen[purl+"@"+p.Version] {
+			continue
+		}
+		seen[purl+"@"+p.Version] = true
+		components = append(components, dtos.ComponentDTO{Purl: purl, Requirement: p.Version})
+	}
+	return components
+}
+
+// imageLayers determines the ordered list of layer (file) names making up the image.
+func imageLayers(src imageSource) ([]string, error) {
+	if data, err := src.readFile("manifest.json"); err == nil {
+		var manifests []dockerManifest
+		if err = json.Unmarshal(data, &manifests); err != nil {
+			return nil, fmt.Errorf("failed to parse image manifest.json: %v", err)
+		}
+		if len(manifests) == 0 || len(manifests[0].Layers) == 0 {
+			return nil, errors.New("no layers found in image manifest.json")
+		}
+		if len(manifests) > 1 {
+			zlog.S.Warnf("Image contains %v manifests. Only using the first one", len(manifests))
+		}
+		return manifests[0].Layers, nil
+	}
+	data, err := src.readFile("index.json")
+	if err != nil {
+		return nil, errors.New("not a container image: no manifest.json or index.json found")
+	}
+	return ociLayers(src, data, 0)
+}
+
+// ociLayers walks an OCI index/manifest (recursively) until it finds the list of layer blobs.
+func ociLayers(src imageSource, data []byte, depth int) ([]string, error) {
+	if depth > 4 {
+		return nil, errors.New("too many nested OCI image indexes")
+	}
+	var manifest ociManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse OCI manifest: %v", err)
+	}
+	if len(manifest.Layers) > 0 {
+		layers := make([]string, 0, len(manifest.Layers))
+		for _, l := range manifest.Layers {
+			layers = append(layers, blobPath(l.Digest))
+		}
+		return layers, nil
+	}
+	if len(manifest.Manifests) == 0 {
+		return nil, errors.New("no manifests or layers found in OCI index")
+	}
+	child, err := src.readFile(blobPath(manifest.Manifests[0].Digest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OCI manifest %v: %v", manifest.Manifests[0].Digest, err)
+	}
+	return ociLayers(src, child, depth+1)
+}
+
+// blobPath converts an OCI digest (alg:hex) into its location inside an OCI layout.
+func blobPath(digest string) string {
+	alg, hex, found := strings.Cut(digest, ":")
+	if !found {
+		return path.Join("blobs", "sha256", digest)
+	}
+	return path.Join("blobs", alg, hex)
+}
+
+// tarImageSource reads image content from a tarball.
+type tarImageSource struct {
+	path  string
+	small map[string][]byte // small (JSON) entries loaded on the first pass
+}
+
+// newTarImageSource loads the small entries (manifests, indexes, configs) of an image tarball into memory.
+func newTarImageSource(imagePath string) (*tarImageSource, error) {
+	src := &tarImageSource{path: imagePath, small: make(map[string][]byte)}
+	err := src.walk(func(hdr *tar.Header, r io.Reader) error {
+		if hdr.Size > maxImageJSONSize {
+			return nil
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		src.small[cleanEntryName(hdr.Name)] = data
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return src, nil
+}
+
+// walk iterates over all regular file entries in the image tarball.
+func (t *tarImageSource) walk(fn func(hdr *tar.Header, r io.Reader) error) error {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return fmt.Errorf("failed to open container image: %v", err)
+	}
+	defer closeFile(f)
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read container image tarball: %v", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err = fn(hdr, tr); err != nil {
+			return err
+		}
+	}
+}
+
+func (t *tarImageSource) readFile(name string) ([]byte, error) {
+	data, ok := t.small[cleanEntryName(name)]
+	if !ok {
+		return nil, fmt.Errorf("%v not found in image", name)
+	}
+	return data, nil
+}
+
+func (t *tarImageSource) walkLayers(layers []string, fn func(index int, r io.Reader) error) error {
+	layerIndex := make(map[string]int, len(layers))
+	for i, l := range layers {
+		layerIndex[cleanEntryName(l)] = i
+	}
+	found := 0
+	err := t.walk(func(hdr *tar.Header, r io.Reader) error {
+		i, ok := layerIndex[cleanEntryName(hdr.Name)]
+		if !ok {
+			return nil
+		}
+		found++
+		return fn(i, r)
+	})
+	if err == nil && found < len(layerIndex) {
+		zlog.S.Warnf("Only found %v of %v layers in the image tarball", found, len(layerIndex))
+	}
+	return err
+}
+
+// dirImageSource reads image content from an unpacked OCI layout directory.
+type dirImageSource struct {
+	root string
+}
+
+func (d dirImageSource) readFile(name string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(d.root, filepath.FromSlash(name)))
+}
+
+func (d dirImageSource) walkLayers(layers []string, fn func(index int, r io.Reader) error) error {
+	for i, l := range layers {
+		f, err := os.Open(filepath.Join(d.root, filepath.FromSlash(l)))
+		if err != nil {
+			return fmt.Errorf("failed to open image layer %v: %v", l, err)
+		}
+		err = fn(i, f)
+		closeFile(f)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addLayer reads a single (optionally gzipped) layer tarball and records any package metadata files and whiteouts.
+func (m *imageFiles) addLayer(index int, r io.Reader) error {
+	br := bufio.NewReader(r)
+	var layer io.Reader = br
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return fmt.Errorf("failed to decompress image layer %v: %v", index, err)
+		}
+		defer closeFile(gz)
+		layer = gz
+	}
+	tr := tar.NewReader(layer)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			zlog.S.Warnf("Problem reading image layer %v: %v. Skipping rest of layer", index, err)
+			return nil
+		}
+		name := cleanEntryName(hdr.Name)
+		dir, base := path.Split(name)
+		dir = strings.TrimSuffix(dir, "/")
+		switch {
+		case base == whiteoutOpaque:
+			m.opaques[dir] = max(m.opaques[dir], index)
+		case strings.HasPrefix(base, whiteoutPrefix):
+			removed := path.Join(dir, strings.TrimPrefix(base, whiteoutPrefix))
+			m.whiteouts[removed] = max(m.whiteouts[removed], index)
+		case hdr.Typeflag == tar.TypeReg && isPackageMetadata(name):
+			if hdr.Size > maxMetadataFileSize {
+				zlog.S.Warnf("Skipping oversized package metadata file %v (%v bytes)", name, hdr.Size)
+				continue
+			}
+			if existing, ok := m.files[name]; ok && existing.layer > index {
+				continue
+			}
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return fmt.Errorf("failed to read %v from image layer %v: %v", name, index, err)
+			}
+			m.files[name] = layerFile{layer: index, data: data}
+		}
+	}
+}
+
+// merged returns the package metadata files that are still visible once all layers have been applied.
+func (m *imageFiles) merged() map[string][]byte {
+	ret := make(map[string][]byte, len(m.files))
+	for name, f := range m.files {
+		if !m.removed(name, f.layer) {
+			ret[name] = f.data
+		}
+	}
+	return ret
+}
+
+// removed checks if a file from the given layer was deleted by a whiteout in a later layer.
+func (m *imageFiles) removed(name string, layer int) bool {
+	if l, ok := m.whiteouts[name]; ok && l > layer {
+		return true
+	}
+	for dir := path.Dir(name); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if l, ok := m.whiteouts[dir]; ok && l > layer {
+			return true
+		}
+		if l, ok := m.opaques[dir]; ok && l > layer {
+			return true
+		}
+	}
+	return false
+}
+
+// buildInventory parses all the package metadata files found in the image into a sorted inventory.
+func buildInventory(files map[string][]byte) (ImageInventory, error) {
+	distro := parseOSRelease(files)
+	inventory := ImageInventory{Distro: distro.String()}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pkgs, err := parsePackageMetadata(name, files[name], distro)
+		if err != nil {
+			zlog.S.Warnf("Failed to parse package metadata %v: %v", name, err)
+			continue
+		}
+		inventory.Packages = append(inventory.Packages, pkgs...)
+	}
+	sort.SliceStable(inventory.Packages, func(i, j int) bool {
+		return inventory.Packages[i].Purl < inventory.Packages[j].Purl
+	})
+	zlog.S.Debugf("Found %v packages in image (%v)", len(inventory.Packages), inventory.Distro)
+	return inventory, nil
+}
+
+// cleanEntryName normalises a tar entry name so that it can be compared against image relative paths.
+func cleanEntryName(name string) string {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	return name
+}
+
+// stripPurlVersion removes the version, qualifiers and subpath from a purl string.
+func stripPurlVersion(purl string) string {
+	if i := strings.IndexAny(purl, "?#"); i >= 0 {
+		purl = purl[:i]
+	}
+	if i := strings.LastIndex(purl, "@"); i > 0 {
+		purl = purl[:i]
+	}
+	return purl
+}
+
+// closeFile closes the specified file and logs any errors.
+func closeFile(f io.Closer) {
+	if err := f.Close(); err != nil {
+		zlog.S.Warnf("Problem closing file: %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package inputs
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+func TestParseContainerImage(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	tests := []struct {
+		name       string
+		image      string
+		wantDistro string
+		wantPurls  []string
+	}{
+		{
+			name:       "Docker save with dpkg, npm, pypi and whiteouts",
+			image:      "./tests/debian-docker-save.tar",
+			wantDistro: "debian-12",
+			wantPurls: []string{
+				"pkg:deb/debian/bash@5.2.15-2%2Bb2?arch=amd64&distro=debian-12",
+				"pkg:deb/debian/zlib1g@1%3A1.2.13.dfsg-1?arch=amd64&distro=debian-12",
+				"pkg:npm/%40babel/core@7.23.2",
+				"pkg:npm/lodash@4.17.21",
+				"pkg:pypi/requests-oauthlib@1.3.1",
+			},
+		},
+		{
+			name:       "OCI layout with apk",
+			image:      "./tests/alpine-oci.tar",
+			wantDistro: "alpine-3.19.1",
+			wantPurls: []string{
+				"pkg:apk/alpine/busybox@1.36.1-r15?arch=x86_64&distro=alpine-3.19.1",
+				"pkg:apk/alpine/musl@1.2.4_git20230717-r4?arch=x86_64&distro=alpine-3.19.1",
+			},
+		},
+		{
+			name:       "OCI layout with rpm sqlite",
+			image:      "./tests/fedora-oci.tar",
+			wantDistro: "fedora-39",
+			wantPurls: []string{
+				"pkg:rpm/fedora/bash@5.2.15-5.fc39?arch=x86_64&distro=fedora-39",
+				"pkg:rpm/fedora/openssl-libs@3.1.1-4.fc39?arch=x86_64&distro=fedora-39&epoch=1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inventory, err := ParseContainerImage(tt.image)
+			if err != nil {
+				t.Fatalf("ParseContainerImage() error = %v", err)
+			}
+			fmt.Printf("Inventory: %+v\n", inventory)
+			if inventory.Distro != tt.wantDistro {
+				t.Errorf("ParseContainerImage() distro = %v, want %v", inventory.Distro, tt.wantDistro)
+			}
+			var purls []string
+			for _, p := range inventory.Packages {
+				purls = append(purls, p.Purl)
+			}
+			if !reflect.DeepEqual(purls, tt.wantPurls) {
+				t.Errorf("ParseContainerImage() purls = %v, want %v", purls, tt.wantPurls)
+			}
+		})
+	}
+}
+
+func TestParseContainerImageErrors(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	for _, image := range []string{"", "./tests/does-not-exist.tar", "./container.go"} {
+		_, err = ParseContainerImage(image)
+		if err == nil {
+			t.Errorf("ParseContainerImage(%v) did not return an error", image)
+		} else {
+			fmt.Printf("Got expected error = %v\n", err)
+		}
+	}
+}
+
+func TestImageInventoryComponents(t *testing.T) {
+	inventory := ImageInventory{Packages: []ImagePackage{
+		{Purl: "pkg:deb/debian/bash@5.2.15?arch=amd64&distro=debian-12", Version: "5.2.15"},
+		{Purl: "pkg:deb/debian/bash@5.2.15?arch=i386&distro=debian-12", Version: "5.2.15"},
+		{Purl: "pkg:npm/lodash@4.17.21", Version: "4.17.21"},
+	}}
+	want := []dtos.ComponentDTO{
+		{Purl: "pkg:deb/debian/bash", Requirement: "5.2.15"},
+		{Purl: "pkg:npm/lodash", Requirement: "4.17.21"},
+	}
+	if got := inventory.Components(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Components() = %v, want %v", got, want)
+	}
+}
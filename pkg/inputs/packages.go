@@ -0,0 +1,288 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file handles parsing the OS and language package databases found inside container images
+
+package inputs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+const (
+	dpkgStatusFile    = "var/lib/dpkg/status"
+	dpkgStatusDir     = "var/lib/dpkg/status.d/"
+	apkInstalledFile  = "lib/apk/db/installed"
+	rpmSqliteFile     = "var/lib/rpm/rpmdb.sqlite"
+	rpmSqliteFileUsr  = "usr/lib/sysimage/rpm/rpmdb.sqlite"
+	osReleaseFile     = "etc/os-release"
+	osReleaseFileUsr  = "usr/lib/os-release"
+	nodeModulesDir    = "node_modules"
+	packageJSONFile   = "package.json"
+	distInfoSuffix    = ".dist-info"
+	eggInfoSuffix     = ".egg-info"
+	pythonMetadata    = "METADATA"
+	pythonPkgInfo     = "PKG-INFO"
+	sitePackagesDir   = "site-packages"
+	distPackagesDir   = "dist-packages"
+	defaultDebDistro  = "debian"
+	defaultApkDistro  = "alpine"
+	dpkgInstalledFlag = "installed"
+)
+
+var pypiNameRegex = regexp.MustCompile(`[-_.]+`) // PEP 503 name normalisation
+
+// osRelease holds the distribution details read from /etc/os-release.
+type osRelease struct {
+	ID        string
+	VersionID string
+}
+
+// String returns the distro qualifier value (i.e. debian-12).
+func (o osRelease) String() string {
+	if len(o.ID) == 0 {
+		return ""
+	}
+	if len(o.VersionID) == 0 {
+		return o.ID
+	}
+	return o.ID + "-" + o.VersionID
+}
+
+// isPackageMetadata checks if the given image path is a package database/metadata file we know how to parse.
+func isPackageMetadata(name string) bool {
+	switch name {
+	case dpkgStatusFile, apkInstalledFile, rpmSqliteFile, rpmSqliteFileUsr, osReleaseFile, osReleaseFileUsr:
+		return true
+	}
+	if strings.HasPrefix(name, dpkgStatusDir) {
+		return !strings.HasSuffix(name, ".md5sums")
+	}
+	return isNpmPackageJSON(name) || isPythonMetadata(name)
+}
+
+// isNpmPackageJSON checks if the path is the package.json of a module installed under node_modules.
+func isNpmPackageJSON(name string) bool {
+	parts := strings.Split(name, "/")
+	last := -1
+	for i := range parts {
+		if parts[i] == nodeModulesDir {
+			last = i
+		}
+	}
+	if last < 0 || parts[len(parts)-1] != packageJSONFile {
+		return false
+	}
+	rest := parts[last+1 : len(parts)-1]
+	switch len(rest) {
+	case 1:
+		return !strings.HasPrefix(rest[0], "@") && !strings.HasPrefix(rest[0], ".")
+	case 2:
+		return strings.HasPrefix(rest[0], "@")
+	default:
+		return false
+	}
+}
+
+// isPythonMetadata checks if the path is the metadata file of an installed Python distribution.
+func isPythonMetadata(name string) bool {
+	dir, base := path.Split(strings.TrimSuffix(name, "/"))
+	dir = strings.TrimSuffix(dir, "/")
+	parent := path.Base(path.Dir(dir))
+	if parent != sitePackagesDir && parent != distPackagesDir {
+		return false
+	}
+	return (strings.HasSuffix(dir, distInfoSuffix) && base == pythonMetadata) ||
+		(strings.HasSuffix(dir, eggInfoSuffix) && base == pythonPkgInfo)
+}
+
+// parseOSRelease extracts the distribution ID and version from the image os-release file.
+func parseOSRelease(files map[string][]byte) osRelease {
+	data, ok := files[osReleaseFile]
+	if !ok {
+		data = files[osReleaseFileUsr]
+	}
+	var rel osRelease
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		key, value, found := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !found {
+			continue
+		}
+		value = strings.Trim(value, `"'`)
+		switch key {
+		case "ID":
+			rel.ID = strings.ToLower(value)
+		case "VERSION_ID":
+			rel.VersionID = value
+		}
+	}
+	return rel
+}
+
+// parsePackageMetadata dispatches the given metadata file to the relevant package parser.
+func parsePackageMetadata(name string, data []byte, distro osRelease) ([]ImagePackage, error) {
+	switch {
+	case name == osReleaseFile || name == osReleaseFileUsr:
+		return nil, nil
+	case name == dpkgStatusFile || strings.HasPrefix(name, dpkgStatusDir):
+		return parseDpkgStatus(name, data, distro), nil
+	case name == apkInstalledFile:
+		return parseApkInstalled(name, data, distro), nil
+	case name == rpmSqliteFile || name == rpmSqliteFileUsr:
+		return parseRpmSqlite(name, data, distro)
+	case isNpmPackageJSON(name):
+		return parseNpmPackageJSON(name, data)
+	case isPythonMetadata(name):
+		return parsePythonMetadata(name, data), nil
+	}
+	return nil, fmt.Errorf("unsupported package metadata file: %v", name)
+}
+
+// parseControlParagraphs splits a Debian control style file into a list of field maps.
+func parseControlParagraphs(data []byte, separator string) []map[string]string {
+	var paragraphs []map[string]string
+	current := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMetadataFileSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(strings.TrimSpace(line)) == 0 {
+			if len(current) > 0 {
+				paragraphs = append(paragraphs, current)
+				current = make(map[string]string)
+			}
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' { // continuation line
+			continue
+		}
+		key, value, found := strings.Cut(line, separator)
+		if found {
+			current[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	if len(current) > 0 {
+		paragraphs = append(paragraphs, current)
+	}
+	return paragraphs
+}
+
+// parseDpkgStatus parses a dpkg status database (or distroless status.d entry).
+func parseDpkgStatus(name string, data []byte, distro osRelease) []ImagePackage {
+	namespace := distro.ID
+	if len(namespace) == 0 {
+		namespace = defaultDebDistro
+	}
+	var pkgs []ImagePackage
+	for _, p := range parseControlParagraphs(data, ":") {
+		pkgName, version := p["Package"], p["Version"]
+		if len(pkgName) == 0 || len(version) == 0 {
+			continue
+		}
+		if status, ok := p["Status"]; ok && !strings.HasSuffix(status, " "+dpkgInstalledFlag) {
+			continue // Package has been removed (or only partially installed)
+		}
+		qualifiers := map[string]string{"arch": p["Architecture"], "distro": distro.String()}
+		pkgs = append(pkgs, newImagePackage(packageurl.TypeDebian, namespace, pkgName, version, qualifiers, name))
+	}
+	return pkgs
+}
+
+// parseApkInstalled parses an Alpine apk installed database.
+func parseApkInstalled(name string, data []byte, distro osRelease) []ImagePackage {
+	namespace := distro.ID
+	if len(namespace) == 0 {
+		namespace = defaultApkDistro
+	}
+	var pkgs []ImagePackage
+	for _, p := range parseControlParagraphs(data, ":") {
+		pkgName, version := p["P"], p["V"]
+		if len(pkgName) == 0 || len(version) == 0 {
+			continue
+		}
+		qualifiers := map[string]string{"arch": p["A"], "distro": distro.String()}
+		pkgs = append(pkgs, newImagePackage(packageurl.TypeApk, namespace, pkgName, version, qualifiers, name))
+	}
+	return pkgs
+}
+
+// parseNpmPackageJSON parses the package.json of an installed npm module.
+func parseNpmPackageJSON(name string, data []byte) ([]ImagePackage, error) {
+	var pkg struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("failed to parse package.json: %v", err)
+	}
+	if len(pkg.Name) == 0 || len(pkg.Version) == 0 {
+		return nil, nil
+	}
+	namespace, pkgName := "", pkg.Name
+	if strings.HasPrefix(pkg.Name, "@") {
+		namespace, pkgName, _ = strings.Cut(pkg.Name, "/")
+	}
+	return []ImagePackage{newImagePackage(packageurl.TypeNPM, namespace, pkgName, pkg.Version, nil, name)}, nil
+}
+
+// parsePythonMetadata parses the METADATA/PKG-INFO file of an installed Python distribution.
+func parsePythonMetadata(name string, data []byte) []ImagePackage {
+	var pkgName, version string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(strings.TrimSpace(line)) == 0 {
+			break // End of the headers, the rest is the package description
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "Name":
+			pkgName = strings.TrimSpace(value)
+		case "Version":
+			version = strings.TrimSpace(value)
+		}
+	}
+	if len(pkgName) == 0 || len(version) == 0 {
+		return nil
+	}
+	pkgName = strings.ToLower(pypiNameRegex.ReplaceAllString(pkgName, "-"))
+	return []ImagePackage{newImagePackage(packageurl.TypePyPi, "", pkgName, version, nil, name)}
+}
+
+// newImagePackage builds an ImagePackage (and its purl) from the supplied package details.
+func newImagePackage(purlType, namespace, name, version string, qualifiers map[string]string, source string) ImagePackage {
+	var q packageurl.Qualifiers
+	for k, v := range qualifiers {
+		if len(v) > 0 {
+			q = append(q, packageurl.Qualifier{Key: k, Value: v})
+		}
+	}
+	purl := packageurl.NewPackageURL(purlType, namespace, name, version, q, "")
+	_ = purl.Normalize() // sorts the qualifiers and lower cases the type
+	return ImagePackage{Purl: purl.ToString(), Type: purlType, Name: name, Version: version, Source: source}
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file handles parsing plain lists of purls (CLI arguments, files or stdin)
+
+package inputs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// purlListFile is the JSON object form of a purl list. Both the Semgrep input ('purls')
+// and the SCANOSS API components request ('components') layouts are accepted.
+type purlListFile struct {
+	Purls      []json.RawMessage `json:"purls"`
+	Components []json.RawMessage `json:"components"`
+}
+
+// ParsePurlList parses a list of components from any of the following layouts:
+//   - {"purls": [{"purl": "...", "requirement": "..."}]} (Semgrep input)
+//   - {"components": [{"purl": "...", "requirement": "..."}]} (SCANOSS components request)
+//   - ["pkg:npm/lodash@4.17.21", {"purl": "...", "requirement": "..."}] (JSON array)
+//   - newline separated purls (blank lines and lines starting with '#' are ignored)
+func ParsePurlList(data []byte) ([]dtos.ComponentDTO, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, errors.New("no purl data supplied to parse")
+	}
+	var entries []json.RawMessage
+	switch trimmed[0] {
+	case '{':
+		var file purlListFile
+		if err := json.Unmarshal(trimmed, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse purl list: %v", err)
+		}
+		entries = append(entries, file.Purls...)
+		entries = append(entries, file.Components...)
+	case '[':
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, fmt.Errorf("failed to parse purl list: %v", err)
+		}
+	default:
+		return parsePurlLines(trimmed)
+	}
+	components := make([]dtos.ComponentDTO, 0, len(entries))
+	for i, entry := range entries {
+		var c dtos.ComponentDTO
+		var purl string
+		if err := json.Unmarshal(entry, &purl); err == nil {
+			c.Purl = purl
+		} else if err = json.Unmarshal(entry, &c); err != nil {
+			return nil, fmt.Errorf("failed to parse purl list entry %d: %v", i, err)
+		}
+		if c.Purl = strings.TrimSpace(c.Purl); len(c.Purl) == 0 {
+			return nil, fmt.Errorf("purl list entry %d has no purl", i)
+		}
+		components = append(components, c)
+	}
+	if len(components) == 0 {
+		return nil, errors.New("purl list contains no components")
+	}
+	return components, nil
+}
+
+// parsePurlLines parses a newline separated list of purls.
+func parsePurlLines(data []byte) ([]dtos.ComponentDTO, error) {
+	var components []dtos.ComponentDTO
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, "pkg:") {
+			return nil, fmt.Errorf("invalid purl in list: %v", line)
+		}
+		components = append(components, dtos.ComponentDTO{Purl: line})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read purl list: %v", err)
+	}
+	if len(components) == 0 {
+		return nil, errors.New("purl list contains no components")
+	}
+	return components, nil
+}
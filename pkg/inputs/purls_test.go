@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package inputs
+
+import (
+	"reflect"
+	"testing"
+
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+func TestParsePurlList(t *testing.T) {
+	lodash := dtos.ComponentDTO{Purl: "pkg:npm/lodash", Requirement: "4.17.21"}
+	zlib := dtos.ComponentDTO{Purl: "pkg:github/madler/zlib@1.2.13"}
+	tests := []struct {
+		name    string
+		input   string
+		want    []dtos.ComponentDTO
+		wantErr bool
+	}{
+		{name: "semgrep input", input: `{"purls":[{"purl":"pkg:npm/lodash","requirement":"4.17.21"},{"purl":"pkg:github/madler/zlib@1.2.13"}]}`, want: []dtos.ComponentDTO{lodash, zlib}},
+		{name: "components request", input: `{"components":[{"purl":"pkg:npm/lodash","requirement":"4.17.21"}]}`, want: []dtos.ComponentDTO{lodash}},
+		{name: "mixed array", input: `["pkg:github/madler/zlib@1.2.13", {"purl":"pkg:npm/lodash","requirement":"4.17.21"}]`, want: []dtos.ComponentDTO{zlib, lodash}},
+		{name: "lines", input: "# dependencies\npkg:github/madler/zlib@1.2.13\n\n  pkg:npm/left-pad  \n", want: []dtos.ComponentDTO{zlib, {Purl: "pkg:npm/left-pad"}}},
+		{name: "empty", input: "  \n", wantErr: true},
+		{name: "empty object", input: `{}`, wantErr: true},
+		{name: "missing purl", input: `[{"requirement":"1.0"}]`, wantErr: true},
+		{name: "bad json", input: `{"purls": [`, wantErr: true},
+		{name: "bad line", input: "lodash@4.17.21", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePurlList([]byte(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePurlList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePurlList() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
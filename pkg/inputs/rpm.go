@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file handles reading the RPM sqlite package database (rpmdb.sqlite)
+
+package inputs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/package-url/packageurl-go"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+// RPM header tags and types we're interested in.
+const (
+	rpmTagName        = 1000
+	rpmTagVersion     = 1001
+	rpmTagRelease     = 1002
+	rpmTagEpoch       = 1003
+	rpmTagArch        = 1022
+	rpmTypeInt32      = 4
+	rpmTypeString     = 6
+	rpmIndexEntrySize = 16
+	rpmGPGPubKey      = "gpg-pubkey"
+)
+
+// rpmHeader holds the package details extracted from an RPM header blob.
+type rpmHeader struct {
+	Name    string
+	Version string
+	Release string
+	Epoch   int
+	Arch    string
+}
+
+// parseRpmSqlite reads the list of installed packages from an RPM sqlite database.
+func parseRpmSqlite(name string, data []byte, distro osRelease) ([]ImagePackage, error) {
+	tmp, err := os.CreateTemp("", "scanoss-rpmdb-*.sqlite")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary rpm database: %v", err)
+	}
+	defer removeFile(tmp.Name())
+	_, err = tmp.Write(data)
+	closeFile(tmp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write temporary rpm database: %v", err)
+	}
+	db, err := sqlx.Open("sqlite3", "file:"+tmp.Name()+"?mode=ro&immutable=1")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rpm database: %v", err)
+	}
+	defer closeFile(db)
+	var blobs [][]byte
+	if err = db.Select(&blobs, "SELECT blob FROM Packages"); err != nil {
+		return nil, fmt.Errorf("failed to query rpm database: %v", err)
+	}
+	var pkgs []ImagePackage
+	for _, blob := range blobs {
+		hdr, err := parseRpmHeader(blob)
+		if err != nil {
+			zlog.S.Warnf("Skipping unreadable rpm header in %v: %v", name, err)
+			continue
+		}
+		if len(hdr.Name) == 0 || len(hdr.Version) == 0 || hdr.Name == rpmGPGPubKey {
+			continue
+		}
+		version := hdr.Version
+		if len(hdr.Release) > 0 {
+			version += "-" + hdr.Release
+		}
+		qualifiers := map[string]string{"arch": hdr.Arch, "distro": distro.String()}
+		if hdr.Epoch > 0 {
+			qualifiers["epoch"] = strconv.Itoa(hdr.Epoch)
+		}
+		pkgs = append(pkgs, newImagePackage(packageurl.TypeRPM, distro.ID, hdr.Name, version, qualifiers, name))
+	}
+	return pkgs, nil
+}
+
+// parseRpmHeader decodes an RPM header blob (index count, data length, index entries, data store).
+func parseRpmHeader(blob []byte) (rpmHeader, error) {
+	if len(blob) < 8 {
+		return rpmHeader{}, errors.New("rpm header too short")
+	}
+	indexCount := int(binary.BigEndian.Uint32(blob[0:4]))
+	dataLength := int(binary.BigEndian.Uint32(blob[4:8]))
+	storeStart := 8 + indexCount*rpmIndexEntrySize
+	if indexCount < 0 || dataLength < 0 || storeStart+dataLength > len(blob) {
+		return rpmHeader{}, errors.New("rpm header index out of range")
+	}
+	store := blob[storeStart : storeStart+dataLength]
+	var hdr rpmHeader
+	for i := 0; i < indexCount; i++ {
+		entry := blob[8+i*rpmIndexEntrySize : 8+(i+1)*rpmIndexEntrySize]
+		tag := binary.BigEndian.Uint32(entry[0:4])
+		typ := binary.BigEndian.Uint32(entry[4:8])
+		offset := int(binary.BigEndian.Uint32(entry[8:12]))
+		if offset < 0 || offset >= len(store) {
+			continue
+		}
+		switch {
+		case typ == rpmTypeString:
+			value := rpmString(store[offset:])
+			switch tag {
+			case rpmTagName:
+				hdr.Name = value
+			case rpmTagVersion:
+				hdr.Version = value
+			case rpmTagRelease:
+				hdr.Release = value
+			case rpmTagArch:
+				hdr.Arch = value
+			}
+		case typ == rpmTypeInt32 && tag == rpmTagEpoch && offset+4 <= len(store):
+			hdr.Epoch = int(binary.BigEndian.Uint32(store[offset : offset+4]))
+		}
+	}
+	return hdr, nil
+}
+
+// rpmString reads a NUL terminated string from the RPM header data store.
+func rpmString(data []byte) string {
+	if end := bytes.IndexByte(data, 0); end >= 0 {
+		return string(data[:end])
+	}
+	return string(data)
+}
+
+// removeFile deletes the specified file and logs any errors.
+func removeFile(name string) {
+	if err := os.Remove(name); err != nil {
+		zlog.S.Warnf("Problem removing file %v: %v", name, err)
+	}
+}
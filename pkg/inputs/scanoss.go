@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file handles SCANOSS file/snippet scan results (scanoss.py results JSON)
+
+package inputs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+const (
+	scanossSemgrepKey = "semgrep"
+	scanossNoMatch    = "none"
+)
+
+// ScanossResults represents a SCANOSS scan result. It maps each scanned file path to its list of matches.
+type ScanossResults map[string][]ScanossMatch
+
+// ScanossMatch is a single match entry of a scanned file.
+// Only the fields required for the Semgrep lookup are decoded, everything else is preserved as-is.
+type ScanossMatch struct {
+	ID      string                  `json:"id"`
+	Purl    []string                `json:"purl,omitempty"`
+	Version string                  `json:"version,omitempty"`
+	Semgrep *dtos.SemgrepOutputItem `json:"-"` // Findings of the matched component (added by Annotate)
+	raw     map[string]json.RawMessage
+}
+
+// UnmarshalJSON decodes a match while keeping a copy of all the original fields.
+func (m *ScanossMatch) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	type match ScanossMatch // avoid recursing into this method
+	var decoded match
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*m = ScanossMatch(decoded)
+	m.raw = raw
+	return nil
+}
+
+// MarshalJSON encodes a match with all its original fields, plus the Semgrep findings (if any).
+func (m ScanossMatch) MarshalJSON() ([]byte, error) {
+	out := make(map[string]any, len(m.raw)+1)
+	for k, v := range m.raw {
+		out[k] = v
+	}
+	out["id"] = m.ID
+	if len(m.Purl) > 0 {
+		out["purl"] = m.Purl
+	}
+	if len(m.Version) > 0 {
+		out["version"] = m.Version
+	}
+	delete(out, scanossSemgrepKey)
+	if m.Semgrep != nil {
+		out[scanossSemgrepKey] = m.Semgrep
+	}
+	return json.Marshal(out)
+}
+
+// ParseScanossResults converts the input byte array to a ScanossResults structure.
+func ParseScanossResults(input []byte) (ScanossResults, error) {
+	if len(input) == 0 {
+		return ScanossResults{}, errors.New("no SCANOSS scan results supplied to parse")
+	}
+	var data ScanossResults
+	err := json.Unmarshal(input, &data)
+	if err != nil {
+		zlog.S.Errorf("Parse failure: %v", err)
+		return ScanossResults{}, fmt.Errorf("failed to parse SCANOSS scan results: %v", err)
+	}
+	return data, nil
+}
+
+// matched checks if the match entry refers to a component.
+func (m ScanossMatch) matched() bool {
+	return m.ID != scanossNoMatch && len(m.ID) > 0 && len(m.Purl) > 0 && len(m.Purl[0]) > 0
+}
+
+// componentKey returns the purl@version key used to identify the matched component.
+func (m ScanossMatch) componentKey() string {
+	if len(m.Version) == 0 {
+		return m.Purl[0]
+	}
+	return m.Purl[0] + "@" + m.Version
+}
+
+// Components returns the distinct list of matched components (purl/version pairs) in the scan results.
+func (r ScanossResults) Components() []dtos.ComponentDTO {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, matches := range r {
+		for _, m := range matches {
+			if !m.matched() {
+				continue
+			}
+			key := m.componentKey()
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	components := make([]dtos.ComponentDTO, 0, len(keys))
+	for _, key := range keys {
+		components = append(components, dtos.ComponentDTO{Purl: key})
+	}
+	return components
+}
+
+// Annotate adds the Semgrep findings of each matched component to the scanned files that matched it.
+// The output is expected to come from a lookup of the list returned by Components.
+func (r ScanossResults) Annotate(output dtos.SemgrepOutput) ScanossResults {
+	findings := make(map[string]*dtos.SemgrepOutputItem, len(output.Purls))
+	for i := range output.Purls {
+		findings[output.Purls[i].Purl] = &output.Purls[i]
+	}
+	annotated := make(ScanossResults, len(r))
+	for path, matches := range r {
+		list := make([]ScanossMatch, len(matches))
+		for i, m := range matches {
+			if m.matched() {
+				m.Semgrep = findings[m.componentKey()]
+			}
+			list[i] = m
+		}
+		annotated[path] = list
+	}
+	return annotated
+}
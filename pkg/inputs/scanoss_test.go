@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package inputs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+func TestScanossResults(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	data, err := os.ReadFile("./tests/scanoss-results.json")
+	if err != nil {
+		t.Fatalf("failed to read test results: %v", err)
+	}
+	results, err := ParseScanossResults(data)
+	if err != nil {
+		t.Fatalf("ParseScanossResults() error = %v", err)
+	}
+	want := []dtos.ComponentDTO{
+		{Purl: "pkg:github/madler/zlib@1.2.13"},
+		{Purl: "pkg:github/openssl/openssl@3.0.7"},
+	}
+	if got := results.Components(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Components() = %v, want %v", got, want)
+	}
+	output := dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{
+		{Purl: "pkg:github/openssl/openssl@3.0.7", Version: "3.0.7", Files: []dtos.SemgrepFileIssues{
+			{File: "abc123", Path: "crypto/aes/aes_core.c", Issues: []dtos.IssueItem{{RuleID: "c.lang.security.insecure-use-memset", From: "10", To: "12", Severity: "WARNING"}}},
+		}},
+		{Purl: "pkg:github/madler/zlib@1.2.13", Version: "1.2.13"},
+	}}
+	annotated := results.Annotate(output)
+	if annotated["src/crypto/aes.c"][0].Semgrep == nil || len(annotated["src/util/strings.c"][0].Semgrep.Files) != 1 {
+		t.Errorf("Annotate() openssl matches not annotated: %+v", annotated)
+	}
+	if annotated["src/main.c"][0].Semgrep != nil {
+		t.Errorf("Annotate() annotated an unmatched file: %+v", annotated["src/main.c"][0])
+	}
+	if results["src/crypto/aes.c"][0].Semgrep != nil {
+		t.Errorf("Annotate() modified the original results")
+	}
+	exported, err := json.Marshal(annotated)
+	if err != nil {
+		t.Fatalf("failed to marshal annotated results: %v", err)
+	}
+	fmt.Printf("Annotated: %v\n", string(exported))
+	var decoded map[string][]map[string]any
+	if err = json.Unmarshal(exported, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal annotated results: %v", err)
+	}
+	match := decoded["src/crypto/aes.c"][0]
+	if match["file_hash"] != "3f8e4c1a9d9b5a3f7b6d9b1e2c4a8f01" || match["matched"] != "100%" {
+		t.Errorf("annotated results lost original fields: %v", match)
+	}
+	if _, ok := match["semgrep"]; !ok {
+		t.Errorf("annotated results missing semgrep findings: %v", match)
+	}
+	_, err = ParseScanossResults(nil)
+	if err == nil {
+		t.Errorf("ParseScanossResults() did not fail on empty input")
+	}
+	_, err = ParseScanossResults([]byte(`["not", "results"]`))
+	if err == nil {
+		t.Errorf("ParseScanossResults() did not fail on bad input")
+	}
+}
@@ -81,6 +81,45 @@ func (m *AllUrlsModel) GetUrlsByPurlList(ctx context.Context, s *zap.SugaredLogg
 	return allUrls, nil
 }
 
+// ListMinedURLs retrieves a page of mined URLs (hash, purl name and type), ordered by URL hash.
+// Pass the last URL hash of the previous page to get the next one (empty for the first page).
+func (m *AllUrlsModel) ListMinedURLs(ctx context.Context, afterHash string, limit int) ([]AllURL, error) {
+	var allUrls []AllURL
+	err := m.db.SelectContext(ctx, &allUrls,
+		"SELECT package_hash AS url_hash, purl_name, m.purl_type AS purl_type FROM all_urls u "+
+			"LEFT JOIN mines m ON u.mine_id = m.id "+
+			"WHERE package_hash > $1 AND is_mined = true "+
+			"ORDER BY package_hash LIMIT $2;",
+		afterHash, limit)
+	if err != nil {
+		zlog.S.Errorf("Failed to list mined urls after %v: %v", afterHash, err)
+		return nil, fmt.Errorf("failed to query the all urls table: %v", err)
+	}
+	return allUrls, nil
+}
+
+// GetVersionsByHashes retrieves the component version of each of the given URL hashes (keyed by URL hash).
+func (m *AllUrlsModel) GetVersionsByHashes(ctx context.Context, urlHashes []string) (map[string]string, error) {
+	versions := make(map[string]string, len(urlHashes))
+	if len(urlHashes) == 0 {
+		return versions, nil
+	}
+	query, args, err := sqlx.In("SELECT package_hash AS url_hash, COALESCE(v.version_name, '') AS version FROM all_urls u "+
+		"LEFT JOIN versions v ON u.version_id = v.id WHERE package_hash IN (?)", urlHashes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build all urls version query: %v", err)
+	}
+	var allUrls []AllURL
+	if err = m.db.SelectContext(ctx, &allUrls, m.db.Rebind(query), args...); err != nil {
+		zlog.S.Errorf("Failed to get the versions of %v urls: %v", len(urlHashes), err)
+		return nil, fmt.Errorf("failed to query the all urls table: %v", err)
+	}
+	for _, u := range allUrls {
+		versions[u.URLHash] = u.Version
+	}
+	return versions, nil
+}
+
 // GetUrlsByPurlString searches for component details of the specified Purl string (and optional requirement).
 func (m *AllUrlsModel) GetUrlsByPurlString(ctx context.Context, purlString, purlReq string) (AllURL, error) {
 	if len(purlString) == 0 {
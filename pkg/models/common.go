@@ -22,11 +22,40 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
 )
 
+// maxInListSize is the largest number of values bound in a single IN list, keeping bulk lookups
+// well below the bound parameter limits of SQLite and PostgreSQL.
+const maxInListSize = 500
+
+// inListChunks splits a list of values into chunks small enough to be bound in a single IN list.
+func inListChunks(values []string) [][]string {
+	var chunks [][]string
+	for start := 0; start < len(values); start += maxInListSize {
+		chunks = append(chunks, values[start:min(start+maxInListSize, len(values))])
+	}
+	return chunks
+}
+
+// execSchema runs each of the (semicolon separated) statements of a table schema, named in errors (i.e. "semgrep_triage table").
+// The schemas only use SQL understood by both PostgreSQL and SQLite, and create what's missing (IF NOT EXISTS).
+func execSchema(ctx context.Context, db *sqlx.DB, schema, name string) error {
+	for _, stmt := range strings.Split(schema, ";") {
+		if len(strings.TrimSpace(stmt)) == 0 {
+			continue
+		}
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			zlog.S.Errorf("Failed to create %v: %v", name, err)
+			return fmt.Errorf("failed to create the %v: %v", name, err)
+		}
+	}
+	return nil
+}
+
 // loadSQLData Load the specified SQL files into the supplied DB.
 func loadSQLData(db *sqlx.DB, ctx context.Context, filename string) error {
 	fmt.Printf("Loading test data file: %v\n", filename)
@@ -23,4 +23,9 @@
 // - Projects
 // - All URLs
 // - Golang Projects
+// - Semgrep Triage
+// - Semgrep Peer Stats
+// - Semgrep Rules
+// - Semgrep Local Store (imported Semgrep results)
+// - Semgrep KB provenance (manifest file or KB info tables)
 package models
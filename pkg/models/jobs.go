@@ -0,0 +1,328 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle all interaction with the semgrep_jobs and semgrep_job_results tables
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+// jobsSchema creates the batch job tables (if they don't exist).
+const jobsSchema = `CREATE TABLE IF NOT EXISTS semgrep_jobs
+(
+    id           text      NOT NULL PRIMARY KEY,
+    project      text      NOT NULL DEFAULT '',
+    state        text      NOT NULL,
+    request      text      NOT NULL,
+    resolved     integer   NOT NULL DEFAULT 0,
+    total        integer   NOT NULL DEFAULT 0,
+    message      text      NOT NULL DEFAULT '',
+    owner        text      NOT NULL DEFAULT '',
+    created_at   timestamp NOT NULL,
+    started_at   timestamp,
+    heartbeat_at timestamp,
+    finished_at  timestamp
+);
+CREATE INDEX IF NOT EXISTS semgrep_jobs_state ON semgrep_jobs (state, created_at);
+CREATE TABLE IF NOT EXISTS semgrep_job_results
+(
+    job_id text    NOT NULL,
+    seq    integer NOT NULL,
+    purl   text    NOT NULL,
+    result text    NOT NULL,
+    PRIMARY KEY (job_id, seq)
+);`
+
+const jobColumns = "id, project, state, request, resolved, total, message, owner, created_at, started_at, heartbeat_at, finished_at"
+
+// Batch job states.
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobCompleted = "completed"
+	JobFailed    = "failed"
+	JobCancelled = "cancelled"
+	// jobRequeuing marks the jobs being requeued, while their results are discarded.
+	jobRequeuing = "requeuing"
+)
+
+// ErrJobNotFound is returned when a batch job does not exist.
+var ErrJobNotFound = errors.New("job not found")
+
+// ErrJobNotRunning is returned when updating a job that is no longer running (i.e. it has been cancelled),
+// or that is no longer held by the updating worker (its lease expired and it was requeued).
+var ErrJobNotRunning = errors.New("job not running")
+
+// JobModel handles all interaction with the batch job tables.
+type JobModel struct {
+	db *sqlx.DB
+}
+
+// Job is a single row of the semgrep_jobs table.
+type Job struct {
+	ID          string       `db:"id"`
+	Project     string       `db:"project"`
+	State       string       `db:"state"`
+	Request     string       `db:"request"` // Requested components (JSON)
+	Resolved    int          `db:"resolved"`
+	Total       int          `db:"total"`
+	Message     string       `db:"message"` // Reason a job failed
+	Owner       string       `db:"owner"`   // Worker processing the job (while running)
+	CreatedAt   time.Time    `db:"created_at"`
+	StartedAt   sql.NullTime `db:"started_at"`
+	HeartbeatAt sql.NullTime `db:"heartbeat_at"` // Last time the owner reported it was still processing the job
+	FinishedAt  sql.NullTime `db:"finished_at"`
+}
+
+// JobResult is a single row of the semgrep_job_results table: the issues of one of the requested components.
+type JobResult struct {
+	JobID  string `db:"job_id"`
+	Seq    int    `db:"seq"`
+	Purl   string `db:"purl"`
+	Result string `db:"result"` // Component issues (JSON)
+}
+
+// NewJobModel creates a new instance of the Job Model.
+func NewJobModel(db *sqlx.DB) *JobModel {
+	return &JobModel{db: db}
+}
+
+// CreateTables creates the batch job tables and indexes if they are missing.
+func (m *JobModel) CreateTables(ctx context.Context) error {
+	return execSchema(ctx, m.db, jobsSchema, "semgrep job tables")
+}
+
+// Create inserts a new (queued) job.
+func (m *JobModel) Create(ctx context.Context, j Job) error {
+	_, err := m.db.NamedExecContext(ctx, "INSERT INTO semgrep_jobs ("+jobColumns+") VALUES"+
+		" (:id, :project, :state, :request, :resolved, :total, :message, :owner, :created_at, :started_at, :heartbeat_at, :finished_at)", j)
+	if err != nil {
+		zlog.S.Errorf("Failed to insert job %v: %v", j.ID, err)
+		return fmt.Errorf("failed to insert into the semgrep_jobs table: %v", err)
+	}
+	return nil
+}
+
+// Get retrieves a job by ID.
+func (m *JobModel) Get(ctx context.Context, id string) (Job, error) {
+	var j Job
+	err := m.db.GetContext(ctx, &j, "SELECT "+jobColumns+" FROM semgrep_jobs WHERE id = $1", id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Job{}, ErrJobNotFound
+		}
+		zlog.S.Errorf("Failed to query job %v: %v", id, err)
+		return Job{}, fmt.Errorf("failed to query the semgrep_jobs table: %v", err)
+	}
+	return j, nil
+}
+
+// ClaimNext marks the oldest queued job as running (held by the given owner) and returns it. It returns false if there are no queued jobs.
+// Jobs are claimed with a conditional update, so concurrent workers never claim the same job.
+func (m *JobModel) ClaimNext(ctx context.Context, owner string, now time.Time) (Job, bool, error) {
+	for {
+		var id string
+		err := m.db.GetContext(ctx, &id, "SELECT id FROM semgrep_jobs WHERE state = $1 ORDER BY created_at, id LIMIT 1", JobQueued)
+		if errors.Is(err, sql.ErrNoRows) {
+			return Job{}, false, nil
+		}
+		if err != nil {
+			zlog.S.Errorf("Failed to query queued jobs: %v", err)
+			return Job{}, false, fmt.Errorf("failed to query the semgrep_jobs table: %v", err)
+		}
+		res, err := m.db.ExecContext(ctx, "UPDATE semgrep_jobs SET state = $1, owner = $2, started_at = $3, heartbeat_at = $3 WHERE id = $4 AND state = $5",
+			JobRunning, owner, now, id, JobQueued)
+		if err != nil {
+			zlog.S.Errorf("Failed to claim job %v: %v", id, err)
+			return Job{}, false, fmt.Errorf("failed to update the semgrep_jobs table: %v", err)
+		}
+		if n, err := res.RowsAffected(); err == nil && n == 0 {
+			continue // claimed (or cancelled) by someone else in the meantime
+		}
+		j, err := m.Get(ctx, id)
+		return j, err == nil, err
+	}
+}
+
+// Heartbeat records that the owner is still processing a running job, extending its lease.
+// It returns ErrJobNotRunning if the job is no longer running or held by the owner.
+func (m *JobModel) Heartbeat(ctx context.Context, id, owner string, now time.Time) error {
+	res, err := m.db.ExecContext(ctx, "UPDATE semgrep_jobs SET heartbeat_at = $1 WHERE id = $2 AND state = $3 AND owner = $4",
+		now, id, JobRunning, owner)
+	if err != nil {
+		zlog.S.Errorf("Failed to record job %v heartbeat: %v", id, err)
+		return fmt.Errorf("failed to update the semgrep_jobs table: %v", err)
+	}
+	return checkJobRowsAffected(res, ErrJobNotRunning)
+}
+
+// UpdateProgress records the progress of a running job (extending its lease).
+// It returns ErrJobNotRunning if the job is no longer running or held by the owner.
+func (m *JobModel) UpdateProgress(ctx context.Context, id, owner string, resolved, total int, now time.Time) error {
+	res, err := m.db.ExecContext(ctx, "UPDATE semgrep_jobs SET resolved = $1, total = $2, heartbeat_at = $3 WHERE id = $4 AND state = $5 AND owner = $6",
+		resolved, total, now, id, JobRunning, owner)
+	if err != nil {
+		zlog.S.Errorf("Failed to update job %v progress: %v", id, err)
+		return fmt.Errorf("failed to update the semgrep_jobs table: %v", err)
+	}
+	return checkJobRowsAffected(res, ErrJobNotRunning)
+}
+
+// Finish records the final state (and failure message) of a running job.
+// It returns ErrJobNotRunning if the job is no longer running or held by the owner.
+func (m *JobModel) Finish(ctx context.Context, id, owner, state, message string, now time.Time) error {
+	res, err := m.db.ExecContext(ctx, "UPDATE semgrep_jobs SET state = $1, message = $2, finished_at = $3 WHERE id = $4 AND state = $5 AND owner = $6",
+		state, message, now, id, JobRunning, owner)
+	if err != nil {
+		zlog.S.Errorf("Failed to finish job %v: %v", id, err)
+		return fmt.Errorf("failed to update the semgrep_jobs table: %v", err)
+	}
+	return checkJobRowsAffected(res, ErrJobNotRunning)
+}
+
+// Cancel cancels a queued or running job. It returns ErrJobNotRunning if the job has already finished.
+// Running jobs stop the next time their worker records progress.
+func (m *JobModel) Cancel(ctx context.Context, id string, now time.Time) error {
+	res, err := m.db.ExecContext(ctx, "UPDATE semgrep_jobs SET state = $1, finished_at = $2 WHERE id = $3 AND state IN ($4, $5)",
+		JobCancelled, now, id, JobQueued, JobRunning)
+	if err != nil {
+		zlog.S.Errorf("Failed to cancel job %v: %v", id, err)
+		return fmt.Errorf("failed to update the semgrep_jobs table: %v", err)
+	}
+	if err = checkJobRowsAffected(res, ErrJobNotRunning); err != nil {
+		if _, getErr := m.Get(ctx, id); getErr != nil {
+			return getErr
+		}
+		return err
+	}
+	return nil
+}
+
+// Requeue puts a running job held by the owner back in the queue, discarding any results already recorded.
+// It's used for the jobs interrupted by a server shutdown. It returns ErrJobNotRunning if the job is no longer running or held by the owner.
+func (m *JobModel) Requeue(ctx context.Context, id, owner string) error {
+	n, err := m.requeue(ctx, "id = $3 AND owner = $4", id, owner)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrJobNotRunning
+	}
+	return nil
+}
+
+// RequeueExpired puts the running jobs whose lease expired (no heartbeat since the given time) back in the queue,
+// discarding any results already recorded. It's used for the jobs of workers that died. It returns the number of jobs requeued.
+func (m *JobModel) RequeueExpired(ctx context.Context, before time.Time) (int64, error) {
+	return m.requeue(ctx, "(heartbeat_at IS NULL OR heartbeat_at < $3)", before)
+}
+
+// requeue puts the running jobs matching the condition back in the queue, discarding their results. The condition placeholders start at $3.
+func (m *JobModel) requeue(ctx context.Context, condition string, args ...any) (int64, error) {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start a semgrep_jobs transaction: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	// Move the jobs out of the running state first, so their (previous) owners can no longer add results
+	if _, err = tx.ExecContext(ctx, "UPDATE semgrep_jobs SET state = $1 WHERE state = $2 AND "+condition,
+		append([]any{jobRequeuing, JobRunning}, args...)...); err != nil {
+		zlog.S.Errorf("Failed to requeue running jobs: %v", err)
+		return 0, fmt.Errorf("failed to update the semgrep_jobs table: %v", err)
+	}
+	if _, err = tx.ExecContext(ctx, "DELETE FROM semgrep_job_results WHERE job_id IN (SELECT id FROM semgrep_jobs WHERE state = $1)", jobRequeuing); err != nil {
+		zlog.S.Errorf("Failed to delete interrupted job results: %v", err)
+		return 0, fmt.Errorf("failed to delete from the semgrep_job_results table: %v", err)
+	}
+	res, err := tx.ExecContext(ctx, "UPDATE semgrep_jobs SET state = $1, resolved = 0, owner = '', started_at = NULL, heartbeat_at = NULL"+
+		" WHERE state = $2", JobQueued, jobRequeuing)
+	if err != nil {
+		zlog.S.Errorf("Failed to requeue running jobs: %v", err)
+		return 0, fmt.Errorf("failed to update the semgrep_jobs table: %v", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit the semgrep_jobs transaction: %v", err)
+	}
+	return res.RowsAffected()
+}
+
+// AddResult records the issues of one of the requested components of a job, as long as the job is still running and held by the owner.
+// It returns ErrJobNotRunning otherwise, so a worker that lost its job can't add to the results of the worker that took it over.
+func (m *JobModel) AddResult(ctx context.Context, r JobResult, owner string) error {
+	res, err := m.db.ExecContext(ctx, "INSERT INTO semgrep_job_results (job_id, seq, purl, result) SELECT $1, CAST($2 AS integer), $3, $4"+
+		" WHERE EXISTS (SELECT 1 FROM semgrep_jobs WHERE id = $1 AND state = $5 AND owner = $6)",
+		r.JobID, r.Seq, r.Purl, r.Result, JobRunning, owner)
+	if err != nil {
+		zlog.S.Errorf("Failed to insert job %v result %v: %v", r.JobID, r.Seq, err)
+		return fmt.Errorf("failed to insert into the semgrep_job_results table: %v", err)
+	}
+	return checkJobRowsAffected(res, ErrJobNotRunning)
+}
+
+// Results retrieves up to limit results of a job, in request order, starting after the given sequence number (-1 for the first page).
+func (m *JobModel) Results(ctx context.Context, id string, afterSeq, limit int) ([]JobResult, error) {
+	var results []JobResult
+	err := m.db.SelectContext(ctx, &results,
+		"SELECT job_id, seq, purl, result FROM semgrep_job_results WHERE job_id = $1 AND seq > $2 ORDER BY seq LIMIT $3", id, afterSeq, limit)
+	if err != nil {
+		zlog.S.Errorf("Failed to query job %v results: %v", id, err)
+		return nil, fmt.Errorf("failed to query the semgrep_job_results table: %v", err)
+	}
+	return results, nil
+}
+
+// DeleteFinishedBefore removes the jobs (and their results) that finished before the given time, returning the number of jobs removed.
+func (m *JobModel) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start a semgrep_jobs transaction: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err = tx.ExecContext(ctx, "DELETE FROM semgrep_job_results WHERE job_id IN (SELECT id FROM semgrep_jobs WHERE finished_at < $1)", before); err != nil {
+		zlog.S.Errorf("Failed to delete expired job results: %v", err)
+		return 0, fmt.Errorf("failed to delete from the semgrep_job_results table: %v", err)
+	}
+	res, err := tx.ExecContext(ctx, "DELETE FROM semgrep_jobs WHERE finished_at < $1", before)
+	if err != nil {
+		zlog.S.Errorf("Failed to delete expired jobs: %v", err)
+		return 0, fmt.Errorf("failed to delete from the semgrep_jobs table: %v", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit the semgrep_jobs transaction: %v", err)
+	}
+	return res.RowsAffected()
+}
+
+// checkJobRowsAffected returns notFound if the statement did not change any rows.
+func checkJobRowsAffected(res sql.Result, notFound error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check the semgrep_jobs table update: %v", err)
+	}
+	if n == 0 {
+		return notFound
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestJobModel(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	db.SetMaxOpenConns(1) // keep the in-memory DB alive across calls
+	defer CloseDB(db)
+	model := NewJobModel(db)
+	if err = model.CreateTables(ctx); err != nil {
+		t.Fatalf("CreateTables() error = %v", err)
+	}
+	if err = model.CreateTables(ctx); err != nil {
+		t.Fatalf("CreateTables() should be idempotent: %v", err)
+	}
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i, id := range []string{"b", "a", "c"} {
+		if err = model.Create(ctx, Job{ID: id, State: JobQueued, Request: `[]`, Total: 2, CreatedAt: now.Add(time.Duration(i) * time.Minute)}); err != nil {
+			t.Fatalf("Create(%v) error = %v", id, err)
+		}
+	}
+	job, ok, err := model.ClaimNext(ctx, "w1", now)
+	if err != nil || !ok || job.ID != "b" || job.State != JobRunning || job.Owner != "w1" || !job.StartedAt.Valid || !job.HeartbeatAt.Valid || job.FinishedAt.Valid {
+		t.Fatalf("ClaimNext() = %+v, %v, %v", job, ok, err)
+	}
+	for seq, purl := range []string{"pkg:npm/a", "pkg:npm/b"} {
+		if err = model.AddResult(ctx, JobResult{JobID: "b", Seq: seq, Purl: purl, Result: `{}`}, "w1"); err != nil {
+			t.Fatalf("AddResult() error = %v", err)
+		}
+	}
+	if err = model.UpdateProgress(ctx, "b", "w1", 2, 2, now); err != nil {
+		t.Errorf("UpdateProgress() error = %v", err)
+	}
+	// Only the owner can update a running job
+	if err = model.AddResult(ctx, JobResult{JobID: "b", Seq: 2, Purl: "pkg:npm/c", Result: `{}`}, "w2"); !errors.Is(err, ErrJobNotRunning) {
+		t.Errorf("AddResult() other owner error = %v, want ErrJobNotRunning", err)
+	}
+	if err = model.UpdateProgress(ctx, "b", "w2", 1, 2, now); !errors.Is(err, ErrJobNotRunning) {
+		t.Errorf("UpdateProgress() other owner error = %v, want ErrJobNotRunning", err)
+	}
+	if err = model.Heartbeat(ctx, "b", "w2", now); !errors.Is(err, ErrJobNotRunning) {
+		t.Errorf("Heartbeat() other owner error = %v, want ErrJobNotRunning", err)
+	}
+	if err = model.Finish(ctx, "b", "w2", JobCompleted, "", now); !errors.Is(err, ErrJobNotRunning) {
+		t.Errorf("Finish() other owner error = %v, want ErrJobNotRunning", err)
+	}
+	if results, err := model.Results(ctx, "b", 0, 10); err != nil || len(results) != 1 || results[0].Purl != "pkg:npm/b" {
+		t.Errorf("Results() = %v, %v", results, err)
+	}
+	if err = model.Finish(ctx, "b", "w1", JobCompleted, "", now); err != nil {
+		t.Errorf("Finish() error = %v", err)
+	}
+	if err = model.Finish(ctx, "b", "w1", JobFailed, "boom", now); !errors.Is(err, ErrJobNotRunning) {
+		t.Errorf("Finish() finished job error = %v, want ErrJobNotRunning", err)
+	}
+	// Cancelled jobs can no longer be updated by their worker
+	if job, _, _ = model.ClaimNext(ctx, "w1", now); job.ID != "a" {
+		t.Fatalf("ClaimNext() = %v, want a", job.ID)
+	}
+	if err = model.Cancel(ctx, "a", now); err != nil {
+		t.Errorf("Cancel() error = %v", err)
+	}
+	if err = model.UpdateProgress(ctx, "a", "w1", 1, 2, now); !errors.Is(err, ErrJobNotRunning) {
+		t.Errorf("UpdateProgress() cancelled job error = %v, want ErrJobNotRunning", err)
+	}
+	if err = model.Cancel(ctx, "b", now); !errors.Is(err, ErrJobNotRunning) {
+		t.Errorf("Cancel() completed job error = %v, want ErrJobNotRunning", err)
+	}
+	if err = model.Cancel(ctx, "missing", now); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Cancel() missing job error = %v, want ErrJobNotFound", err)
+	}
+	// Jobs whose lease expired are put back in the queue, without their partial results
+	if job, _, _ = model.ClaimNext(ctx, "w1", now); job.ID != "c" {
+		t.Fatalf("ClaimNext() = %v, want c", job.ID)
+	}
+	if err = model.AddResult(ctx, JobResult{JobID: "c", Seq: 0, Purl: "pkg:npm/c", Result: `{}`}, "w1"); err != nil {
+		t.Fatalf("AddResult() error = %v", err)
+	}
+	if err = model.Heartbeat(ctx, "c", "w1", now.Add(time.Minute)); err != nil {
+		t.Errorf("Heartbeat() error = %v", err)
+	}
+	if n, err := model.RequeueExpired(ctx, now.Add(time.Minute)); err != nil || n != 0 {
+		t.Errorf("RequeueExpired() live job = %v, %v", n, err)
+	}
+	if n, err := model.RequeueExpired(ctx, now.Add(2*time.Minute)); err != nil || n != 1 {
+		t.Errorf("RequeueExpired() = %v, %v", n, err)
+	}
+	if job, err = model.Get(ctx, "c"); err != nil || job.State != JobQueued || job.Owner != "" || job.StartedAt.Valid || job.HeartbeatAt.Valid {
+		t.Errorf("Get() requeued job = %+v, %v", job, err)
+	}
+	if results, _ := model.Results(ctx, "c", -1, 10); len(results) != 0 {
+		t.Errorf("Results() requeued job = %v", results)
+	}
+	// The previous owner can no longer add results to a requeued job
+	if err = model.AddResult(ctx, JobResult{JobID: "c", Seq: 1, Purl: "pkg:npm/d", Result: `{}`}, "w1"); !errors.Is(err, ErrJobNotRunning) {
+		t.Errorf("AddResult() requeued job error = %v, want ErrJobNotRunning", err)
+	}
+	// Jobs interrupted by a shutdown are put back in the queue by their owner
+	if job, _, _ = model.ClaimNext(ctx, "w2", now); job.ID != "c" {
+		t.Fatalf("ClaimNext() requeued job = %v, want c", job.ID)
+	}
+	if err = model.Requeue(ctx, "c", "w1"); !errors.Is(err, ErrJobNotRunning) {
+		t.Errorf("Requeue() other owner error = %v, want ErrJobNotRunning", err)
+	}
+	if err = model.Requeue(ctx, "c", "w2"); err != nil {
+		t.Errorf("Requeue() error = %v", err)
+	}
+	if _, ok, _ = model.ClaimNext(ctx, "w1", now); !ok {
+		t.Errorf("ClaimNext() requeued job not claimed")
+	}
+	if _, ok, err = model.ClaimNext(ctx, "w1", now); ok || err != nil {
+		t.Errorf("ClaimNext() empty queue = %v, %v", ok, err)
+	}
+	// Only jobs finished before the cut-off are removed
+	if n, err := model.DeleteFinishedBefore(ctx, now.Add(time.Second)); err != nil || n != 2 {
+		t.Errorf("DeleteFinishedBefore() = %v, %v", n, err)
+	}
+	if _, err = model.Get(ctx, "b"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Get() expired job error = %v", err)
+	}
+	if results, _ := model.Results(ctx, "b", -1, 10); len(results) != 0 {
+		t.Errorf("Results() expired job = %v", results)
+	}
+	if job, err = model.Get(ctx, "c"); err != nil || job.FinishedAt != (sql.NullTime{}) {
+		t.Errorf("Get() running job = %+v, %v", job, err)
+	}
+}
@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle all interaction with the KB provenance (manifest file and semgrep_kb_* tables)
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+// kbInfoSchema creates the KB provenance tables (if they don't exist).
+const kbInfoSchema = `CREATE TABLE IF NOT EXISTS semgrep_kb_info
+(
+    kb_version      text      NOT NULL PRIMARY KEY,
+    snapshot_date   text      NOT NULL DEFAULT '',
+    engine_version  text      NOT NULL DEFAULT '',
+    ruleset_version text      NOT NULL DEFAULT '',
+    loaded_at       timestamp NOT NULL
+);
+CREATE TABLE IF NOT EXISTS semgrep_kb_components
+(
+    url_hash        text NOT NULL PRIMARY KEY,
+    scan_date       text NOT NULL DEFAULT '',
+    engine_version  text NOT NULL DEFAULT '',
+    ruleset_version text NOT NULL DEFAULT ''
+);`
+
+// KBInfo identifies a knowledge base snapshot along with the Semgrep engine and ruleset used to build it.
+type KBInfo struct {
+	Version        string `json:"kb_version" db:"kb_version"`
+	SnapshotDate   string `json:"snapshot_date" db:"snapshot_date"`
+	EngineVersion  string `json:"engine_version" db:"engine_version"`
+	RulesetVersion string `json:"ruleset_version" db:"ruleset_version"`
+}
+
+// ComponentScan records when and how a single component version (URL) was analysed.
+type ComponentScan struct {
+	URLHash        string `json:"url_hash" db:"url_hash"`
+	ScanDate       string `json:"scan_date" db:"scan_date"`
+	EngineVersion  string `json:"engine_version" db:"engine_version"`
+	RulesetVersion string `json:"ruleset_version" db:"ruleset_version"`
+}
+
+// KBManifest is the provenance manifest shipped next to the LDB tables.
+// Components only need listing when they were analysed with a different engine/ruleset or date than the snapshot.
+type KBManifest struct {
+	KBInfo
+	Components []ComponentScan `json:"components,omitempty"`
+}
+
+// LoadKBManifest loads a KB provenance manifest from the given JSON file.
+func LoadKBManifest(filename string) (*KBManifest, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read KB manifest %v: %v", filename, err)
+	}
+	var manifest KBManifest
+	if err = json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid KB manifest %v: %v", filename, err)
+	}
+	if len(manifest.Version) == 0 {
+		return nil, fmt.Errorf("invalid KB manifest %v: kb_version is required", filename)
+	}
+	return &manifest, nil
+}
+
+// KBManifestFile serves the KB provenance from a manifest file, reloading it whenever the file changes.
+// A missing manifest is not an error: the provenance is simply unknown.
+type KBManifestFile struct {
+	filename string
+	mu       sync.Mutex
+	modTime  time.Time
+	info     *KBInfo
+	scans    map[string]ComponentScan
+}
+
+// NewKBManifestFile creates a new KB provenance source reading the given manifest file.
+func NewKBManifestFile(filename string) *KBManifestFile {
+	return &KBManifestFile{filename: filename}
+}
+
+// load (re)reads the manifest if it has changed since the last call.
+func (m *KBManifestFile) load() (*KBInfo, map[string]ComponentScan, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stat, err := os.Stat(m.filename)
+	if errors.Is(err, os.ErrNotExist) {
+		m.info, m.scans, m.modTime = nil, nil, time.Time{}
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to check KB manifest %v: %v", m.filename, err)
+	}
+	if m.info != nil && stat.ModTime().Equal(m.modTime) {
+		return m.info, m.scans, nil
+	}
+	manifest, err := LoadKBManifest(m.filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	m.info, m.modTime = &manifest.KBInfo, stat.ModTime()
+	m.scans = make(map[string]ComponentScan, len(manifest.Components))
+	for _, c := range manifest.Components {
+		m.scans[c.URLHash] = c
+	}
+	zlog.S.Debugf("Loaded KB manifest %v (version %v, %v component(s))", m.filename, manifest.Version, len(manifest.Components))
+	return m.info, m.scans, nil
+}
+
+// Info returns the KB snapshot described by the manifest (nil if there is no manifest).
+func (m *KBManifestFile) Info(_ context.Context) (*KBInfo, error) {
+	info, _, err := m.load()
+	return info, err
+}
+
+// ComponentScans returns the manifest entries of the given URLs (keyed by URL hash).
+func (m *KBManifestFile) ComponentScans(_ context.Context, urlHashes []string) (map[string]ComponentScan, error) {
+	_, scans, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]ComponentScan)
+	for _, hash := range urlHashes {
+		if scan, found := scans[hash]; found {
+			result[hash] = scan
+		}
+	}
+	return result, nil
+}
+
+// KBInfoModel serves the KB provenance from the semgrep_kb_info and semgrep_kb_components tables.
+type KBInfoModel struct {
+	db *sqlx.DB
+}
+
+// NewKBInfoModel creates a new instance of the KB Info Model.
+func NewKBInfoModel(db *sqlx.DB) *KBInfoModel {
+	return &KBInfoModel{db: db}
+}
+
+// CreateTables creates the KB provenance tables if they are missing.
+func (m *KBInfoModel) CreateTables(ctx context.Context) error {
+	return execSchema(ctx, m.db, kbInfoSchema, "semgrep KB info tables")
+}
+
+// Import stores the manifest as the current KB snapshot along with its component entries (in a single transaction).
+func (m *KBInfoModel) Import(ctx context.Context, manifest KBManifest) error {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		zlog.S.Errorf("Failed to start KB info transaction: %v", err)
+		return fmt.Errorf("failed to update the semgrep KB info: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	info := manifest.KBInfo
+	if _, err = tx.ExecContext(ctx, "INSERT INTO semgrep_kb_info (kb_version, snapshot_date, engine_version, ruleset_version, loaded_at)"+
+		" VALUES ($1, $2, $3, $4, $5) ON CONFLICT (kb_version) DO UPDATE SET snapshot_date = excluded.snapshot_date,"+
+		" engine_version = excluded.engine_version, ruleset_version = excluded.ruleset_version, loaded_at = excluded.loaded_at",
+		info.Version, info.SnapshotDate, info.EngineVersion, info.RulesetVersion, time.Now().UTC()); err != nil {
+		zlog.S.Errorf("Failed to insert KB info %v: %v", info.Version, err)
+		return fmt.Errorf("failed to insert into the semgrep_kb_info table: %v", err)
+	}
+	for _, c := range manifest.Components {
+		if _, err = tx.NamedExecContext(ctx, "INSERT INTO semgrep_kb_components (url_hash, scan_date, engine_version, ruleset_version)"+
+			" VALUES (:url_hash, :scan_date, :engine_version, :ruleset_version) ON CONFLICT (url_hash) DO UPDATE SET"+
+			" scan_date = excluded.scan_date, engine_version = excluded.engine_version, ruleset_version = excluded.ruleset_version", c); err != nil {
+			zlog.S.Errorf("Failed to insert KB component %v: %v", c.URLHash, err)
+			return fmt.Errorf("failed to insert into the semgrep_kb_components table: %v", err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		zlog.S.Errorf("Failed to commit KB info import: %v", err)
+		return fmt.Errorf("failed to update the semgrep KB info: %v", err)
+	}
+	return nil
+}
+
+// Info returns the most recently loaded KB snapshot (nil if none has been loaded).
+func (m *KBInfoModel) Info(ctx context.Context) (*KBInfo, error) {
+	var info KBInfo
+	err := m.db.QueryRowxContext(ctx, "SELECT kb_version, snapshot_date, engine_version, ruleset_version FROM semgrep_kb_info"+
+		" ORDER BY loaded_at DESC LIMIT 1").StructScan(&info)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		zlog.S.Errorf("Failed to query KB info: %v", err)
+		return nil, fmt.Errorf("failed to query the semgrep_kb_info table: %v", err)
+	}
+	return &info, nil
+}
+
+// ComponentScans returns the recorded scans of the given URLs (keyed by URL hash), looked up in chunks of maxInListSize.
+func (m *KBInfoModel) ComponentScans(ctx context.Context, urlHashes []string) (map[string]ComponentScan, error) {
+	result := make(map[string]ComponentScan)
+	for _, chunk := range inListChunks(urlHashes) {
+		query, args, err := sqlx.In("SELECT url_hash, scan_date, engine_version, ruleset_version FROM semgrep_kb_components WHERE url_hash IN (?)", chunk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build KB components query: %v", err)
+		}
+		var rows []ComponentScan
+		if err = m.db.SelectContext(ctx, &rows, m.db.Rebind(query), args...); err != nil {
+			zlog.S.Errorf("Failed to query KB components for %v urls: %v", len(chunk), err)
+			return nil, fmt.Errorf("failed to query the semgrep_kb_components table: %v", err)
+		}
+		for _, r := range rows {
+			result[r.URLHash] = r
+		}
+	}
+	return result, nil
+}
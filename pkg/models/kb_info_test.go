@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+const testManifestURL = "4d66775f503b1e76582e7e5b2ea54d92"
+
+func TestKBManifestFile(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	filename := filepath.Join(t.TempDir(), "semgrep-manifest.json")
+	source := NewKBManifestFile(filename)
+	if info, err := source.Info(ctx); err != nil || info != nil {
+		t.Errorf("Info() without a manifest = %+v, %v", info, err)
+	}
+	data, err := os.ReadFile("tests/semgrep-manifest.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(filename, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	info, err := source.Info(ctx)
+	if want := (KBInfo{Version: "25.09", SnapshotDate: "2025-09-01", EngineVersion: "1.85.0", RulesetVersion: "semgrep-rules@2025-08-28"}); err != nil || info == nil || *info != want {
+		t.Errorf("Info() = %+v, %v", info, err)
+	}
+	scans, err := source.ComponentScans(ctx, []string{testManifestURL, "other"})
+	if err != nil || len(scans) != 1 || scans[testManifestURL].ScanDate != "2025-08-30" {
+		t.Errorf("ComponentScans() = %+v, %v", scans, err)
+	}
+	// A changed manifest is reloaded
+	later := time.Now().Add(time.Minute)
+	if err = os.WriteFile(filename, []byte(`{"kb_version": "25.10"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chtimes(filename, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if info, err = source.Info(ctx); err != nil || info == nil || info.Version != "25.10" {
+		t.Errorf("Info() after a change = %+v, %v", info, err)
+	}
+	if err = os.WriteFile(filename, []byte(`{"snapshot_date": "2025-10-01"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chtimes(filename, later.Add(time.Minute), later.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = source.Info(ctx); err == nil {
+		t.Errorf("Info() expected an error for a manifest without a KB version")
+	}
+}
+
+func TestKBInfoModel(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer CloseDB(db)
+	model := NewKBInfoModel(db)
+	if err = model.CreateTables(ctx); err != nil {
+		t.Fatalf("CreateTables() error = %v", err)
+	}
+	if info, err := model.Info(ctx); err != nil || info != nil {
+		t.Errorf("Info() without a KB = %+v, %v", info, err)
+	}
+	manifest, err := LoadKBManifest("tests/semgrep-manifest.json")
+	if err != nil {
+		t.Fatalf("LoadKBManifest() error = %v", err)
+	}
+	if err = model.Import(ctx, *manifest); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if err = model.Import(ctx, *manifest); err != nil {
+		t.Fatalf("Import() again error = %v", err)
+	}
+	if info, err := model.Info(ctx); err != nil || info == nil || *info != manifest.KBInfo {
+		t.Errorf("Info() = %+v, %v", info, err)
+	}
+	scans, err := model.ComponentScans(ctx, []string{testManifestURL, "other"})
+	if err != nil || len(scans) != 1 || scans[testManifestURL] != manifest.Components[0] {
+		t.Errorf("ComponentScans() = %+v, %v", scans, err)
+	}
+	// Large URL lists are looked up in chunks
+	urls := make([]string, 0, 2*maxInListSize+1)
+	for i := 0; i < 2*maxInListSize; i++ {
+		urls = append(urls, fmt.Sprintf("url%04d", i))
+	}
+	if scans, err = model.ComponentScans(ctx, append(urls, testManifestURL)); err != nil || len(scans) != 1 || scans[testManifestURL] != manifest.Components[0] {
+		t.Errorf("ComponentScans() chunked = %+v, %v", scans, err)
+	}
+	if _, err = LoadKBManifest("tests/missing-manifest.json"); err == nil {
+		t.Errorf("LoadKBManifest() expected an error for a missing file")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
 )
 
 type SemgrepItem struct {
@@ -81,7 +82,7 @@ func QueryBulkPivotLDB(keys []string) map[string][]string {
 
 		buffer, errLDB := ldbCmd.Output()
 		if errLDB != nil {
-			fmt.Println(errLDB)
+			zlog.S.Errorf("Failed to query pivot LDB table: %v", errLDB)
 		}
 		// split results line by line
 		// each row contains 3 values: <UrlMD5>,<FileMD5>,unknown
@@ -150,6 +151,19 @@ func ContainsTable(arr []string, value string) bool {
 }
 
 func QueryBulkFileLDB(fileURL []string) map[string]string {
+	paths := QueryBulkFilePairsLDB(fileURL)
+	ret := make(map[string]string, len(paths))
+	for _, pair := range fileURL {
+		if v, exists := paths[pair]; exists {
+			ret[strings.Split(pair, "-")[0]] = v
+		}
+	}
+	return ret
+}
+
+// QueryBulkFilePairsLDB returns the path of each of the given <file md5>-<url md5> pairs, keyed by the pair.
+// Unlike QueryBulkFileLDB, the same file can be looked up within several URLs.
+func QueryBulkFilePairsLDB(fileURL []string) map[string]string {
 	name := fmt.Sprintf("/tmp/%s-file.txt", uuid.New().String())
 	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE, 0600)
 	if err != nil {
@@ -189,8 +203,7 @@ func QueryBulkFileLDB(fileURL []string) map[string]string {
 		ret := make(map[string]string)
 		for r := range fileURL {
 			if v, exists := res[fileURL[r]]; exists {
-				pair := strings.Split(fileURL[r], "-")
-				ret[pair[0]] = v
+				ret[fileURL[r]] = v
 			}
 		}
 
@@ -199,3 +212,38 @@ func QueryBulkFileLDB(fileURL []string) map[string]string {
 	}
 	return map[string]string{}
 }
+
+// QueryBulkFilePathsLDB returns the path of each of the given files (keyed by file MD5), taken from the first URL the file table lists for it.
+// It is used when the URL the files came from is unknown (see QueryBulkFileLDB otherwise).
+func QueryBulkFilePathsLDB(fileMD5s []string) map[string]string {
+	ret := make(map[string]string)
+	name := fmt.Sprintf("/tmp/%s-file.txt", uuid.New().String())
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return ret
+	}
+	defer os.Remove(name)
+	var written = 0
+	for _, md5 := range fileMD5s {
+		n, err := f.WriteString(fmt.Sprintf("select from %s key %s csv hex 32\n", LDBFileTableName, md5))
+		if err == nil {
+			written += n
+		}
+	}
+	f.Close()
+	if written == 0 {
+		return ret
+	}
+	ldbCmd := exec.Command(LDBEncBinPath, "-f", name)
+	buffer, _ := ldbCmd.Output()
+	// each row contains 3 values: <FileMD5>,<UrlMD5>,<path>
+	for _, line := range strings.Split(string(buffer), "\n") {
+		fields := strings.SplitN(line, ",", 3)
+		if len(fields) == 3 {
+			if _, found := ret[fields[0]]; !found {
+				ret[fields[0]] = fields[2]
+			}
+		}
+	}
+	return ret
+}
@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle all interaction with the semgrep_local_* tables (locally imported Semgrep results)
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+// localStoreSchema creates the local store tables (if they don't exist).
+// They mirror the KB: component URLs (all_urls), URL to file pivot & file paths (pivot & file LDB tables) and file issues (semgrep LDB table).
+// Issues are recorded against the URL they were imported with, so re-importing a component doesn't touch the issues of others sharing its files.
+const localStoreSchema = `CREATE TABLE IF NOT EXISTS semgrep_local_urls
+(
+    url_hash        text      NOT NULL PRIMARY KEY,
+    purl_name       text      NOT NULL,
+    purl_type       text      NOT NULL DEFAULT '',
+    version         text      NOT NULL,
+    url             text      NOT NULL DEFAULT '',
+    engine_version  text      NOT NULL DEFAULT '',
+    ruleset_version text      NOT NULL DEFAULT '',
+    imported_at     timestamp NOT NULL
+);
+CREATE INDEX IF NOT EXISTS semgrep_local_urls_purl_name ON semgrep_local_urls (purl_name);
+CREATE TABLE IF NOT EXISTS semgrep_local_files
+(
+    url_hash text NOT NULL,
+    file_md5 text NOT NULL,
+    path     text NOT NULL,
+    PRIMARY KEY (url_hash, file_md5)
+);
+CREATE TABLE IF NOT EXISTS semgrep_local_issues
+(
+    url_hash  text NOT NULL,
+    file_md5  text NOT NULL,
+    rule_id   text NOT NULL,
+    line_from text NOT NULL DEFAULT '',
+    line_to   text NOT NULL DEFAULT '',
+    severity  text NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS semgrep_local_issues_file_md5 ON semgrep_local_issues (file_md5);
+CREATE INDEX IF NOT EXISTS semgrep_local_issues_url_hash ON semgrep_local_issues (url_hash);`
+
+// LocalStoreModel handles all interaction with the local store tables.
+type LocalStoreModel struct {
+	db *sqlx.DB
+}
+
+// LocalURL is a single component version imported into the local store.
+type LocalURL struct {
+	URLHash        string    `db:"url_hash"`
+	PurlName       string    `db:"purl_name"`
+	PurlType       string    `db:"purl_type"`
+	Version        string    `db:"version"`
+	URL            string    `db:"url"`
+	EngineVersion  string    `db:"engine_version"`  // Semgrep version that produced the results
+	RulesetVersion string    `db:"ruleset_version"` // Ruleset the results were produced with (if known)
+	ImportedAt     time.Time `db:"imported_at"`
+}
+
+// LocalFile is a single file of a component version imported into the local store.
+type LocalFile struct {
+	URLHash string `db:"url_hash"`
+	FileMD5 string `db:"file_md5"`
+	Path    string `db:"path"`
+}
+
+// localIssue is a single row of the semgrep_local_issues table.
+type localIssue struct {
+	URLHash  string `db:"url_hash"`
+	FileMD5  string `db:"file_md5"`
+	RuleID   string `db:"rule_id"`
+	From     string `db:"line_from"`
+	To       string `db:"line_to"`
+	Severity string `db:"severity"`
+}
+
+// NewLocalStoreModel creates a new instance of the Local Store Model.
+func NewLocalStoreModel(db *sqlx.DB) *LocalStoreModel {
+	return &LocalStoreModel{db: db}
+}
+
+// CreateTables creates the local store tables and indexes if they are missing.
+func (m *LocalStoreModel) CreateTables(ctx context.Context) error {
+	return execSchema(ctx, m.db, localStoreSchema, "semgrep local store tables")
+}
+
+// Import stores a component version along with its files and their issues (in a single transaction).
+// Any previous import of the same URL (files and issues included) is replaced.
+func (m *LocalStoreModel) Import(ctx context.Context, url LocalURL, files []LocalFile, issues []SemgrepItem) error {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		zlog.S.Errorf("Failed to start local store transaction: %v", err)
+		return fmt.Errorf("failed to update the semgrep local store: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	for _, stmt := range []string{"DELETE FROM semgrep_local_issues WHERE url_hash = $1", "DELETE FROM semgrep_local_files WHERE url_hash = $1",
+		"DELETE FROM semgrep_local_urls WHERE url_hash = $1"} {
+		if _, err = tx.ExecContext(ctx, stmt, url.URLHash); err != nil {
+			zlog.S.Errorf("Failed to clear local store url %v: %v", url.URLHash, err)
+			return fmt.Errorf("failed to clear the semgrep local store: %v", err)
+		}
+	}
+	if _, err = tx.NamedExecContext(ctx, "INSERT INTO semgrep_local_urls (url_hash, purl_name, purl_type, version, url, engine_version, ruleset_version, imported_at)"+
+		" VALUES (:url_hash, :purl_name, :purl_type, :version, :url, :engine_version, :ruleset_version, :imported_at)", url); err != nil {
+		zlog.S.Errorf("Failed to insert local store url %v: %v", url.URLHash, err)
+		return fmt.Errorf("failed to insert into the semgrep_local_urls table: %v", err)
+	}
+	for _, file := range files {
+		if _, err = tx.NamedExecContext(ctx, "INSERT INTO semgrep_local_files (url_hash, file_md5, path) VALUES (:url_hash, :file_md5, :path)"+
+			" ON CONFLICT (url_hash, file_md5) DO NOTHING", file); err != nil {
+			zlog.S.Errorf("Failed to insert local store file %v: %v", file.FileMD5, err)
+			return fmt.Errorf("failed to insert into the semgrep_local_files table: %v", err)
+		}
+	}
+	for _, issue := range issues {
+		row := localIssue{URLHash: url.URLHash, FileMD5: issue.MD5, RuleID: issue.RuleID, From: issue.From, To: issue.To, Severity: issue.Severity}
+		if _, err = tx.NamedExecContext(ctx, "INSERT INTO semgrep_local_issues (url_hash, file_md5, rule_id, line_from, line_to, severity)"+
+			" VALUES (:url_hash, :file_md5, :rule_id, :line_from, :line_to, :severity)", row); err != nil {
+			zlog.S.Errorf("Failed to insert local store issue %v/%v: %v", issue.MD5, issue.RuleID, err)
+			return fmt.Errorf("failed to insert into the semgrep_local_issues table: %v", err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		zlog.S.Errorf("Failed to commit local store import: %v", err)
+		return fmt.Errorf("failed to update the semgrep local store: %v", err)
+	}
+	return nil
+}
+
+// GetURLsByPurlNames retrieves the imported versions of the given components (in the same shape as the all_urls lookups).
+// The components are looked up in chunks of maxInListSize, as are the lists of all the other lookups.
+func (m *LocalStoreModel) GetURLsByPurlNames(ctx context.Context, purlNames []string) ([]AllURL, error) {
+	var urls []AllURL
+	for _, chunk := range inListChunks(purlNames) {
+		query, args, err := sqlx.In("SELECT url_hash, purl_name, purl_type, version FROM semgrep_local_urls WHERE purl_name IN (?)", chunk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build local urls query: %v", err)
+		}
+		var found []AllURL
+		if err = m.db.SelectContext(ctx, &found, m.db.Rebind(query), args...); err != nil {
+			zlog.S.Errorf("Failed to query local store urls for %v: %v", strings.Join(chunk, ","), err)
+			return nil, fmt.Errorf("failed to query the semgrep_local_urls table: %v", err)
+		}
+		urls = append(urls, found...)
+	}
+	for i := range urls {
+		urls[i].Component = urls[i].PurlName
+	}
+	return urls, nil
+}
+
+// GetFiles retrieves the files of the given imported URLs (ordered by URL hash and path).
+func (m *LocalStoreModel) GetFiles(ctx context.Context, urlHashes []string) ([]LocalFile, error) {
+	var files []LocalFile
+	for _, chunk := range inListChunks(urlHashes) {
+		query, args, err := sqlx.In("SELECT url_hash, file_md5, path FROM semgrep_local_files WHERE url_hash IN (?)", chunk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build local files query: %v", err)
+		}
+		var found []LocalFile
+		if err = m.db.SelectContext(ctx, &found, m.db.Rebind(query), args...); err != nil {
+			zlog.S.Errorf("Failed to query local store files for %v urls: %v", len(chunk), err)
+			return nil, fmt.Errorf("failed to query the semgrep_local_files table: %v", err)
+		}
+		files = append(files, found...)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].URLHash != files[j].URLHash {
+			return files[i].URLHash < files[j].URLHash
+		}
+		return files[i].Path < files[j].Path
+	})
+	return files, nil
+}
+
+// GetIssues retrieves the imported issues of the given files (keyed by file MD5).
+// A file imported with several URLs gets the issues of the most recent import reporting any.
+func (m *LocalStoreModel) GetIssues(ctx context.Context, fileMD5s []string) (map[string][]SemgrepItem, error) {
+	result := make(map[string][]SemgrepItem)
+	for _, chunk := range inListChunks(fileMD5s) {
+		query, args, err := sqlx.In("SELECT i.url_hash, i.file_md5, i.rule_id, i.line_from, i.line_to, i.severity FROM semgrep_local_issues i"+
+			" JOIN semgrep_local_urls u ON u.url_hash = i.url_hash WHERE i.file_md5 IN (?) ORDER BY u.imported_at DESC, i.url_hash", chunk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build local issues query: %v", err)
+		}
+		var rows []localIssue
+		if err = m.db.SelectContext(ctx, &rows, m.db.Rebind(query), args...); err != nil {
+			zlog.S.Errorf("Failed to query local store issues for %v files: %v", len(chunk), err)
+			return nil, fmt.Errorf("failed to query the semgrep_local_issues table: %v", err)
+		}
+		source := make(map[string]string) // URL hash the issues of each file are taken from
+		for _, r := range rows {
+			if url, found := source[r.FileMD5]; found && url != r.URLHash {
+				continue
+			}
+			source[r.FileMD5] = r.URLHash
+			result[r.FileMD5] = append(result[r.FileMD5], SemgrepItem{MD5: r.FileMD5, RuleID: r.RuleID, From: r.From, To: r.To, Severity: r.Severity})
+		}
+	}
+	return result, nil
+}
+
+// ComponentScans returns the import details of the given local URLs (keyed by URL hash). The import date is used as the scan date.
+func (m *LocalStoreModel) ComponentScans(ctx context.Context, urlHashes []string) (map[string]ComponentScan, error) {
+	result := make(map[string]ComponentScan)
+	for _, chunk := range inListChunks(urlHashes) {
+		query, args, err := sqlx.In("SELECT url_hash, purl_name, version, engine_version, ruleset_version, imported_at FROM semgrep_local_urls WHERE url_hash IN (?)", chunk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build local urls query: %v", err)
+		}
+		var urls []LocalURL
+		if err = m.db.SelectContext(ctx, &urls, m.db.Rebind(query), args...); err != nil {
+			zlog.S.Errorf("Failed to query local store urls for %v urls: %v", len(chunk), err)
+			return nil, fmt.Errorf("failed to query the semgrep_local_urls table: %v", err)
+		}
+		for _, u := range urls {
+			result[u.URLHash] = ComponentScan{URLHash: u.URLHash, ScanDate: u.ImportedAt.UTC().Format(time.RFC3339),
+				EngineVersion: u.EngineVersion, RulesetVersion: u.RulesetVersion}
+		}
+	}
+	return result, nil
+}
+
+// GetFilesByMD5 retrieves the imported files with the given MD5s (from any URL), ordered by file MD5 and URL hash.
+func (m *LocalStoreModel) GetFilesByMD5(ctx context.Context, fileMD5s []string) ([]LocalFile, error) {
+	var files []LocalFile
+	for _, chunk := range inListChunks(fileMD5s) {
+		query, args, err := sqlx.In("SELECT url_hash, file_md5, path FROM semgrep_local_files WHERE file_md5 IN (?)", chunk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build local files query: %v", err)
+		}
+		var found []LocalFile
+		if err = m.db.SelectContext(ctx, &found, m.db.Rebind(query), args...); err != nil {
+			zlog.S.Errorf("Failed to query local store files for %v md5s: %v", len(chunk), err)
+			return nil, fmt.Errorf("failed to query the semgrep_local_files table: %v", err)
+		}
+		files = append(files, found...)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].FileMD5 != files[j].FileMD5 {
+			return files[i].FileMD5 < files[j].FileMD5
+		}
+		return files[i].URLHash < files[j].URLHash
+	})
+	return files, nil
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestLocalStoreModel(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer CloseDB(db)
+	model := NewLocalStoreModel(db)
+	if err = model.CreateTables(ctx); err != nil {
+		t.Fatalf("CreateTables() error = %v", err)
+	}
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	url := LocalURL{URLHash: "u1", PurlName: "acme/tool", PurlType: "github", Version: "1.0.0", EngineVersion: "1.85.0", ImportedAt: now}
+	files := []LocalFile{{URLHash: "u1", FileMD5: "f1", Path: "src/main.c"}, {URLHash: "u1", FileMD5: "f2", Path: "src/util.c"}}
+	issues := []SemgrepItem{{MD5: "f1", RuleID: "c.rule.a", From: "1", To: "2", Severity: "ERROR"}, {MD5: "f1", RuleID: "c.rule.b", From: "5", To: "5", Severity: "INFO"}}
+	if err = model.Import(ctx, url, files, issues); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	// Re-import with fewer files and issues
+	if err = model.Import(ctx, url, files[:1], issues[:1]); err != nil {
+		t.Fatalf("Import() again error = %v", err)
+	}
+	urls, err := model.GetURLsByPurlNames(ctx, []string{"acme/tool", "other"})
+	if err != nil || len(urls) != 1 || urls[0].URLHash != "u1" || urls[0].Version != "1.0.0" || urls[0].PurlType != "github" {
+		t.Errorf("GetURLsByPurlNames() = %+v, %v", urls, err)
+	}
+	stored, err := model.GetFiles(ctx, []string{"u1"})
+	if err != nil || len(stored) != 1 || stored[0].Path != "src/main.c" {
+		t.Errorf("GetFiles() = %+v, %v", stored, err)
+	}
+	found, err := model.GetIssues(ctx, []string{"f1", "f2"})
+	if err != nil || len(found) != 1 || len(found["f1"]) != 1 || found["f1"][0] != issues[0] {
+		t.Errorf("GetIssues() = %+v, %v", found, err)
+	}
+	scans, err := model.ComponentScans(ctx, []string{"u1", "u2"})
+	if want := (ComponentScan{URLHash: "u1", ScanDate: "2025-10-01T12:00:00Z", EngineVersion: "1.85.0"}); err != nil || len(scans) != 1 || scans["u1"] != want {
+		t.Errorf("ComponentScans() = %+v, %v", scans, err)
+	}
+	if urls, err = model.GetURLsByPurlNames(ctx, nil); err != nil || len(urls) != 0 {
+		t.Errorf("GetURLsByPurlNames(nil) = %+v, %v", urls, err)
+	}
+	// A later version sharing f1 must not touch the issues imported for u1, even once it's re-imported without them
+	url2 := LocalURL{URLHash: "u2", PurlName: "acme/tool", Version: "1.1.0", ImportedAt: now.Add(time.Hour)}
+	files2 := []LocalFile{{URLHash: "u2", FileMD5: "f1", Path: "src/main.c"}}
+	if err = model.Import(ctx, url2, files2, issues[1:]); err != nil {
+		t.Fatalf("Import() u2 error = %v", err)
+	}
+	if found, err = model.GetIssues(ctx, []string{"f1"}); err != nil || len(found["f1"]) != 1 || found["f1"][0] != issues[1] {
+		t.Errorf("GetIssues() latest import = %+v, %v", found, err)
+	}
+	if err = model.Import(ctx, url2, files2, nil); err != nil {
+		t.Fatalf("Import() u2 again error = %v", err)
+	}
+	if found, err = model.GetIssues(ctx, []string{"f1"}); err != nil || len(found["f1"]) != 1 || found["f1"][0] != issues[0] {
+		t.Errorf("GetIssues() after re-import = %+v, %v", found, err)
+	}
+}
+
+func TestLocalStoreModelChunks(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer CloseDB(db)
+	model := NewLocalStoreModel(db)
+	if err = model.CreateTables(ctx); err != nil {
+		t.Fatalf("CreateTables() error = %v", err)
+	}
+	var files []LocalFile
+	var issues []SemgrepItem
+	var md5s []string
+	for i := 0; i < 2*maxInListSize+1; i++ {
+		md5 := fmt.Sprintf("f%04d", i)
+		md5s = append(md5s, md5)
+		files = append(files, LocalFile{URLHash: "u1", FileMD5: md5, Path: "src/" + md5 + ".c"})
+		issues = append(issues, SemgrepItem{MD5: md5, RuleID: "c.rule"})
+	}
+	if err = model.Import(ctx, LocalURL{URLHash: "u1", PurlName: "acme/tool", Version: "1.0.0", ImportedAt: time.Now()}, files, issues); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if found, err := model.GetFilesByMD5(ctx, md5s); err != nil || len(found) != len(md5s) || found[len(md5s)-1].FileMD5 != md5s[len(md5s)-1] {
+		t.Errorf("GetFilesByMD5() = %v files, %v", len(found), err)
+	}
+	if found, err := model.GetIssues(ctx, md5s); err != nil || len(found) != len(md5s) {
+		t.Errorf("GetIssues() = %v files, %v", len(found), err)
+	}
+}
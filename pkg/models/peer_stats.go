@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle all interaction with the semgrep_peer_stats table
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+// peerStatsSchema creates the peer statistics table (if it doesn't exist).
+const peerStatsSchema = `CREATE TABLE IF NOT EXISTS semgrep_peer_stats
+(
+    purl_type  text             NOT NULL,
+    percentile integer          NOT NULL,
+    density    double precision NOT NULL,
+    components integer          NOT NULL,
+    updated_at timestamp        NOT NULL,
+    PRIMARY KEY (purl_type, percentile)
+);`
+
+const peerStatsColumns = "purl_type, percentile, density, components, updated_at"
+
+// PeerStatsModel handles all interaction with the semgrep_peer_stats table.
+type PeerStatsModel struct {
+	db *sqlx.DB
+}
+
+// PeerStat is a single row of the semgrep_peer_stats table: the finding density at the given percentile
+// of all the analysed components of a purl type.
+type PeerStat struct {
+	PurlType   string    `db:"purl_type"`
+	Percentile int       `db:"percentile"`
+	Density    float64   `db:"density"`
+	Components int       `db:"components"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// NewPeerStatsModel creates a new instance of the Peer Stats Model.
+func NewPeerStatsModel(db *sqlx.DB) *PeerStatsModel {
+	return &PeerStatsModel{db: db}
+}
+
+// CreateTable creates the peer statistics table if it is missing.
+func (m *PeerStatsModel) CreateTable(ctx context.Context) error {
+	return execSchema(ctx, m.db, peerStatsSchema, "semgrep_peer_stats table")
+}
+
+// Replace swaps the whole content of the table for the supplied statistics (in a single transaction).
+func (m *PeerStatsModel) Replace(ctx context.Context, stats []PeerStat) error {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		zlog.S.Errorf("Failed to start peer stats transaction: %v", err)
+		return fmt.Errorf("failed to update the semgrep_peer_stats table: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err = tx.ExecContext(ctx, "DELETE FROM semgrep_peer_stats"); err != nil {
+		zlog.S.Errorf("Failed to clear peer stats: %v", err)
+		return fmt.Errorf("failed to clear the semgrep_peer_stats table: %v", err)
+	}
+	for _, stat := range stats {
+		_, err = tx.NamedExecContext(ctx, "INSERT INTO semgrep_peer_stats ("+peerStatsColumns+") VALUES"+
+			" (:purl_type, :percentile, :density, :components, :updated_at)", stat)
+		if err != nil {
+			zlog.S.Errorf("Failed to insert peer stat %v/%v: %v", stat.PurlType, stat.Percentile, err)
+			return fmt.Errorf("failed to insert into the semgrep_peer_stats table: %v", err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		zlog.S.Errorf("Failed to commit peer stats: %v", err)
+		return fmt.Errorf("failed to update the semgrep_peer_stats table: %v", err)
+	}
+	return nil
+}
+
+// GetByTypes retrieves the statistics of the given purl types, ordered by percentile.
+func (m *PeerStatsModel) GetByTypes(ctx context.Context, purlTypes []string) (map[string][]PeerStat, error) {
+	result := make(map[string][]PeerStat)
+	if len(purlTypes) == 0 {
+		return result, nil
+	}
+	query, args, err := sqlx.In("SELECT "+peerStatsColumns+" FROM semgrep_peer_stats WHERE purl_type IN (?)"+
+		" ORDER BY purl_type, percentile", purlTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build peer stats query: %v", err)
+	}
+	var stats []PeerStat
+	if err = m.db.SelectContext(ctx, &stats, m.db.Rebind(query), args...); err != nil {
+		zlog.S.Errorf("Failed to query peer stats for %v: %v", strings.Join(purlTypes, ","), err)
+		return nil, fmt.Errorf("failed to query the semgrep_peer_stats table: %v", err)
+	}
+	for _, stat := range stats {
+		result[stat.PurlType] = append(result[stat.PurlType], stat)
+	}
+	return result, nil
+}
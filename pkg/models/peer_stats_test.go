@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestPeerStatsModel(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer CloseDB(db)
+	model := NewPeerStatsModel(db)
+	if err = model.CreateTable(ctx); err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	if err = model.Replace(ctx, []PeerStat{
+		{PurlType: "npm", Percentile: 50, Density: 0.5, Components: 10, UpdatedAt: now},
+		{PurlType: "pypi", Percentile: 50, Density: 0.25, Components: 4, UpdatedAt: now},
+	}); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	if err = model.Replace(ctx, []PeerStat{
+		{PurlType: "npm", Percentile: 100, Density: 2, Components: 12, UpdatedAt: now},
+		{PurlType: "npm", Percentile: 50, Density: 0.75, Components: 12, UpdatedAt: now},
+	}); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	stats, err := model.GetByTypes(ctx, []string{"npm", "pypi"})
+	if err != nil {
+		t.Fatalf("GetByTypes() error = %v", err)
+	}
+	if len(stats) != 1 || len(stats["npm"]) != 2 || stats["npm"][0].Percentile != 50 || stats["npm"][0].Density != 0.75 {
+		t.Errorf("GetByTypes() = %+v", stats)
+	}
+	if stats, err = model.GetByTypes(ctx, nil); err != nil || len(stats) != 0 {
+		t.Errorf("GetByTypes(nil) = %+v, %v", stats, err)
+	}
+}
+
+func TestListMinedURLs(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer CloseDB(db)
+	if err = loadSQLData(db, ctx, "./tests/mines.sql"); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	db.MustExec("CREATE TABLE all_urls (package_hash TEXT, purl_name TEXT, mine_id INTEGER, is_mined BOOLEAN);" +
+		"INSERT INTO all_urls VALUES ('cc', 'lodash', 2, true), ('aa', 'requests', 3, true), ('bb', 'left-pad', 2, false), ('dd', 'express', 2, true);")
+	m := NewAllURLModel(db, NewProjectModel(db))
+	page, err := m.ListMinedURLs(ctx, "", 2)
+	if err != nil || len(page) != 2 || page[0].URLHash != "aa" || page[0].PurlType != "pypi" || page[1].URLHash != "cc" {
+		t.Fatalf("ListMinedURLs() first page = %+v, %v", page, err)
+	}
+	if page, err = m.ListMinedURLs(ctx, page[1].URLHash, 2); err != nil || len(page) != 1 || page[0].PurlName != "express" {
+		t.Errorf("ListMinedURLs() second page = %+v, %v", page, err)
+	}
+}
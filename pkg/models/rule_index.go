@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle all interaction with the semgrep_rule_index table
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+// ruleIndexSchema creates the rule index table (if it doesn't exist).
+// It's the reverse of the KB lookups: rule ID to file MD5s (semgrep LDB table) to URL hashes (pivot LDB table) to purl/version (all_urls).
+const ruleIndexSchema = `CREATE TABLE IF NOT EXISTS semgrep_rule_index
+(
+    rule_id   text    NOT NULL,
+    url_hash  text    NOT NULL,
+    file_md5  text    NOT NULL,
+    purl_type text    NOT NULL,
+    purl_name text    NOT NULL,
+    version   text    NOT NULL,
+    severity  text    NOT NULL,
+    findings  integer NOT NULL,
+    PRIMARY KEY (rule_id, url_hash, file_md5)
+);
+CREATE INDEX IF NOT EXISTS semgrep_rule_index_purl ON semgrep_rule_index (rule_id, purl_type, purl_name);`
+
+const ruleIndexColumns = "rule_id, url_hash, file_md5, purl_type, purl_name, version, severity, findings"
+
+// RuleIndexModel handles all interaction with the semgrep_rule_index table.
+type RuleIndexModel struct {
+	db *sqlx.DB
+}
+
+// RuleHit is a single row of the semgrep_rule_index table: a component file (of a URL) with findings of a rule.
+type RuleHit struct {
+	RuleID   string `db:"rule_id"`
+	URLHash  string `db:"url_hash"`
+	FileMD5  string `db:"file_md5"`
+	PurlType string `db:"purl_type"`
+	PurlName string `db:"purl_name"`
+	Version  string `db:"version"`
+	Severity string `db:"severity"`
+	Findings int    `db:"findings"` // Number of findings of the rule in the file
+}
+
+// RuleIndexScope restricts a rule index search to a component (and optionally, a single version of it).
+type RuleIndexScope struct {
+	PurlType string
+	PurlName string
+	Version  string
+}
+
+// RuleIndexWriter replaces the content of the rule index within a single transaction.
+// The previous index is served until Commit is called.
+type RuleIndexWriter struct {
+	tx *sqlx.Tx
+}
+
+// NewRuleIndexModel creates a new instance of the Rule Index Model.
+func NewRuleIndexModel(db *sqlx.DB) *RuleIndexModel {
+	return &RuleIndexModel{db: db}
+}
+
+// CreateTable creates the rule index table (and its indexes) if missing.
+func (m *RuleIndexModel) CreateTable(ctx context.Context) error {
+	return execSchema(ctx, m.db, ruleIndexSchema, "semgrep_rule_index table")
+}
+
+// Rebuild starts replacing the whole index. The returned writer must be committed or rolled back.
+func (m *RuleIndexModel) Rebuild(ctx context.Context) (*RuleIndexWriter, error) {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		zlog.S.Errorf("Failed to start rule index transaction: %v", err)
+		return nil, fmt.Errorf("failed to update the semgrep_rule_index table: %v", err)
+	}
+	if _, err = tx.ExecContext(ctx, "DELETE FROM semgrep_rule_index"); err != nil {
+		_ = tx.Rollback()
+		zlog.S.Errorf("Failed to clear rule index: %v", err)
+		return nil, fmt.Errorf("failed to clear the semgrep_rule_index table: %v", err)
+	}
+	return &RuleIndexWriter{tx: tx}, nil
+}
+
+// Add inserts the given hits into the index being rebuilt.
+func (w *RuleIndexWriter) Add(ctx context.Context, hits []RuleHit) error {
+	for _, hit := range hits {
+		_, err := w.tx.NamedExecContext(ctx, "INSERT INTO semgrep_rule_index ("+ruleIndexColumns+") VALUES"+
+			" (:rule_id, :url_hash, :file_md5, :purl_type, :purl_name, :version, :severity, :findings)", hit)
+		if err != nil {
+			zlog.S.Errorf("Failed to insert rule hit %v/%v/%v: %v", hit.RuleID, hit.URLHash, hit.FileMD5, err)
+			return fmt.Errorf("failed to insert into the semgrep_rule_index table: %v", err)
+		}
+	}
+	return nil
+}
+
+// Commit makes the rebuilt index visible.
+func (w *RuleIndexWriter) Commit() error {
+	if err := w.tx.Commit(); err != nil {
+		zlog.S.Errorf("Failed to commit rule index: %v", err)
+		return fmt.Errorf("failed to update the semgrep_rule_index table: %v", err)
+	}
+	return nil
+}
+
+// Rollback abandons the rebuild, keeping the previous index. It's a no-op after Commit.
+func (w *RuleIndexWriter) Rollback() {
+	_ = w.tx.Rollback()
+}
+
+// Search retrieves a page of the URLs (component versions) with findings of the given rule, ordered by URL hash,
+// along with all their files with findings. An empty scope searches the whole index.
+// Pass the last URL hash of the previous page to get the next one (empty for the first page).
+func (m *RuleIndexModel) Search(ctx context.Context, ruleID string, scope []RuleIndexScope, afterHash string, limit int) ([]RuleHit, error) {
+	where := "rule_id = ? AND url_hash > ?"
+	args := []any{ruleID, afterHash}
+	if len(scope) > 0 {
+		clauses := make([]string, 0, len(scope))
+		for _, c := range scope {
+			clause := "(purl_type = ? AND purl_name = ?"
+			args = append(args, c.PurlType, c.PurlName)
+			if len(c.Version) > 0 {
+				clause += " AND version = ?"
+				args = append(args, c.Version)
+			}
+			clauses = append(clauses, clause+")")
+		}
+		where += " AND (" + strings.Join(clauses, " OR ") + ")"
+	}
+	var urlHashes []string
+	err := m.db.SelectContext(ctx, &urlHashes, m.db.Rebind("SELECT DISTINCT url_hash FROM semgrep_rule_index WHERE "+where+
+		" ORDER BY url_hash LIMIT ?"), append(args, limit)...)
+	if err != nil {
+		zlog.S.Errorf("Failed to search rule index for %v: %v", ruleID, err)
+		return nil, fmt.Errorf("failed to query the semgrep_rule_index table: %v", err)
+	}
+	if len(urlHashes) == 0 {
+		return nil, nil
+	}
+	query, inArgs, err := sqlx.In("SELECT "+ruleIndexColumns+" FROM semgrep_rule_index WHERE rule_id = ? AND url_hash IN (?)"+
+		" ORDER BY url_hash, file_md5", ruleID, urlHashes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build rule index query: %v", err)
+	}
+	var hits []RuleHit
+	if err = m.db.SelectContext(ctx, &hits, m.db.Rebind(query), inArgs...); err != nil {
+		zlog.S.Errorf("Failed to get rule index hits for %v: %v", ruleID, err)
+		return nil, fmt.Errorf("failed to query the semgrep_rule_index table: %v", err)
+	}
+	return hits, nil
+}
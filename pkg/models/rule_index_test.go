@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestRuleIndex(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer CloseDB(db)
+	model := NewRuleIndexModel(db)
+	if err = model.CreateTable(ctx); err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+	writer, err := model.Rebuild(ctx)
+	if err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+	err = writer.Add(ctx, []RuleHit{
+		{RuleID: "js.eval", URLHash: "u1", FileMD5: "f1", PurlType: "npm", PurlName: "lodash", Version: "4.17.20", Severity: "ERROR", Findings: 2},
+		{RuleID: "js.eval", URLHash: "u1", FileMD5: "f2", PurlType: "npm", PurlName: "lodash", Version: "4.17.20", Severity: "ERROR", Findings: 1},
+		{RuleID: "js.eval", URLHash: "u2", FileMD5: "f3", PurlType: "npm", PurlName: "lodash", Version: "4.17.21", Severity: "ERROR", Findings: 1},
+		{RuleID: "js.eval", URLHash: "u3", FileMD5: "f4", PurlType: "npm", PurlName: "express", Version: "4.0.0", Severity: "ERROR", Findings: 1},
+		{RuleID: "js.other", URLHash: "u1", FileMD5: "f1", PurlType: "npm", PurlName: "lodash", Version: "4.17.20", Severity: "INFO", Findings: 1},
+	})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err = writer.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	tests := []struct {
+		name   string
+		scope  []RuleIndexScope
+		after  string
+		limit  int
+		hashes []string
+	}{
+		{name: "first page", limit: 2, hashes: []string{"u1", "u1", "u2"}},
+		{name: "next page", after: "u2", limit: 2, hashes: []string{"u3"}},
+		{name: "component", scope: []RuleIndexScope{{PurlType: "npm", PurlName: "lodash"}}, limit: 10, hashes: []string{"u1", "u1", "u2"}},
+		{name: "version", scope: []RuleIndexScope{{PurlType: "npm", PurlName: "lodash", Version: "4.17.21"}, {PurlType: "npm", PurlName: "express"}},
+			limit: 10, hashes: []string{"u2", "u3"}},
+		{name: "other type", scope: []RuleIndexScope{{PurlType: "pypi", PurlName: "lodash"}}, limit: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := model.Search(ctx, "js.eval", tt.scope, tt.after, tt.limit)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			var hashes []string
+			for _, hit := range hits {
+				hashes = append(hashes, hit.URLHash)
+			}
+			if strings.Join(hashes, ",") != strings.Join(tt.hashes, ",") {
+				t.Errorf("Search() = %v, want %v", hashes, tt.hashes)
+			}
+		})
+	}
+	// A rolled back rebuild keeps the previous index
+	if writer, err = model.Rebuild(ctx); err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+	writer.Rollback()
+	if hits, err := model.Search(ctx, "js.other", nil, "", 10); err != nil || len(hits) != 1 || hits[0].Findings != 1 {
+		t.Errorf("Search() after rollback = %+v, %v", hits, err)
+	}
+}
+
+func TestGetVersionsByHashes(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer CloseDB(db)
+	db.MustExec("CREATE TABLE versions (id INTEGER, version_name TEXT);" +
+		"INSERT INTO versions VALUES (1, '4.17.20'), (2, '4.17.21');" +
+		"CREATE TABLE all_urls (package_hash TEXT, version_id INTEGER);" +
+		"INSERT INTO all_urls VALUES ('u1', 1), ('u2', 2), ('u3', 9);")
+	m := NewAllURLModel(db, NewProjectModel(db))
+	versions, err := m.GetVersionsByHashes(ctx, []string{"u1", "u3", "missing"})
+	if err != nil || len(versions) != 2 || versions["u1"] != "4.17.20" || versions["u3"] != "" {
+		t.Errorf("GetVersionsByHashes() = %v, %v", versions, err)
+	}
+}
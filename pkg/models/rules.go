@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle all interaction with the semgrep_rules table
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+// rulesSchema creates the rule catalog table (if it doesn't exist).
+// The list columns (cwe, owasp & refs) hold JSON arrays of strings.
+const rulesSchema = `CREATE TABLE IF NOT EXISTS semgrep_rules
+(
+    rule_id    text      NOT NULL PRIMARY KEY,
+    title      text      NOT NULL DEFAULT '',
+    message    text      NOT NULL DEFAULT '',
+    cwe        text      NOT NULL DEFAULT '[]',
+    owasp      text      NOT NULL DEFAULT '[]',
+    confidence text      NOT NULL DEFAULT '',
+    likelihood text      NOT NULL DEFAULT '',
+    impact     text      NOT NULL DEFAULT '',
+    refs       text      NOT NULL DEFAULT '[]',
+    updated_at timestamp NOT NULL
+);`
+
+const rulesColumns = "rule_id, title, message, cwe, owasp, confidence, likelihood, impact, refs, updated_at"
+
+// RuleModel handles all interaction with the semgrep_rules table.
+type RuleModel struct {
+	db *sqlx.DB
+}
+
+// Rule is a single row of the semgrep_rules table.
+type Rule struct {
+	RuleID     string    `db:"rule_id"`
+	Title      string    `db:"title"`
+	Message    string    `db:"message"`
+	CWE        string    `db:"cwe"`   // JSON array
+	OWASP      string    `db:"owasp"` // JSON array
+	Confidence string    `db:"confidence"`
+	Likelihood string    `db:"likelihood"`
+	Impact     string    `db:"impact"`
+	References string    `db:"refs"` // JSON array
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// NewRuleModel creates a new instance of the Rule Model.
+func NewRuleModel(db *sqlx.DB) *RuleModel {
+	return &RuleModel{db: db}
+}
+
+// CreateTable creates the rule catalog table if it is missing.
+func (m *RuleModel) CreateTable(ctx context.Context) error {
+	return execSchema(ctx, m.db, rulesSchema, "semgrep_rules table")
+}
+
+// Upsert inserts the supplied rules, replacing any existing rule with the same ID (in a single transaction).
+func (m *RuleModel) Upsert(ctx context.Context, rules []Rule) error {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		zlog.S.Errorf("Failed to start rules transaction: %v", err)
+		return fmt.Errorf("failed to update the semgrep_rules table: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	for _, rule := range rules {
+		_, err = tx.NamedExecContext(ctx, "INSERT INTO semgrep_rules ("+rulesColumns+") VALUES"+
+			" (:rule_id, :title, :message, :cwe, :owasp, :confidence, :likelihood, :impact, :refs, :updated_at)"+
+			" ON CONFLICT (rule_id) DO UPDATE SET title = excluded.title, message = excluded.message, cwe = excluded.cwe,"+
+			" owasp = excluded.owasp, confidence = excluded.confidence, likelihood = excluded.likelihood,"+
+			" impact = excluded.impact, refs = excluded.refs, updated_at = excluded.updated_at", rule)
+		if err != nil {
+			zlog.S.Errorf("Failed to upsert rule %v: %v", rule.RuleID, err)
+			return fmt.Errorf("failed to insert into the semgrep_rules table: %v", err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		zlog.S.Errorf("Failed to commit rules: %v", err)
+		return fmt.Errorf("failed to update the semgrep_rules table: %v", err)
+	}
+	return nil
+}
+
+// GetByIDs retrieves the catalog entries of the given rule IDs (keyed by rule ID). Unknown rules are not returned.
+func (m *RuleModel) GetByIDs(ctx context.Context, ruleIDs []string) (map[string]Rule, error) {
+	result := make(map[string]Rule)
+	if len(ruleIDs) == 0 {
+		return result, nil
+	}
+	query, args, err := sqlx.In("SELECT "+rulesColumns+" FROM semgrep_rules WHERE rule_id IN (?)", ruleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build rules query: %v", err)
+	}
+	var rules []Rule
+	if err = m.db.SelectContext(ctx, &rules, m.db.Rebind(query), args...); err != nil {
+		zlog.S.Errorf("Failed to query %v rules: %v", len(ruleIDs), err)
+		return nil, fmt.Errorf("failed to query the semgrep_rules table: %v", err)
+	}
+	for _, rule := range rules {
+		result[rule.RuleID] = rule
+	}
+	return result, nil
+}
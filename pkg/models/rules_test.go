@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestRuleModel(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer CloseDB(db)
+	model := NewRuleModel(db)
+	if err = model.CreateTable(ctx); err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	if err = model.Upsert(ctx, []Rule{
+		{RuleID: "rule.a", Title: "Rule A", CWE: `["CWE-78"]`, OWASP: "[]", References: "[]", UpdatedAt: now},
+		{RuleID: "rule.b", Title: "Rule B", CWE: "[]", OWASP: `["A03:2021 - Injection"]`, References: "[]", UpdatedAt: now},
+	}); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	if err = model.Upsert(ctx, []Rule{{RuleID: "rule.a", Title: "Rule A v2", CWE: `["CWE-89"]`, OWASP: "[]", References: "[]", UpdatedAt: now}}); err != nil {
+		t.Fatalf("Upsert() update error = %v", err)
+	}
+	rules, err := model.GetByIDs(ctx, []string{"rule.a", "rule.b", "rule.c"})
+	if err != nil {
+		t.Fatalf("GetByIDs() error = %v", err)
+	}
+	if len(rules) != 2 || rules["rule.a"].Title != "Rule A v2" || rules["rule.a"].CWE != `["CWE-89"]` || rules["rule.b"].Title != "Rule B" {
+		t.Errorf("GetByIDs() = %+v", rules)
+	}
+	if rules, err = model.GetByIDs(ctx, nil); err != nil || len(rules) != 0 {
+		t.Errorf("GetByIDs(nil) = %+v, %v", rules, err)
+	}
+}
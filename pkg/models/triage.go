@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle all interaction with the semgrep_triage table
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+// triageSchema creates the triage table (if it doesn't exist).
+const triageSchema = `CREATE TABLE IF NOT EXISTS semgrep_triage
+(
+    id         text      NOT NULL PRIMARY KEY,
+    project    text      NOT NULL DEFAULT '',
+    file_md5   text      NOT NULL,
+    rule_id    text      NOT NULL,
+    line_from  text      NOT NULL DEFAULT '',
+    line_to    text      NOT NULL DEFAULT '',
+    state      text      NOT NULL,
+    author     text      NOT NULL,
+    comment    text      NOT NULL DEFAULT '',
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    UNIQUE (project, file_md5, rule_id, line_from, line_to)
+);
+CREATE INDEX IF NOT EXISTS semgrep_triage_file_md5 ON semgrep_triage (file_md5);`
+
+const triageColumns = "id, project, file_md5, rule_id, line_from, line_to, state, author, comment, created_at, updated_at"
+
+// ErrTriageNotFound is returned when a triage decision does not exist.
+var ErrTriageNotFound = errors.New("triage decision not found")
+
+// ErrTriageExists is returned when a triage decision already exists for the same finding and project.
+var ErrTriageExists = errors.New("triage decision already exists")
+
+// TriageModel handles all interaction with the semgrep_triage table.
+type TriageModel struct {
+	db *sqlx.DB
+}
+
+// Triage is a single row of the semgrep_triage table.
+type Triage struct {
+	ID        string    `db:"id"`
+	Project   string    `db:"project"`
+	FileMD5   string    `db:"file_md5"`
+	RuleID    string    `db:"rule_id"`
+	From      string    `db:"line_from"`
+	To        string    `db:"line_to"`
+	State     string    `db:"state"`
+	Author    string    `db:"author"`
+	Comment   string    `db:"comment"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// TriageQuery holds the (optional) filters used to list triage decisions.
+type TriageQuery struct {
+	Project    string
+	GlobalOnly bool
+	FileMD5    string
+	RuleID     string
+	State      string
+}
+
+// NewTriageModel creates a new instance of the Triage Model.
+func NewTriageModel(db *sqlx.DB) *TriageModel {
+	return &TriageModel{db: db}
+}
+
+// CreateTable creates the triage table and indexes if they are missing.
+func (m *TriageModel) CreateTable(ctx context.Context) error {
+	return execSchema(ctx, m.db, triageSchema, "semgrep_triage table")
+}
+
+// Create inserts a new triage decision.
+func (m *TriageModel) Create(ctx context.Context, t Triage) error {
+	_, err := m.db.NamedExecContext(ctx, "INSERT INTO semgrep_triage ("+triageColumns+") VALUES"+
+		" (:id, :project, :file_md5, :rule_id, :line_from, :line_to, :state, :author, :comment, :created_at, :updated_at)", t)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrTriageExists
+		}
+		zlog.S.Errorf("Failed to insert triage decision %v: %v", t.ID, err)
+		return fmt.Errorf("failed to insert into the semgrep_triage table: %v", err)
+	}
+	return nil
+}
+
+// Get retrieves a triage decision by ID.
+func (m *TriageModel) Get(ctx context.Context, id string) (Triage, error) {
+	var t Triage
+	err := m.db.GetContext(ctx, &t, "SELECT "+triageColumns+" FROM semgrep_triage WHERE id = $1", id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Triage{}, ErrTriageNotFound
+		}
+		zlog.S.Errorf("Failed to query triage decision %v: %v", id, err)
+		return Triage{}, fmt.Errorf("failed to query the semgrep_triage table: %v", err)
+	}
+	return t, nil
+}
+
+// List retrieves all the triage decisions matching the query.
+func (m *TriageModel) List(ctx context.Context, q TriageQuery) ([]Triage, error) {
+	var where []string
+	var args []any
+	addFilter := func(column, value string) {
+		args = append(args, value)
+		where = append(where, fmt.Sprintf("%v = $%d", column, len(args)))
+	}
+	switch {
+	case q.GlobalOnly:
+		addFilter("project", "")
+	case len(q.Project) > 0:
+		addFilter("project", q.Project)
+	}
+	if len(q.FileMD5) > 0 {
+		addFilter("file_md5", q.FileMD5)
+	}
+	if len(q.RuleID) > 0 {
+		addFilter("rule_id", q.RuleID)
+	}
+	if len(q.State) > 0 {
+		addFilter("state", q.State)
+	}
+	query := "SELECT " + triageColumns + " FROM semgrep_triage"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	var decisions []Triage
+	if err := m.db.SelectContext(ctx, &decisions, query+" ORDER BY updated_at DESC, id", args...); err != nil {
+		zlog.S.Errorf("Failed to list triage decisions: %v", err)
+		return nil, fmt.Errorf("failed to query the semgrep_triage table: %v", err)
+	}
+	return decisions, nil
+}
+
+// GetByFiles retrieves the triage decisions for the given files that apply to the project (global or project specific).
+// The files are looked up in chunks of maxInListSize, so any number of files can be supplied.
+func (m *TriageModel) GetByFiles(ctx context.Context, project string, fileMD5s []string) ([]Triage, error) {
+	var decisions []Triage
+	for _, chunk := range inListChunks(fileMD5s) {
+		query, args, err := sqlx.In("SELECT "+triageColumns+" FROM semgrep_triage WHERE file_md5 IN (?) AND project IN (?)",
+			chunk, []string{"", project})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build semgrep_triage query: %v", err)
+		}
+		var found []Triage
+		if err = m.db.SelectContext(ctx, &found, m.db.Rebind(query), args...); err != nil {
+			zlog.S.Errorf("Failed to query triage decisions for %v files: %v", len(chunk), err)
+			return nil, fmt.Errorf("failed to query the semgrep_triage table: %v", err)
+		}
+		decisions = append(decisions, found...)
+	}
+	return decisions, nil
+}
+
+// Update changes the state, author and comment of an existing triage decision.
+func (m *TriageModel) Update(ctx context.Context, t Triage) error {
+	res, err := m.db.ExecContext(ctx, "UPDATE semgrep_triage SET state = $1, author = $2, comment = $3, updated_at = $4 WHERE id = $5",
+		t.State, t.Author, t.Comment, t.UpdatedAt, t.ID)
+	if err != nil {
+		zlog.S.Errorf("Failed to update triage decision %v: %v", t.ID, err)
+		return fmt.Errorf("failed to update the semgrep_triage table: %v", err)
+	}
+	return checkRowsAffected(res)
+}
+
+// Delete removes a triage decision.
+func (m *TriageModel) Delete(ctx context.Context, id string) error {
+	res, err := m.db.ExecContext(ctx, "DELETE FROM semgrep_triage WHERE id = $1", id)
+	if err != nil {
+		zlog.S.Errorf("Failed to delete triage decision %v: %v", id, err)
+		return fmt.Errorf("failed to delete from the semgrep_triage table: %v", err)
+	}
+	return checkRowsAffected(res)
+}
+
+// checkRowsAffected returns ErrTriageNotFound if the statement did not change any rows.
+func checkRowsAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check the semgrep_triage table update: %v", err)
+	}
+	if n == 0 {
+		return ErrTriageNotFound
+	}
+	return nil
+}
+
+// isUniqueViolation checks if the error was caused by a unique constraint (PostgreSQL or SQLite).
+func isUniqueViolation(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "unique constraint") || strings.Contains(msg, "duplicate key")
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestTriageModel(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer CloseDB(db)
+	model := NewTriageModel(db)
+	if err = model.CreateTable(ctx); err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+	if err = model.CreateTable(ctx); err != nil {
+		t.Fatalf("CreateTable() should be idempotent: %v", err)
+	}
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	decisions := []Triage{
+		{ID: "1", FileMD5: "abc123", RuleID: "rule.a", State: "false_positive", Author: "alice", CreatedAt: now, UpdatedAt: now},
+		{ID: "2", Project: "web", FileMD5: "abc123", RuleID: "rule.a", State: "confirmed", Author: "bob", CreatedAt: now, UpdatedAt: now},
+		{ID: "3", Project: "api", FileMD5: "def456", RuleID: "rule.b", From: "10", State: "accepted_risk", Author: "carol", CreatedAt: now, UpdatedAt: now},
+	}
+	for _, d := range decisions {
+		if err = model.Create(ctx, d); err != nil {
+			t.Fatalf("Create(%v) error = %v", d.ID, err)
+		}
+	}
+	duplicate := decisions[0]
+	duplicate.ID = "4"
+	if err = model.Create(ctx, duplicate); !errors.Is(err, ErrTriageExists) {
+		t.Errorf("Create() duplicate error = %v, want ErrTriageExists", err)
+	}
+	got, err := model.Get(ctx, "2")
+	if err != nil || got.Project != "web" || got.State != "confirmed" || !got.CreatedAt.Equal(now) {
+		t.Errorf("Get() = %+v, %v", got, err)
+	}
+	if _, err = model.Get(ctx, "missing"); !errors.Is(err, ErrTriageNotFound) {
+		t.Errorf("Get() missing error = %v", err)
+	}
+	tests := []struct {
+		name  string
+		query TriageQuery
+		want  int
+	}{
+		{name: "all", query: TriageQuery{}, want: 3},
+		{name: "global", query: TriageQuery{GlobalOnly: true}, want: 1},
+		{name: "project", query: TriageQuery{Project: "api"}, want: 1},
+		{name: "file and rule", query: TriageQuery{FileMD5: "abc123", RuleID: "rule.a"}, want: 2},
+		{name: "state", query: TriageQuery{State: "confirmed"}, want: 1},
+	}
+	for _, tt := range tests {
+		list, err := model.List(ctx, tt.query)
+		if err != nil || len(list) != tt.want {
+			t.Errorf("List(%v) = %v, %v, want %v", tt.name, len(list), err, tt.want)
+		}
+	}
+	byFiles, err := model.GetByFiles(ctx, "web", []string{"abc123", "def456"})
+	if err != nil || len(byFiles) != 2 {
+		t.Errorf("GetByFiles() = %+v, %v, want global and web decisions", byFiles, err)
+	}
+	// Longer file lists are looked up in several chunks
+	files := make([]string, 0, 2*maxInListSize+1)
+	for i := 0; i < 2*maxInListSize; i++ {
+		files = append(files, fmt.Sprintf("file-%d", i))
+	}
+	files = append(files, "abc123")
+	if byFiles, err = model.GetByFiles(ctx, "web", files); err != nil || len(byFiles) != 2 {
+		t.Errorf("GetByFiles() chunked = %+v, %v, want global and web decisions", byFiles, err)
+	}
+	later := now.Add(time.Hour)
+	if err = model.Update(ctx, Triage{ID: "1", State: "accepted_risk", Author: "dave", Comment: "reviewed", UpdatedAt: later}); err != nil {
+		t.Errorf("Update() error = %v", err)
+	}
+	if got, _ = model.Get(ctx, "1"); got.State != "accepted_risk" || got.Comment != "reviewed" || !got.UpdatedAt.Equal(later) {
+		t.Errorf("Update() result = %+v", got)
+	}
+	if err = model.Update(ctx, Triage{ID: "missing", State: "confirmed", UpdatedAt: later}); !errors.Is(err, ErrTriageNotFound) {
+		t.Errorf("Update() missing error = %v", err)
+	}
+	if err = model.Delete(ctx, "3"); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if err = model.Delete(ctx, "3"); !errors.Is(err, ErrTriageNotFound) {
+		t.Errorf("Delete() missing error = %v", err)
+	}
+}
@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package outputs contains all the logic required to export Semgrep lookup results into other report formats.
+// Current formats supported are:
+// - JSON (native SemgrepOutput)
+// - SARIF 2.1.0
+// - JUnit XML
+// - GitLab SAST report (gl-sast-report.json)
+// - Plain text table
+// - HTML report (self-contained) and Markdown summary, rendered from the embedded templates
+package outputs
@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file handles exporting Semgrep output to a GitLab SAST report (gl-sast-report.json)
+
+package outputs
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+const (
+	gitLabSchemaVersion = "15.2.1" // GitLab security report schema version produced
+	gitLabSchema        = "https://gitlab.com/gitlab-org/security-products/security-report-schemas/-/raw/v15.2.1/dist/sast-report-format.json"
+	gitLabTimeFormat    = "2006-01-02T15:04:05"
+	gitLabAnalyzerID    = "scanoss-semgrep"
+)
+
+// GitLabReport is the top level GitLab SAST report.
+type GitLabReport struct {
+	Schema          string                `json:"schema"`
+	Version         string                `json:"version"`
+	Scan            GitLabScan            `json:"scan"`
+	Vulnerabilities []GitLabVulnerability `json:"vulnerabilities"`
+}
+
+// GitLabScan describes the scan that produced the report.
+type GitLabScan struct {
+	Analyzer  GitLabScanner `json:"analyzer"`
+	Scanner   GitLabScanner `json:"scanner"`
+	Type      string        `json:"type"`
+	StartTime string        `json:"start_time"`
+	EndTime   string        `json:"end_time"`
+	Status    string        `json:"status"`
+}
+
+// GitLabScanner describes the analyzer/scanner that produced the report.
+type GitLabScanner struct {
+	ID      string       `json:"id"`
+	Name    string       `json:"name"`
+	Version string       `json:"version"`
+	URL     string       `json:"url,omitempty"`
+	Vendor  GitLabVendor `json:"vendor"`
+}
+
+// GitLabVendor is the vendor of the analyzer/scanner.
+type GitLabVendor struct {
+	Name string `json:"name"`
+}
+
+// GitLabVulnerability is a single finding.
+type GitLabVulnerability struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Severity    string             `json:"severity"`
+	Identifiers []GitLabIdentifier `json:"identifiers"`
+	Location    GitLabLocation     `json:"location"`
+	Flags       []GitLabFlag       `json:"flags,omitempty"`
+}
+
+// GitLabFlag marks a finding for the reviewer (i.e. accepted via an ignore file).
+type GitLabFlag struct {
+	Type        string `json:"type"`
+	Origin      string `json:"origin"`
+	Description string `json:"description"`
+}
+
+// GitLabIdentifier identifies the rule that produced a finding.
+type GitLabIdentifier struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// GitLabLocation is the file (and line range) a finding is located in.
+type GitLabLocation struct {
+	File      string `json:"file"`
+	StartLine int    `json:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+}
+
+// gitLabSeverity maps a Semgrep severity onto a GitLab severity.
+func gitLabSeverity(severity string) string {
+	switch dtos.NormaliseSeverity(severity) {
+	case dtos.SeverityError:
+		return "High"
+	case dtos.SeverityInfo:
+		return "Low"
+	default:
+		return "Medium"
+	}
+}
+
+// ExportGitLabSAST converts the Semgrep output into a GitLab SAST report (gl-sast-report.json).
+func ExportGitLabSAST(output dtos.SemgrepOutput) ([]byte, error) {
+	data, err := json.MarshalIndent(BuildGitLabSAST(output), "", "  ")
+	if err != nil {
+		zlog.S.Errorf("Failed to produce GitLab SAST report: %v", err)
+		return nil, errors.New("failed to produce GitLab SAST report from semgrep output data")
+	}
+	return data, nil
+}
+
+// BuildGitLabSAST converts the Semgrep output into a GitLab SAST report structure.
+func BuildGitLabSAST(output dtos.SemgrepOutput) GitLabReport {
+	timestamp := now().UTC().Format(gitLabTimeFormat)
+	scanner := GitLabScanner{
+		ID:      gitLabAnalyzerID,
+		Name:    toolName,
+		Version: ToolVersion,
+		URL:     toolInformationURI,
+		Vendor:  GitLabVendor{Name: "SCANOSS"},
+	}
+	if len(scanner.Version) == 0 {
+		scanner.Version = "unknown" // the schema requires a version
+	}
+	vulns := []GitLabVulnerability{}
+	for _, item := range output.Purls {
+		component := item.ComponentName()
+		for _, file := range item.Files {
+			path := componentFilePath(item, file)
+			for _, issue := range file.Issues {
+				location := GitLabLocation{File: path}
+				if region := sarifRegion(issue); region != nil {
+					location.StartLine = region.StartLine
+					location.EndLine = region.EndLine
+				}
+				vuln := GitLabVulnerability{
+					ID:          gitLabID(component, path, issue),
+					Name:        issue.RuleID,
+					Description: fmt.Sprintf("Semgrep rule %v matched in %v (%v)", issue.RuleID, component, file.Path),
+					Severity:    gitLabSeverity(issue.Severity),
+					Identifiers: gitLabIdentifiers(issue),
+					Location:    location,
+				}
+				if issue.Rule != nil && len(issue.Rule.Title) > 0 {
+					vuln.Name = issue.Rule.Title
+				}
+				if issue.Rule != nil && len(issue.Rule.Message) > 0 {
+					vuln.Description = fmt.Sprintf("%v (%v in %v)", issue.Rule.Message, component, file.Path)
+				}
+				if issue.Accepted() {
+					vuln.Flags = []GitLabFlag{{Type: "flagged-as-likely-false-positive", Origin: gitLabAnalyzerID, Description: issue.AcceptanceReason()}}
+				}
+				vulns = append(vulns, vuln)
+			}
+		}
+	}
+	return GitLabReport{
+		Schema:  gitLabSchema,
+		Version: gitLabSchemaVersion,
+		Scan: GitLabScan{
+			Analyzer:  scanner,
+			Scanner:   scanner,
+			Type:      "sast",
+			StartTime: timestamp,
+			EndTime:   timestamp,
+			Status:    "success",
+		},
+		Vulnerabilities: vulns,
+	}
+}
+
+// gitLabIdentifiers returns the identifiers of a finding: the rule, (if available) its fingerprint and any CWE/OWASP categories of the rule.
+func gitLabIdentifiers(issue dtos.IssueItem) []GitLabIdentifier {
+	identifiers := []GitLabIdentifier{{Type: "semgrep_id", Name: issue.RuleID, Value: issue.RuleID}}
+	if len(issue.Fingerprint) > 0 {
+		identifiers = append(identifiers, GitLabIdentifier{Type: "scanoss_fingerprint", Name: "Fingerprint " + issue.Fingerprint, Value: issue.Fingerprint})
+	}
+	if issue.Rule != nil {
+		for _, cwe := range issue.Rule.CWE {
+			id, _, _ := strings.Cut(cwe, ":")
+			identifiers = append(identifiers, GitLabIdentifier{Type: "cwe", Name: cwe, Value: strings.TrimPrefix(strings.TrimSpace(id), "CWE-")})
+		}
+		for _, owasp := range issue.Rule.OWASP {
+			id, _, _ := strings.Cut(owasp, " - ")
+			identifiers = append(identifiers, GitLabIdentifier{Type: "owasp", Name: owasp, Value: strings.TrimSpace(id)})
+		}
+	}
+	return identifiers
+}
+
+// gitLabID produces a stable UUID formatted identifier for a finding, so GitLab can track it across pipelines.
+// The finding fingerprint is used when available, so the ID survives version bumps.
+func gitLabID(component, path string, issue dtos.IssueItem) string {
+	key := fmt.Sprintf("%v|%v|%v|%v|%v", component, path, issue.RuleID, issue.From, issue.To)
+	if len(issue.Fingerprint) > 0 {
+		key = issue.Fingerprint
+	}
+	sum := sha256.Sum256([]byte(key))
+	sum[6] = (sum[6] & 0x0f) | 0x50 // version 5 style
+	sum[8] = (sum[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
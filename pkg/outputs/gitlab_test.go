@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package outputs
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+func TestBuildGitLabSAST(t *testing.T) {
+	now = func() time.Time { return time.Date(2025, 10, 1, 12, 30, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	report := BuildGitLabSAST(testOutput())
+	if report.Version != gitLabSchemaVersion || report.Scan.Type != "sast" || report.Scan.Status != "success" {
+		t.Errorf("BuildGitLabSAST() unexpected header: %+v", report.Scan)
+	}
+	if report.Scan.StartTime != "2025-10-01T12:30:00" || report.Scan.Scanner.Version == "" {
+		t.Errorf("BuildGitLabSAST() scan details = %+v", report.Scan)
+	}
+	if len(report.Vulnerabilities) != 4 {
+		t.Fatalf("BuildGitLabSAST() vulnerabilities = %v, want 4", len(report.Vulnerabilities))
+	}
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	seen := make(map[string]bool)
+	for _, v := range report.Vulnerabilities {
+		if !uuid.MatchString(v.ID) || seen[v.ID] {
+			t.Errorf("BuildGitLabSAST() invalid or duplicate id %v", v.ID)
+		}
+		seen[v.ID] = true
+		if len(v.Identifiers) != 1 || v.Identifiers[0].Value != v.Name {
+			t.Errorf("BuildGitLabSAST() identifiers = %+v", v.Identifiers)
+		}
+	}
+	first := report.Vulnerabilities[0]
+	if first.Severity != "High" || first.Location.File != "npm/lodash@4.17.21/lodash-4.17.21/lodash.js" ||
+		first.Location.StartLine != 10 || first.Location.EndLine != 15 {
+		t.Errorf("BuildGitLabSAST() first vulnerability = %+v", first)
+	}
+	if report.Vulnerabilities[1].Severity != "Low" || report.Vulnerabilities[2].Severity != "Medium" {
+		t.Errorf("BuildGitLabSAST() severities = %v, %v", report.Vulnerabilities[1].Severity, report.Vulnerabilities[2].Severity)
+	}
+	// IDs must be stable between runs so GitLab can track findings across pipelines
+	if again := BuildGitLabSAST(testOutput()); again.Vulnerabilities[0].ID != first.ID {
+		t.Errorf("BuildGitLabSAST() ids are not stable")
+	}
+}
+
+func TestExportGitLabSASTEmpty(t *testing.T) {
+	data, err := ExportGitLabSAST(dtos.SemgrepOutput{})
+	if err != nil {
+		t.Fatalf("ExportGitLabSAST() error = %v", err)
+	}
+	var doc map[string]any
+	if err = json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("ExportGitLabSAST() produced invalid JSON: %v", err)
+	}
+	if vulns, ok := doc["vulnerabilities"].([]any); !ok || len(vulns) != 0 {
+		t.Errorf("ExportGitLabSAST() vulnerabilities should be an empty list: %v", doc["vulnerabilities"])
+	}
+}
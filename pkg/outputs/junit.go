@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file handles exporting Semgrep output to JUnit XML
+
+package outputs
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// JUnitTestSuites is the top level JUnit XML document (one test suite per component).
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite holds the test cases (findings) of a single component.
+type JUnitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Properties []JUnitProperty `xml:"properties>property,omitempty"`
+	Cases      []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitProperty is a name/value pair attached to a test suite.
+type JUnitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// JUnitTestCase is a single finding. Only ERROR findings carry a failure, and suppressed findings are skipped.
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Skipped   *JUnitSkipped `xml:"skipped,omitempty"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// JUnitSkipped describes why a test case was skipped.
+type JUnitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// JUnitFailure describes why a test case failed.
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ExportJUnit converts the Semgrep output into a JUnit XML report.
+func ExportJUnit(output dtos.SemgrepOutput) ([]byte, error) {
+	data, err := xml.MarshalIndent(BuildJUnit(output), "", "  ")
+	if err != nil {
+		zlog.S.Errorf("Failed to produce JUnit XML: %v", err)
+		return nil, errors.New("failed to produce JUnit XML from semgrep output data")
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// BuildJUnit converts the Semgrep output into a JUnit structure.
+// Each component is a test suite and each finding a test case; ERROR findings fail their test case and suppressed findings are skipped.
+// Components without findings get a single passing test case, so they are still listed in the report.
+func BuildJUnit(output dtos.SemgrepOutput) JUnitTestSuites {
+	report := JUnitTestSuites{Name: toolName, Suites: []JUnitTestSuite{}}
+	for _, item := range output.Purls {
+		component := item.ComponentName()
+		suite := JUnitTestSuite{
+			Name:       component,
+			Properties: []JUnitProperty{{Name: "purl", Value: item.Purl}, {Name: "version", Value: item.Version}},
+		}
+		for _, file := range item.Files {
+			for _, issue := range file.Issues {
+				severity := dtos.NormaliseSeverity(issue.Severity)
+				tc := JUnitTestCase{
+					Name:      fmt.Sprintf("%v %v", issue.RuleID, junitLocation(file, issue)),
+					ClassName: component,
+					SystemOut: fmt.Sprintf("severity: %v\nfile MD5: %v\nfingerprint: %v", severity, file.File, issue.Fingerprint),
+				}
+				switch {
+				case issue.Accepted():
+					tc.Skipped = &JUnitSkipped{Message: "suppressed: " + issue.AcceptanceReason()}
+					suite.Skipped++
+				case severity == dtos.SeverityError:
+					tc.Failure = &JUnitFailure{
+						Message: fmt.Sprintf("Semgrep rule %v matched", issue.RuleID),
+						Type:    severity,
+						Text:    fmt.Sprintf("%v\n%v", issue.RuleID, componentFilePath(item, file)),
+					}
+					suite.Failures++
+				}
+				suite.Cases = append(suite.Cases, tc)
+			}
+		}
+		if len(suite.Cases) == 0 {
+			suite.Cases = append(suite.Cases, JUnitTestCase{Name: "no findings", ClassName: component})
+		}
+		suite.Tests = len(suite.Cases)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+		report.Suites = append(report.Suites, suite)
+	}
+	return report
+}
+
+// junitLocation returns the path:from-to location of an issue.
+func junitLocation(file dtos.SemgrepFileIssues, issue dtos.IssueItem) string {
+	path := file.Path
+	if len(path) == 0 {
+		path = file.File
+	}
+	from, to := parseLine(issue.From), parseLine(issue.To)
+	switch {
+	case from == 0:
+		return path
+	case to > from:
+		return fmt.Sprintf("%v:%d-%d", path, from, to)
+	default:
+		return fmt.Sprintf("%v:%d", path, from)
+	}
+}
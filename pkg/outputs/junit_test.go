@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package outputs
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestBuildJUnit(t *testing.T) {
+	report := BuildJUnit(testOutput())
+	if len(report.Suites) != 3 {
+		t.Fatalf("BuildJUnit() suites = %v, want 3", len(report.Suites))
+	}
+	if report.Tests != 5 || report.Failures != 1 {
+		t.Errorf("BuildJUnit() tests/failures = %v/%v, want 5/1", report.Tests, report.Failures)
+	}
+	lodash := report.Suites[0]
+	if lodash.Name != "pkg:npm/lodash@4.17.21" || lodash.Tests != 3 || lodash.Failures != 1 {
+		t.Errorf("BuildJUnit() lodash suite = %v %v/%v", lodash.Name, lodash.Tests, lodash.Failures)
+	}
+	if lodash.Cases[0].Failure == nil || lodash.Cases[1].Failure != nil || lodash.Cases[2].Failure != nil {
+		t.Errorf("BuildJUnit() only ERROR findings should fail: %+v", lodash.Cases)
+	}
+	if lodash.Cases[0].Name != "javascript.lang.security.audit.prototype-pollution lodash-4.17.21/lodash.js:10-15" {
+		t.Errorf("BuildJUnit() test case name = %v", lodash.Cases[0].Name)
+	}
+	leftPad := report.Suites[2]
+	if leftPad.Tests != 1 || leftPad.Failures != 0 || leftPad.Cases[0].Failure != nil {
+		t.Errorf("BuildJUnit() component without findings should have one passing case: %+v", leftPad)
+	}
+}
+
+func TestExportJUnitXML(t *testing.T) {
+	data, err := ExportJUnit(testOutput())
+	if err != nil {
+		t.Fatalf("ExportJUnit() error = %v", err)
+	}
+	if !strings.HasPrefix(string(data), xml.Header) {
+		t.Errorf("ExportJUnit() missing XML header")
+	}
+	var report JUnitTestSuites
+	if err = xml.Unmarshal(data, &report); err != nil {
+		t.Fatalf("ExportJUnit() produced invalid XML: %v", err)
+	}
+	if report.Tests != 5 || report.Failures != 1 || len(report.Suites) != 3 {
+		t.Errorf("ExportJUnit() round trip = %v/%v/%v", report.Tests, report.Failures, len(report.Suites))
+	}
+}
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package outputs
+
+import (
+	"fmt"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// Supported output formats.
+const (
+	FormatJSON   = "json"
+	FormatSARIF  = "sarif"
+	FormatJUnit  = "junit"
+	FormatGitLab = "gitlab"
+	FormatHTML   = "html"
+	FormatMD     = "markdown"
+	FormatTable  = "table"
+)
+
+const (
+	toolName           = "SCANOSS Semgrep"
+	toolInformationURI = "https://github.com/scanoss/semgrep"
+)
+
+// now returns the current time (overridden in tests).
+var now = time.Now
+
+// ToolVersion is the version of the service/CLI reported inside the exported reports (set at startup).
+var ToolVersion string
+
+// exporter converts a Semgrep output into a specific report format.
+type exporter struct {
+	contentType string
+	export      func(output dtos.SemgrepOutput) ([]byte, error)
+}
+
+// exporters holds the list of supported output formats.
+var exporters = map[string]exporter{
+	FormatJSON:  {contentType: "application/json", export: dtos.ExportSemgrepOutput},
+	FormatSARIF: {contentType: "application/sarif+json", export: ExportSARIF},
+	FormatJUnit: {contentType: "application/xml", export: ExportJUnit},
+	// GitLab reports are plain JSON, so use a vendor type to keep Accept header negotiation unambiguous
+	FormatGitLab: {contentType: "application/vnd.gitlab.sast+json", export: ExportGitLabSAST},
+	FormatHTML:   {contentType: "text/html", export: ExportHTML},
+	FormatMD:     {contentType: "text/markdown", export: ExportMarkdown},
+	FormatTable:  {contentType: "text/plain", export: ExportTable},
+}
+
+// Export converts the Semgrep output into the requested report format.
+func Export(format string, output dtos.SemgrepOutput) ([]byte, error) {
+	e, ok := exporters[strings.ToLower(format)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported output format '%v'. Supported formats: %v", format, strings.Join(Formats(), ", "))
+	}
+	return e.export(output)
+}
+
+// ContentType returns the MIME type of the requested report format.
+func ContentType(format string) string {
+	if e, ok := exporters[strings.ToLower(format)]; ok {
+		return e.contentType
+	}
+	return "application/octet-stream"
+}
+
+// Formats returns the sorted list of supported output formats.
+func Formats() []string {
+	formats := make([]string, 0, len(exporters))
+	for f := range exporters {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// IsSupported checks if the given output format is supported.
+func IsSupported(format string) bool {
+	_, ok := exporters[strings.ToLower(format)]
+	return ok
+}
+
+// FormatFromAccept picks the supported output format with the highest quality (q) value from an HTTP Accept header.
+func FormatFromAccept(accept string) (string, bool) {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q <= bestQ {
+			continue
+		}
+		for _, f := range Formats() {
+			if exporters[f].contentType == mediaType {
+				best, bestQ = f, q
+				break
+			}
+		}
+	}
+	return best, len(best) > 0
+}
+
+// parseLine converts a From/To line number into an integer (0 if it's not a valid line).
+func parseLine(line string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// componentFilePath builds the path of a file, prefixed with the component it belongs to (i.e. npm/lodash@4.17.21/lodash.js).
+func componentFilePath(item dtos.SemgrepOutputItem, file dtos.SemgrepFileIssues) string {
+	prefix := strings.TrimPrefix(item.ComponentName(), "pkg:")
+	filePath := file.Path
+	if len(filePath) == 0 {
+		filePath = file.File // no path available, so fall back to the file MD5
+	}
+	var segments []string
+	for _, s := range strings.Split(prefix+"/"+filePath, "/") {
+		if len(s) > 0 {
+			segments = append(segments, s)
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,304 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package outputs
+
+import (
+	"strings"
+	"testing"
+
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// testOutput returns a sample Semgrep output used by all the format tests.
+func testOutput() dtos.SemgrepOutput {
+	return dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{
+		{
+			Purl:    "pkg:npm/lodash",
+			Version: "4.17.21",
+			Files: []dtos.SemgrepFileIssues{
+				{
+					File: "abc123",
+					Path: "lodash-4.17.21/lodash.js",
+					Issues: []dtos.IssueItem{
+						{RuleID: "javascript.lang.security.audit.prototype-pollution", From: "10", To: "15", Severity: "ERROR"},
+						{RuleID: "javascript.lang.correctness.useless-assign", From: "20", To: "20", Severity: "INFO"},
+					},
+				},
+				{
+					File: "def456",
+					Path: "lodash-4.17.21/fp/_baseConvert.js",
+					Issues: []dtos.IssueItem{
+						{RuleID: "javascript.lang.security.audit.prototype-pollution", From: "7", To: "x", Severity: "WARNING"},
+					},
+				},
+			},
+		},
+		{
+			Purl:    "pkg:github/madler/zlib@1.2.13",
+			Version: "1.2.13",
+			Files: []dtos.SemgrepFileIssues{
+				{
+					File: "0011aa",
+					Path: "zlib-1.2.13/inflate.c",
+					Issues: []dtos.IssueItem{
+						{RuleID: "c.lang.security.insecure-use-memset", From: "", To: "", Severity: "WARNING"},
+					},
+				},
+			},
+		},
+		{
+			Purl:    "pkg:npm/left-pad",
+			Version: "1.3.0",
+		},
+	}}
+}
+
+func TestExportFormats(t *testing.T) {
+	for _, format := range Formats() {
+		data, err := Export(format, testOutput())
+		if err != nil {
+			t.Errorf("Export(%v) error = %v", format, err)
+		}
+		if len(data) == 0 {
+			t.Errorf("Export(%v) returned no data", format)
+		}
+		if !IsSupported(format) || ContentType(format) == "application/octet-stream" {
+			t.Errorf("format %v missing content type/support", format)
+		}
+	}
+	if _, err := Export("unknown", testOutput()); err == nil {
+		t.Errorf("Export(unknown) did not return an error")
+	}
+	if ContentType("unknown") != "application/octet-stream" {
+		t.Errorf("ContentType(unknown) = %v", ContentType("unknown"))
+	}
+}
+
+func TestFormatFromAccept(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+		found  bool
+	}{
+		{accept: "application/sarif+json", want: FormatSARIF, found: true},
+		{accept: "text/html;q=0.9, application/json", want: FormatJSON, found: true},
+		{accept: "text/html,application/xhtml+xml,*/*;q=0.8", want: FormatHTML, found: true},
+		{accept: "text/markdown;q=0.5, application/xml;q=0.7", want: FormatJUnit, found: true},
+		{accept: "*/*", want: "", found: false},
+		{accept: "", want: "", found: false},
+	}
+	for _, tt := range tests {
+		got, found := FormatFromAccept(tt.accept)
+		if got != tt.want || found != tt.found {
+			t.Errorf("FormatFromAccept(%v) = %v, %v, want %v, %v", tt.accept, got, found, tt.want, tt.found)
+		}
+	}
+}
+
+func TestSuppressedFindings(t *testing.T) {
+	output := testOutput()
+	output.Purls[0].Files[0].Issues[0].Suppressed = true
+	output.Purls[0].Files[0].Issues[0].SuppressionReason = "Only reachable from trusted input"
+	output.Purls[1].Files[0].Issues[0].SuppressionExpired = true
+	output.Purls[1].Files[0].Issues[0].SuppressionReason = "Vendored copy"
+
+	sarif := BuildSARIF(output)
+	results := sarif.Runs[0].Results
+	if len(results[0].Suppressions) != 1 || results[0].Suppressions[0].Justification != "Only reachable from trusted input" {
+		t.Errorf("BuildSARIF() suppressed result = %+v", results[0])
+	}
+	if results[3].Properties["suppressionExpired"] != "Vendored copy" || len(results[3].Suppressions) != 0 {
+		t.Errorf("BuildSARIF() expired result = %+v", results[3])
+	}
+	junit := BuildJUnit(output)
+	if junit.Failures != 0 || junit.Skipped != 1 || junit.Suites[0].Cases[0].Skipped == nil {
+		t.Errorf("BuildJUnit() failures/skipped = %v/%v", junit.Failures, junit.Skipped)
+	}
+	gitlab := BuildGitLabSAST(output)
+	if len(gitlab.Vulnerabilities[0].Flags) != 1 || len(gitlab.Vulnerabilities[1].Flags) != 0 {
+		t.Errorf("BuildGitLabSAST() flags = %+v", gitlab.Vulnerabilities[0].Flags)
+	}
+	data := buildReportData(output)
+	if data.Totals.Total() != 3 || data.Totals.Error != 0 || data.Suppressed != 1 || data.Components[0].Suppressed != 1 {
+		t.Errorf("buildReportData() totals = %+v, suppressed = %v", data.Totals, data.Suppressed)
+	}
+	table, err := ExportTable(output)
+	if err != nil || !strings.Contains(string(table), "ERROR (suppressed)") || !strings.Contains(string(table), "(1 suppressed)") {
+		t.Errorf("ExportTable() = %s, %v", table, err)
+	}
+}
+
+func TestTriagedFindings(t *testing.T) {
+	output := testOutput()
+	output.Purls[0].Files[0].Issues[0].Triage = &dtos.IssueTriage{State: dtos.TriageAcceptedRisk, Author: "appsec", Comment: "Input is sanitised upstream"}
+	output.Purls[1].Files[0].Issues[0].Triage = &dtos.IssueTriage{State: dtos.TriageConfirmed, Author: "appsec"}
+	reason := "triaged as accepted_risk by appsec: Input is sanitised upstream"
+
+	results := BuildSARIF(output).Runs[0].Results
+	if len(results[0].Suppressions) != 1 || results[0].Suppressions[0].Justification != reason || len(results[3].Suppressions) != 0 {
+		t.Errorf("BuildSARIF() triaged results = %+v, %+v", results[0], results[3])
+	}
+	if junit := BuildJUnit(output); junit.Failures != 0 || junit.Skipped != 1 || junit.Suites[0].Cases[0].Skipped.Message != "suppressed: "+reason {
+		t.Errorf("BuildJUnit() failures/skipped = %v/%v", junit.Failures, junit.Skipped)
+	}
+	if gitlab := BuildGitLabSAST(output); len(gitlab.Vulnerabilities[0].Flags) != 1 || len(gitlab.Vulnerabilities[3].Flags) != 0 {
+		t.Errorf("BuildGitLabSAST() flags = %+v", gitlab.Vulnerabilities)
+	}
+	data := buildReportData(output)
+	if data.Suppressed != 1 || data.Totals.Error != 0 || data.Components[0].Findings[0].Reason != reason {
+		t.Errorf("buildReportData() totals = %+v, suppressed = %v", data.Totals, data.Suppressed)
+	}
+	table, err := ExportTable(output)
+	if err != nil || !strings.Contains(string(table), "ERROR (suppressed)") || !strings.Contains(string(table), "(1 suppressed)") {
+		t.Errorf("ExportTable() = %s, %v", table, err)
+	}
+}
+
+func TestFingerprintedFindings(t *testing.T) {
+	output := testOutput()
+	dtos.AssignFingerprints(output)
+	fingerprint := output.Purls[0].Files[0].Issues[0].Fingerprint
+	if results := BuildSARIF(output).Runs[0].Results; results[0].PartialFingerprints[SARIFFingerprintKey] != fingerprint {
+		t.Errorf("BuildSARIF() partialFingerprints = %v, want %v", results[0].PartialFingerprints, fingerprint)
+	}
+	vuln := BuildGitLabSAST(output).Vulnerabilities[0]
+	if len(vuln.Identifiers) != 2 || vuln.Identifiers[1].Value != fingerprint {
+		t.Errorf("BuildGitLabSAST() identifiers = %+v", vuln.Identifiers)
+	}
+	bumped := testOutput()
+	bumped.Purls[0].Version = "4.17.22"
+	bumped.Purls[0].Files[0].Path = "lodash-4.17.22/lodash.js"
+	dtos.AssignFingerprints(bumped)
+	if BuildGitLabSAST(bumped).Vulnerabilities[0].ID != vuln.ID {
+		t.Errorf("BuildGitLabSAST() ID should be stable across versions")
+	}
+	for _, format := range []string{FormatHTML, FormatMD, FormatTable, FormatJUnit, FormatJSON} {
+		if data, err := Export(format, output); err != nil || !strings.Contains(string(data), fingerprint) {
+			t.Errorf("Export(%v) does not contain the fingerprint (%v)", format, err)
+		}
+	}
+}
+
+func TestBaselineComparedOutput(t *testing.T) {
+	output := testOutput()
+	output.Baseline = &dtos.BaselineSummary{New: 4, Unchanged: 2, Fixed: 1}
+	run := BuildSARIF(output).Runs[0]
+	if run.Results[0].BaselineState != "new" || run.Properties["baseline"] != output.Baseline {
+		t.Errorf("BuildSARIF() baseline = %v, %v", run.Results[0].BaselineState, run.Properties)
+	}
+	for _, format := range []string{FormatHTML, FormatMD, FormatTable} {
+		if data, err := Export(format, output); err != nil || !strings.Contains(string(data), "Compared to baseline") {
+			t.Errorf("Export(%v) does not contain the baseline summary (%v)", format, err)
+		}
+	}
+	if run = BuildSARIF(testOutput()).Runs[0]; run.Results[0].BaselineState != "" || run.Properties != nil {
+		t.Errorf("BuildSARIF() without baseline = %v, %v", run.Results[0].BaselineState, run.Properties)
+	}
+}
+
+func TestRiskScoreOutput(t *testing.T) {
+	output := testOutput()
+	output.RiskScore = 42.5
+	output.Purls[0].RiskScore = 87.25
+	for _, format := range []string{FormatHTML, FormatMD, FormatTable} {
+		if data, err := Export(format, output); err != nil || !strings.Contains(string(data), "42.5") {
+			t.Errorf("Export(%v) does not contain the request risk score (%v)", format, err)
+		}
+	}
+	for _, format := range []string{FormatHTML, FormatMD, FormatJSON} {
+		if data, err := Export(format, output); err != nil || !strings.Contains(string(data), "87.25") {
+			t.Errorf("Export(%v) does not contain the component risk score (%v)", format, err)
+		}
+	}
+}
+
+func TestPeerRankOutput(t *testing.T) {
+	output := testOutput()
+	output.Purls[0].Peer = &dtos.PeerRank{Ecosystem: "npm", Density: 1.5, WorseThan: 85, Components: 120, Summary: "worse than 85% of npm packages"}
+	for _, format := range []string{FormatHTML, FormatMD, FormatJSON} {
+		if data, err := Export(format, output); err != nil || !strings.Contains(string(data), "worse than 85% of npm packages") {
+			t.Errorf("Export(%v) does not contain the peer rank (%v)", format, err)
+		}
+	}
+}
+
+func TestRuleMetadataOutput(t *testing.T) {
+	output := testOutput()
+	output.Purls[0].Files[0].Issues[0].Rule = &dtos.RuleMetadata{
+		Title:      "Prototype pollution",
+		Message:    "Possibility of prototype polluting assignment detected.",
+		CWE:        []string{"CWE-915: Improperly Controlled Modification of Dynamically-Determined Object Attributes"},
+		OWASP:      []string{"A08:2021 - Software and Data Integrity Failures"},
+		References: []string{"https://github.com/HoLyVieR/prototype-pollution-nsec18"},
+	}
+	rule := BuildSARIF(output).Runs[0].Tool.Driver.Rules[2]
+	if rule.ID != "javascript.lang.security.audit.prototype-pollution" || rule.ShortDescription.Text != "Prototype pollution" ||
+		rule.FullDescription == nil || rule.HelpURI == "" || len(rule.Properties["tags"].([]string)) != 2 {
+		t.Errorf("BuildSARIF() rule = %+v", rule)
+	}
+	vuln := BuildGitLabSAST(output).Vulnerabilities[0]
+	if vuln.Name != "Prototype pollution" || len(vuln.Identifiers) != 3 || vuln.Identifiers[1].Type != "cwe" || vuln.Identifiers[1].Value != "915" ||
+		vuln.Identifiers[2].Value != "A08:2021" {
+		t.Errorf("BuildGitLabSAST() vulnerability = %+v", vuln)
+	}
+	for _, format := range []string{FormatHTML, FormatMD, FormatJSON} {
+		if data, err := Export(format, output); err != nil || !strings.Contains(string(data), "CWE-915") {
+			t.Errorf("Export(%v) does not contain the rule CWE (%v)", format, err)
+		}
+	}
+}
+
+func TestCategoryGroupOutput(t *testing.T) {
+	output := testOutput()
+	issue := output.Purls[0].Files[0].Issues[0]
+	output.Groups = []dtos.CategoryGroup{{Category: "CWE-915", Name: "CWE-915: Improperly Controlled Modification", Count: 1,
+		Findings: []dtos.CategoryFinding{{Purl: "pkg:npm/lodash", Version: "4.17.21", FileMD5: "abc123", Path: "lodash.js", Issue: issue}}}}
+	for _, format := range []string{FormatHTML, FormatMD, FormatTable, FormatJSON} {
+		if data, err := Export(format, output); err != nil || !strings.Contains(string(data), "CWE-915: Improperly Controlled Modification") {
+			t.Errorf("Export(%v) does not contain the category group (%v)", format, err)
+		}
+	}
+}
+
+func TestProvenanceOutput(t *testing.T) {
+	output := testOutput()
+	output.KB = &dtos.KBInfo{Version: "25.09", SnapshotDate: "2025-09-01", RulesetVersion: "semgrep-rules@2025-08-28"}
+	output.Purls[0].Provenance = &dtos.Provenance{Source: dtos.ProvenanceKB, ScanDate: "2025-08-30", RulesetVersion: "semgrep-rules@2025-08-15"}
+	if run := BuildSARIF(output).Runs[0]; run.Properties["kb"] != output.KB {
+		t.Errorf("BuildSARIF() properties = %v", run.Properties)
+	}
+	for _, format := range []string{FormatHTML, FormatMD, FormatTable, FormatJSON} {
+		if data, err := Export(format, output); err != nil || !strings.Contains(string(data), "25.09") {
+			t.Errorf("Export(%v) does not contain the KB version (%v)", format, err)
+		}
+	}
+	for _, format := range []string{FormatHTML, FormatMD, FormatJSON} {
+		if data, err := Export(format, output); err != nil || !strings.Contains(string(data), "semgrep-rules@2025-08-15") {
+			t.Errorf("Export(%v) does not contain the component provenance (%v)", format, err)
+		}
+	}
+}
+
+func TestPresenceOutput(t *testing.T) {
+	output := testOutput()
+	output.Purls[0].Presence = &dtos.Presence{Files: 3, Percent: 42.86}
+	for _, format := range []string{FormatHTML, FormatMD} {
+		if data, err := Export(format, output); err != nil || !strings.Contains(string(data), "3 file(s), 42.86%") {
+			t.Errorf("Export(%v) does not contain the component presence (%v)", format, err)
+		}
+	}
+}
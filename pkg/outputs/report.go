@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file handles rendering Semgrep output into human-readable (HTML & Markdown) reports
+
+package outputs
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var (
+	htmlTemplate = htmltemplate.Must(htmltemplate.New("report.html.tmpl").Funcs(htmltemplate.FuncMap{
+		"percent": percent,
+		"lower":   strings.ToLower,
+	}).ParseFS(templateFS, "templates/report.html.tmpl"))
+	markdownTemplate = texttemplate.Must(texttemplate.New("report.md.tmpl").Funcs(texttemplate.FuncMap{
+		"md": markdownEscape,
+	}).ParseFS(templateFS, "templates/report.md.tmpl"))
+)
+
+// severityCounts holds the number of findings per normalised severity.
+type severityCounts struct {
+	Error   int
+	Warning int
+	Info    int
+}
+
+// Total returns the total number of findings.
+func (c severityCounts) Total() int {
+	return c.Error + c.Warning + c.Info
+}
+
+// add increments the count of the given (normalised) severity.
+func (c *severityCounts) add(severity string) {
+	switch severity {
+	case dtos.SeverityError:
+		c.Error++
+	case dtos.SeverityInfo:
+		c.Info++
+	default:
+		c.Warning++
+	}
+}
+
+// reportFinding is a single finding as displayed in a report.
+type reportFinding struct {
+	RuleID      string
+	Title       string   // rule catalog title (if available)
+	Categories  []string // rule catalog CWE & OWASP categories
+	Severity    string
+	Path        string
+	FileMD5     string
+	Lines       string
+	Fingerprint string
+	Suppressed  bool
+	Reason      string // suppression reason (if suppressed)
+}
+
+// reportComponent is the summary and findings of a single component.
+type reportComponent struct {
+	Name       string
+	Purl       string
+	Version    string
+	Counts     severityCounts // excludes suppressed findings
+	Suppressed int
+	RiskScore  float64
+	Peer       *dtos.PeerRank   // set when the component has been ranked against its ecosystem
+	Provenance *dtos.Provenance // set when the scan details of the component are known
+	Presence   *dtos.Presence   // set when the findings were restricted to a file inventory
+	Findings   []reportFinding
+}
+
+// reportData is the data model passed to the report templates.
+type reportData struct {
+	Tool       string
+	Version    string
+	Generated  string
+	Totals     severityCounts // excludes suppressed findings
+	Suppressed int
+	RiskScore  float64
+	Baseline   *dtos.BaselineSummary // set when only the findings new since a baseline are reported
+	Components []reportComponent
+	Groups     []dtos.CategoryGroup // set when the findings have been grouped by CWE or OWASP category
+	KB         *dtos.KBInfo         // set when the KB snapshot the findings were read from is known
+}
+
+// buildReportData converts the Semgrep output into the report template data model.
+func buildReportData(output dtos.SemgrepOutput) reportData {
+	data := reportData{Tool: toolName, Version: ToolVersion, Generated: now().UTC().Format("2006-01-02 15:04:05 UTC"),
+		RiskScore: output.RiskScore, Baseline: output.Baseline, Groups: output.Groups, KB: output.KB}
+	for _, item := range output.Purls {
+		component := reportComponent{Name: item.ComponentName(), Purl: item.Purl, Version: item.Version, RiskScore: item.RiskScore, Peer: item.Peer, Provenance: item.Provenance, Presence: item.Presence}
+		for _, file := range item.Files {
+			for _, issue := range file.Issues {
+				severity := dtos.NormaliseSeverity(issue.Severity)
+				if issue.Accepted() {
+					component.Suppressed++
+					data.Suppressed++
+				} else {
+					component.Counts.add(severity)
+					data.Totals.add(severity)
+				}
+				finding := reportFinding{
+					RuleID:      issue.RuleID,
+					Severity:    severity,
+					Path:        file.Path,
+					FileMD5:     file.File,
+					Lines:       reportLines(issue),
+					Fingerprint: issue.Fingerprint,
+					Suppressed:  issue.Accepted(),
+					Reason:      issue.AcceptanceReason(),
+				}
+				if issue.Rule != nil {
+					finding.Title = issue.Rule.Title
+					finding.Categories = append(append([]string{}, issue.Rule.CWE...), issue.Rule.OWASP...)
+				}
+				component.Findings = append(component.Findings, finding)
+			}
+		}
+		data.Components = append(data.Components, component)
+	}
+	return data
+}
+
+// reportLines returns the displayable line range of an issue.
+func reportLines(issue dtos.IssueItem) string {
+	region := sarifRegion(issue)
+	switch {
+	case region == nil:
+		return ""
+	case region.EndLine > region.StartLine:
+		return fmt.Sprintf("%d-%d", region.StartLine, region.EndLine)
+	default:
+		return fmt.Sprintf("%d", region.StartLine)
+	}
+}
+
+// ExportHTML renders the Semgrep output as a self-contained HTML report.
+func ExportHTML(output dtos.SemgrepOutput) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, buildReportData(output)); err != nil {
+		zlog.S.Errorf("Failed to render HTML report: %v", err)
+		return nil, errors.New("failed to produce HTML report from semgrep output data")
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportMarkdown renders the Semgrep output as a compact Markdown summary (suitable for PR comments).
+func ExportMarkdown(output dtos.SemgrepOutput) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := markdownTemplate.Execute(&buf, buildReportData(output)); err != nil {
+		zlog.S.Errorf("Failed to render Markdown report: %v", err)
+		return nil, errors.New("failed to produce Markdown report from semgrep output data")
+	}
+	return buf.Bytes(), nil
+}
+
+// percent returns the share (0-100) a count represents of the total.
+func percent(count, total int) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(count) * 100 / float64(total)
+}
+
+// markdownEscape escapes the characters that would break a Markdown table cell.
+func markdownEscape(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ", "\r", "", "`", "'", "<", "&lt;", ">", "&gt;").Replace(s)
+}
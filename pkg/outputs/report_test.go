@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package outputs
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+func TestBuildReportData(t *testing.T) {
+	data := buildReportData(testOutput())
+	if data.Totals != (severityCounts{Error: 1, Warning: 2, Info: 1}) {
+		t.Errorf("buildReportData() totals = %+v", data.Totals)
+	}
+	if len(data.Components) != 3 || data.Components[0].Counts.Total() != 3 || data.Components[2].Counts.Total() != 0 {
+		t.Fatalf("buildReportData() components = %+v", data.Components)
+	}
+	lines := []string{data.Components[0].Findings[0].Lines, data.Components[0].Findings[1].Lines, data.Components[1].Findings[0].Lines}
+	if lines[0] != "10-15" || lines[1] != "20" || lines[2] != "" {
+		t.Errorf("buildReportData() lines = %v", lines)
+	}
+}
+
+func TestExportHTML(t *testing.T) {
+	output := testOutput()
+	output.Purls[0].Files[0].Path = "<script>alert(1)</script>.js"
+	data, err := ExportHTML(output)
+	if err != nil {
+		t.Fatalf("ExportHTML() error = %v", err)
+	}
+	html := string(data)
+	for _, want := range []string{"<!DOCTYPE html>", "pkg:npm/lodash@4.17.21", "class=\"chart\"", "table.sortable", "&lt;script&gt;alert(1)&lt;/script&gt;.js"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("ExportHTML() missing %q", want)
+		}
+	}
+	if strings.Contains(html, "<script>alert(1)") {
+		t.Errorf("ExportHTML() did not escape file paths")
+	}
+	if strings.Contains(html, "<link") || strings.Contains(html, "src=\"http") {
+		t.Errorf("ExportHTML() report should be self-contained")
+	}
+}
+
+func TestExportMarkdown(t *testing.T) {
+	now = func() time.Time { return time.Date(2025, 10, 1, 12, 30, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	output := testOutput()
+	output.Purls[0].Files[0].Path = "a|b.js"
+	data, err := ExportMarkdown(output)
+	if err != nil {
+		t.Fatalf("ExportMarkdown() error = %v", err)
+	}
+	md := string(data)
+	for _, want := range []string{
+		"**4** finding(s) in 3 component(s)",
+		"| `pkg:npm/left-pad@1.3.0` | 0 | 0 | 0 |",
+		"<details><summary><code>pkg:npm/lodash@4.17.21</code> (3 finding(s))</summary>",
+		"| ERROR | `javascript.lang.security.audit.prototype-pollution` | a\\|b.js | 10-15 |",
+		"2025-10-01 12:30:00 UTC",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("ExportMarkdown() missing %q in:\n%v", want, md)
+		}
+	}
+	if strings.Count(md, "<details>") != 2 {
+		t.Errorf("ExportMarkdown() should only have details for components with findings")
+	}
+	empty, err := ExportMarkdown(dtos.SemgrepOutput{})
+	if err != nil || !strings.Contains(string(empty), "No findings") {
+		t.Errorf("ExportMarkdown() empty output = %v, %v", string(empty), err)
+	}
+}
@@ -0,0 +1,280 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file handles exporting Semgrep output to SARIF 2.1.0
+
+package outputs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// SARIFFingerprintKey is the partialFingerprints entry holding the finding fingerprint (see dtos.Fingerprint).
+const SARIFFingerprintKey = "scanossFingerprint/v1"
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIFLog is the top level SARIF 2.1.0 document.
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun is a single run of the analysis tool.
+type SARIFRun struct {
+	Tool       SARIFTool      `json:"tool"`
+	Results    []SARIFResult  `json:"results"`
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// SARIFTool describes the analysis tool that produced the run.
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver describes the tool component and the rules it reports on.
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Version        string      `json:"version,omitempty"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+// SARIFRule describes a single reporting rule.
+type SARIFRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name,omitempty"`
+	ShortDescription     *SARIFMessage      `json:"shortDescription,omitempty"`
+	FullDescription      *SARIFMessage      `json:"fullDescription,omitempty"`
+	HelpURI              string             `json:"helpUri,omitempty"`
+	DefaultConfiguration *SARIFRuleDefaults `json:"defaultConfiguration,omitempty"`
+	Properties           map[string]any     `json:"properties,omitempty"`
+}
+
+// SARIFRuleDefaults holds the default configuration of a rule.
+type SARIFRuleDefaults struct {
+	Level string `json:"level"`
+}
+
+// SARIFMessage is a plain text SARIF message.
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFResult is a single finding.
+type SARIFResult struct {
+	RuleID              string             `json:"ruleId"`
+	RuleIndex           int                `json:"ruleIndex"`
+	Level               string             `json:"level"`
+	Message             SARIFMessage       `json:"message"`
+	Locations           []SARIFLocation    `json:"locations,omitempty"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints,omitempty"`
+	BaselineState       string             `json:"baselineState,omitempty"`
+	Suppressions        []SARIFSuppression `json:"suppressions,omitempty"`
+	Properties          map[string]any     `json:"properties,omitempty"`
+}
+
+// SARIFSuppression records that a finding has been accepted (i.e. via an ignore file).
+type SARIFSuppression struct {
+	Kind          string `json:"kind"`
+	Status        string `json:"status,omitempty"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// SARIFLocation is the location of a finding.
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation is the file (and region) a finding is located in.
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+// SARIFArtifactLocation is the URI of the file a finding is located in.
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIFRegion is the line range a finding covers.
+type SARIFRegion struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine,omitempty"`
+}
+
+// sarifLevel maps a Semgrep severity onto a SARIF result level.
+func sarifLevel(severity string) string {
+	switch dtos.NormaliseSeverity(severity) {
+	case dtos.SeverityError:
+		return "error"
+	case dtos.SeverityInfo:
+		return "note"
+	default:
+		return "warning"
+	}
+}
+
+// ExportSARIF converts the Semgrep output into a SARIF 2.1.0 log (one run per request).
+func ExportSARIF(output dtos.SemgrepOutput) ([]byte, error) {
+	log := BuildSARIF(output)
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		zlog.S.Errorf("Failed to produce SARIF: %v", err)
+		return nil, errors.New("failed to produce SARIF from semgrep output data")
+	}
+	return data, nil
+}
+
+// BuildSARIF converts the Semgrep output into a SARIF 2.1.0 log structure.
+func BuildSARIF(output dtos.SemgrepOutput) SARIFLog {
+	rules, ruleIndex := sarifRules(output)
+	results := []SARIFResult{}
+	for _, item := range output.Purls {
+		component := item.ComponentName()
+		for _, file := range item.Files {
+			for _, issue := range file.Issues {
+				result := SARIFResult{
+					RuleID:    issue.RuleID,
+					RuleIndex: ruleIndex[issue.RuleID],
+					Level:     sarifLevel(issue.Severity),
+					Message:   SARIFMessage{Text: fmt.Sprintf("Semgrep rule %v matched in %v (%v)", issue.RuleID, component, file.Path)},
+					Locations: []SARIFLocation{{PhysicalLocation: SARIFPhysicalLocation{
+						ArtifactLocation: SARIFArtifactLocation{URI: sarifURI(item, file)},
+						Region:           sarifRegion(issue),
+					}}},
+					Properties: map[string]any{"purl": item.Purl, "version": item.Version, "fileMD5": file.File},
+				}
+				if len(issue.Fingerprint) > 0 {
+					result.PartialFingerprints = map[string]string{SARIFFingerprintKey: issue.Fingerprint}
+				}
+				if output.Baseline != nil {
+					result.BaselineState = "new" // only new findings are kept when comparing against a baseline
+				}
+				switch {
+				case issue.Accepted():
+					result.Suppressions = []SARIFSuppression{{Kind: "external", Status: "accepted", Justification: issue.AcceptanceReason()}}
+				case issue.SuppressionExpired:
+					result.Properties["suppressionExpired"] = issue.SuppressionReason
+				}
+				results = append(results, result)
+			}
+		}
+	}
+	run := SARIFRun{
+		Tool:    SARIFTool{Driver: SARIFDriver{Name: toolName, InformationURI: toolInformationURI, Version: ToolVersion, Rules: rules}},
+		Results: results,
+	}
+	if output.Baseline != nil || output.KB != nil {
+		run.Properties = map[string]any{}
+	}
+	if output.Baseline != nil {
+		run.Properties["baseline"] = output.Baseline
+	}
+	if output.KB != nil {
+		run.Properties["kb"] = output.KB
+	}
+	return SARIFLog{Schema: sarifSchema, Version: sarifVersion, Runs: []SARIFRun{run}}
+}
+
+// sarifRules builds the sorted list of distinct rules reported in the output, along with a rule ID -> index map.
+// Rules with catalog metadata are described using their title, message, first reference and CWE/OWASP tags.
+func sarifRules(output dtos.SemgrepOutput) ([]SARIFRule, map[string]int) {
+	levels := make(map[string]string)
+	metadata := make(map[string]*dtos.RuleMetadata)
+	for _, item := range output.Purls {
+		for _, file := range item.Files {
+			for _, issue := range file.Issues {
+				if _, ok := levels[issue.RuleID]; !ok {
+					levels[issue.RuleID] = sarifLevel(issue.Severity)
+				}
+				if issue.Rule != nil && metadata[issue.RuleID] == nil {
+					metadata[issue.RuleID] = issue.Rule
+				}
+			}
+		}
+	}
+	ids := make([]string, 0, len(levels))
+	for id := range levels {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	rules := make([]SARIFRule, 0, len(ids))
+	index := make(map[string]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+		rule := SARIFRule{
+			ID:                   id,
+			Name:                 id,
+			ShortDescription:     &SARIFMessage{Text: id},
+			DefaultConfiguration: &SARIFRuleDefaults{Level: levels[id]},
+		}
+		if m := metadata[id]; m != nil {
+			if len(m.Title) > 0 {
+				rule.ShortDescription = &SARIFMessage{Text: m.Title}
+			}
+			if len(m.Message) > 0 {
+				rule.FullDescription = &SARIFMessage{Text: m.Message}
+			}
+			if len(m.References) > 0 {
+				rule.HelpURI = m.References[0]
+			}
+			if tags := append(append([]string{}, m.CWE...), m.OWASP...); len(tags) > 0 {
+				rule.Properties = map[string]any{"tags": tags}
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, index
+}
+
+// sarifURI builds the (escaped) artifact URI of a file, prefixed with the component it belongs to.
+// Purl segments may already be percent-encoded (i.e. %40 for @), so they're decoded before escaping.
+func sarifURI(item dtos.SemgrepOutputItem, file dtos.SemgrepFileIssues) string {
+	segments := strings.Split(componentFilePath(item, file), "/")
+	for i, s := range segments {
+		if raw, err := url.PathUnescape(s); err == nil {
+			s = raw
+		}
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// sarifRegion converts the From/To lines of an issue into a SARIF region (if they are valid).
+func sarifRegion(issue dtos.IssueItem) *SARIFRegion {
+	from, to := parseLine(issue.From), parseLine(issue.To)
+	if from == 0 {
+		return nil
+	}
+	region := &SARIFRegion{StartLine: from}
+	if to >= from {
+		region.EndLine = to
+	}
+	return region
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package outputs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+func TestBuildSARIF(t *testing.T) {
+	log := BuildSARIF(testOutput())
+	if log.Version != sarifVersion || len(log.Runs) != 1 {
+		t.Fatalf("BuildSARIF() unexpected log header: %+v", log)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 3 {
+		t.Errorf("BuildSARIF() rules = %v, want 3 distinct rules", len(run.Tool.Driver.Rules))
+	}
+	if len(run.Results) != 4 {
+		t.Fatalf("BuildSARIF() results = %v, want 4", len(run.Results))
+	}
+	for _, r := range run.Results {
+		if run.Tool.Driver.Rules[r.RuleIndex].ID != r.RuleID {
+			t.Errorf("BuildSARIF() result rule index %v doesn't point to %v", r.RuleIndex, r.RuleID)
+		}
+	}
+	first := run.Results[0]
+	if first.Level != "error" {
+		t.Errorf("BuildSARIF() level = %v, want error", first.Level)
+	}
+	loc := first.Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "npm/lodash@4.17.21/lodash-4.17.21/lodash.js" {
+		t.Errorf("BuildSARIF() uri = %v", loc.ArtifactLocation.URI)
+	}
+	if loc.Region == nil || loc.Region.StartLine != 10 || loc.Region.EndLine != 15 {
+		t.Errorf("BuildSARIF() region = %+v, want 10-15", loc.Region)
+	}
+	if run.Results[1].Level != "note" {
+		t.Errorf("BuildSARIF() level = %v, want note", run.Results[1].Level)
+	}
+	if r := run.Results[2].Locations[0].PhysicalLocation.Region; r == nil || r.EndLine != 0 {
+		t.Errorf("BuildSARIF() region with invalid end line = %+v", r)
+	}
+	zlib := run.Results[3].Locations[0].PhysicalLocation
+	if zlib.Region != nil || zlib.ArtifactLocation.URI != "github/madler/zlib@1.2.13/zlib-1.2.13/inflate.c" {
+		t.Errorf("BuildSARIF() zlib location = %+v", zlib)
+	}
+}
+
+func TestBuildSARIFEmpty(t *testing.T) {
+	log := BuildSARIF(dtos.SemgrepOutput{})
+	if len(log.Runs) != 1 || log.Runs[0].Results == nil || len(log.Runs[0].Results) != 0 {
+		t.Errorf("BuildSARIF() empty output should produce one run with no results: %+v", log)
+	}
+}
+
+func TestSarifURI(t *testing.T) {
+	tests := []struct {
+		name string
+		purl string
+		path string
+		want string
+	}{
+		{name: "plain", purl: "pkg:npm/lodash", path: "lodash/lodash.js", want: "npm/lodash/lodash/lodash.js"},
+		{name: "encoded namespace", purl: "pkg:npm/%40babel/core", path: "core/lib/index.js", want: "npm/@babel/core/core/lib/index.js"},
+		{name: "raw namespace", purl: "pkg:npm/@babel/core", path: "core/lib/index.js", want: "npm/@babel/core/core/lib/index.js"},
+		{name: "special characters", purl: "pkg:github/acme/tool", path: "src/my file#1.c", want: "github/acme/tool/src/my%20file%231.c"},
+		{name: "invalid escape", purl: "pkg:github/acme/tool", path: "src/100%.c", want: "github/acme/tool/src/100%25.c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sarifURI(dtos.SemgrepOutputItem{Purl: tt.purl}, dtos.SemgrepFileIssues{Path: tt.path})
+			if got != tt.want {
+				t.Errorf("sarifURI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportSARIFJSON(t *testing.T) {
+	data, err := ExportSARIF(testOutput())
+	if err != nil {
+		t.Fatalf("ExportSARIF() error = %v", err)
+	}
+	var doc map[string]any
+	if err = json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("ExportSARIF() produced invalid JSON: %v", err)
+	}
+	if doc["$schema"] != sarifSchema || doc["version"] != sarifVersion {
+		t.Errorf("ExportSARIF() header = %v %v", doc["$schema"], doc["version"])
+	}
+}
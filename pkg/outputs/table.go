@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file handles exporting Semgrep output to a plain text table (for terminals)
+
+package outputs
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// ExportTable converts the Semgrep output into a plain text table, one finding per row, followed by a summary.
+func ExportTable(output dtos.SemgrepOutput) ([]byte, error) {
+	data := buildReportData(output)
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "COMPONENT\tSEVERITY\tRULE\tFILE\tLINES\tFINGERPRINT")
+	for _, c := range data.Components {
+		if len(c.Findings) == 0 {
+			_, _ = fmt.Fprintf(tw, "%v\t-\t-\t-\t-\t-\n", c.Name)
+			continue
+		}
+		for _, f := range c.Findings {
+			path := f.Path
+			if len(path) == 0 {
+				path = f.FileMD5
+			}
+			severity := f.Severity
+			if f.Suppressed {
+				severity += " (suppressed)"
+			}
+			_, _ = fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\n", c.Name, severity, f.RuleID, path, f.Lines, f.Fingerprint)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to produce table from semgrep output data: %v", err)
+	}
+	_, _ = fmt.Fprintf(&buf, "\n%d component(s), %d finding(s): %d error, %d warning, %d info",
+		len(data.Components), data.Totals.Total(), data.Totals.Error, data.Totals.Warning, data.Totals.Info)
+	if data.Suppressed > 0 {
+		_, _ = fmt.Fprintf(&buf, " (%d suppressed)", data.Suppressed)
+	}
+	if data.RiskScore > 0 {
+		_, _ = fmt.Fprintf(&buf, ", risk score %v", data.RiskScore)
+	}
+	if data.KB != nil {
+		_, _ = fmt.Fprintf(&buf, "\nKB %v (snapshot %v, ruleset %v)", data.KB.Version, data.KB.SnapshotDate, data.KB.RulesetVersion)
+	}
+	if data.Baseline != nil {
+		_, _ = fmt.Fprintf(&buf, "\nCompared to baseline: %d new, %d unchanged (not listed), %d fixed",
+			data.Baseline.New, data.Baseline.Unchanged, data.Baseline.Fixed)
+	}
+	for i, g := range data.Groups {
+		if i == 0 {
+			_, _ = fmt.Fprintf(&buf, "\nFindings by category:")
+		}
+		_, _ = fmt.Fprintf(&buf, "\n  %v: %d finding(s)", g.Category, g.Count)
+		if len(g.Name) > 0 && g.Name != g.Category {
+			_, _ = fmt.Fprintf(&buf, " (%v)", g.Name)
+		}
+	}
+	_, _ = fmt.Fprintln(&buf)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package outputs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportTable(t *testing.T) {
+	data, err := ExportTable(testOutput())
+	if err != nil {
+		t.Fatalf("ExportTable() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 8 {
+		t.Fatalf("ExportTable() lines = %v, want 8:\n%v", len(lines), string(data))
+	}
+	if !strings.HasPrefix(lines[0], "COMPONENT") || !strings.Contains(lines[1], "ERROR") || !strings.Contains(lines[1], "10-15") {
+		t.Errorf("ExportTable() unexpected rows:\n%v", string(data))
+	}
+	if !strings.HasPrefix(lines[5], "pkg:npm/left-pad@1.3.0") {
+		t.Errorf("ExportTable() component without findings missing: %v", lines[5])
+	}
+	if lines[7] != "3 component(s), 4 finding(s): 1 error, 2 warning, 1 info" {
+		t.Errorf("ExportTable() summary = %v", lines[7])
+	}
+}
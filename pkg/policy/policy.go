@@ -0,0 +1,262 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// Verdicts, from best to worst.
+const (
+	VerdictPass = "pass"
+	VerdictWarn = "warn"
+	VerdictFail = "fail"
+)
+
+// Rule scopes: count the selected findings of each component, or of the whole request.
+const (
+	ScopeComponent = "component"
+	ScopeRequest   = "request"
+)
+
+// Rule is a single acceptance rule. All the supplied selectors must match for a finding to be counted.
+type Rule struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Verdict     string   `yaml:"verdict" json:"verdict"`                              // warn or fail
+	Scope       string   `yaml:"scope,omitempty" json:"scope,omitempty"`              // component (default) or request
+	Severities  []string `yaml:"severities,omitempty" json:"severities,omitempty"`    // ERROR, WARNING and/or INFO
+	Rules       []string `yaml:"rules,omitempty" json:"rules,omitempty"`              // Rule ID globs (i.e. go.lang.security.*)
+	Ecosystems  []string `yaml:"ecosystems,omitempty" json:"ecosystems,omitempty"`    // Purl types (i.e. npm, golang)
+	Components  []string `yaml:"components,omitempty" json:"components,omitempty"`    // Purl name globs (i.e. pkg:npm/@angular/*)
+	MaxFindings int      `yaml:"max_findings,omitempty" json:"maxFindings,omitempty"` // Number of selected findings allowed (default 0)
+}
+
+// Policy is a set of acceptance rules.
+type Policy struct {
+	Rules []Rule `yaml:"rules" json:"rules"`
+}
+
+// Trigger records a rule whose limit has been exceeded.
+type Trigger struct {
+	Rule        string `json:"rule"`
+	Description string `json:"description,omitempty"`
+	Verdict     string `json:"verdict"`
+	Findings    int    `json:"findings"`
+	Message     string `json:"message"`
+}
+
+// ComponentResult is the verdict of a single component, along with the rules that caused it.
+type ComponentResult struct {
+	Purl      string    `json:"purl"`
+	Version   string    `json:"version"`
+	Verdict   string    `json:"verdict"`
+	Triggered []Trigger `json:"triggered,omitempty"`
+}
+
+// Result is the outcome of evaluating a policy against a Semgrep output.
+// The request verdict is the worst of all the component verdicts and triggered request rules.
+type Result struct {
+	Verdict    string            `json:"verdict"`
+	Triggered  []Trigger         `json:"triggered,omitempty"` // Request scoped rules triggered
+	Components []ComponentResult `json:"components"`
+}
+
+// Load reads and validates the given policy file.
+func Load(filename string) (*Policy, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file %v: %v", filename, err)
+	}
+	p, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy file %v: %v", filename, err)
+	}
+	return p, nil
+}
+
+// Parse parses and validates a YAML policy, rejecting unknown settings.
+func Parse(data []byte) (*Policy, error) {
+	var p Policy
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse policy: %v", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Validate checks the rules of the policy, normalising their verdicts, scopes, severities and ecosystems.
+func (p *Policy) Validate() error {
+	if len(p.Rules) == 0 {
+		return errors.New("policy has no rules")
+	}
+	names := make(map[string]bool)
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		if len(strings.TrimSpace(r.Name)) == 0 {
+			return fmt.Errorf("rule %d: a name is required", i+1)
+		}
+		if names[r.Name] {
+			return fmt.Errorf("rule %d: duplicate name '%v'", i+1, r.Name)
+		}
+		names[r.Name] = true
+		if err := r.validate(); err != nil {
+			return fmt.Errorf("rule '%v': %v", r.Name, err)
+		}
+	}
+	return nil
+}
+
+// validate checks and normalises a single rule.
+func (r *Rule) validate() error {
+	r.Verdict = strings.ToLower(strings.TrimSpace(r.Verdict))
+	if r.Verdict != VerdictWarn && r.Verdict != VerdictFail {
+		return fmt.Errorf("verdict must be %v or %v", VerdictWarn, VerdictFail)
+	}
+	r.Scope = strings.ToLower(strings.TrimSpace(r.Scope))
+	switch r.Scope {
+	case "":
+		r.Scope = ScopeComponent
+	case ScopeComponent, ScopeRequest:
+	default:
+		return fmt.Errorf("scope must be %v or %v", ScopeComponent, ScopeRequest)
+	}
+	if r.MaxFindings < 0 {
+		return fmt.Errorf("max_findings cannot be negative (%d)", r.MaxFindings)
+	}
+	for i, severity := range r.Severities {
+		r.Severities[i] = strings.ToUpper(strings.TrimSpace(severity))
+		if r.Severities[i] != dtos.NormaliseSeverity(r.Severities[i]) {
+			return fmt.Errorf("unknown severity '%v'", severity)
+		}
+	}
+	for i, ecosystem := range r.Ecosystems {
+		r.Ecosystems[i] = strings.ToLower(strings.TrimSpace(ecosystem))
+	}
+	for _, glob := range append(append([]string{}, r.Rules...), r.Components...) {
+		if _, err := path.Match(glob, ""); err != nil {
+			return fmt.Errorf("bad glob '%v': %v", glob, err)
+		}
+	}
+	return nil
+}
+
+// Evaluate checks the Semgrep output against the policy rules. Suppressed findings, and findings triaged as
+// false positives or accepted risks, are not counted.
+func (p *Policy) Evaluate(output dtos.SemgrepOutput) Result {
+	result := Result{Verdict: VerdictPass, Components: []ComponentResult{}}
+	requestCounts := make([]int, len(p.Rules))
+	for _, item := range output.Purls {
+		component := ComponentResult{Purl: item.Purl, Version: item.Version, Verdict: VerdictPass}
+		counts := make([]int, len(p.Rules))
+		for i := range p.Rules {
+			if !p.Rules[i].matchesComponent(item.Purl) {
+				continue
+			}
+			for _, file := range item.Files {
+				for _, issue := range file.Issues {
+					if !issue.Accepted() && p.Rules[i].matchesIssue(issue) {
+						counts[i]++
+					}
+				}
+			}
+			requestCounts[i] += counts[i]
+		}
+		for i, r := range p.Rules {
+			if r.Scope == ScopeComponent && counts[i] > r.MaxFindings {
+				component.Triggered = append(component.Triggered, r.trigger(counts[i]))
+				component.Verdict = worst(component.Verdict, r.Verdict)
+			}
+		}
+		result.Verdict = worst(result.Verdict, component.Verdict)
+		result.Components = append(result.Components, component)
+	}
+	for i, r := range p.Rules {
+		if r.Scope == ScopeRequest && requestCounts[i] > r.MaxFindings {
+			result.Triggered = append(result.Triggered, r.trigger(requestCounts[i]))
+			result.Verdict = worst(result.Verdict, r.Verdict)
+		}
+	}
+	return result
+}
+
+// trigger describes the rule being triggered by the given number of findings.
+func (r Rule) trigger(findings int) Trigger {
+	return Trigger{
+		Rule:        r.Name,
+		Description: r.Description,
+		Verdict:     r.Verdict,
+		Findings:    findings,
+		Message:     fmt.Sprintf("%d finding(s) exceed the %v limit of %d", findings, r.Scope, r.MaxFindings),
+	}
+}
+
+// matchesComponent checks if the rule applies to the given component purl.
+func (r Rule) matchesComponent(purl string) bool {
+	name := dtos.PurlName(purl)
+	if len(r.Ecosystems) > 0 && !slices.Contains(r.Ecosystems, purlType(name)) {
+		return false
+	}
+	return len(r.Components) == 0 || matchesAny(r.Components, name)
+}
+
+// matchesIssue checks if the rule selects the given finding.
+func (r Rule) matchesIssue(issue dtos.IssueItem) bool {
+	if len(r.Severities) > 0 && !slices.Contains(r.Severities, dtos.NormaliseSeverity(issue.Severity)) {
+		return false
+	}
+	return len(r.Rules) == 0 || matchesAny(r.Rules, issue.RuleID)
+}
+
+// worst returns the worse of the two verdicts.
+func worst(a, b string) string {
+	rank := map[string]int{VerdictPass: 0, VerdictWarn: 1, VerdictFail: 2}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// purlType returns the type (ecosystem) of a purl (i.e. npm for pkg:npm/lodash).
+func purlType(purl string) string {
+	t, _, _ := strings.Cut(strings.TrimPrefix(purl, "pkg:"), "/")
+	return strings.ToLower(t)
+}
+
+// matchesAny checks if the value matches any of the globs.
+func matchesAny(globs []string, value string) bool {
+	for _, glob := range globs {
+		if matched, _ := path.Match(glob, value); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package policy
+
+import (
+	"testing"
+
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// policyOutput returns 1 error, 3 warning and 1 info findings over 3 components.
+func policyOutput() dtos.SemgrepOutput {
+	return dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{
+		{Purl: "pkg:npm/lodash", Files: []dtos.SemgrepFileIssues{
+			{Issues: []dtos.IssueItem{
+				{RuleID: "javascript.lang.security.audit.prototype-pollution", Severity: "ERROR"},
+				{RuleID: "javascript.lang.correctness.useless-assign", Severity: "INFO"},
+				{RuleID: "javascript.lang.security.audit.prototype-pollution", Severity: "WARNING"},
+			}},
+		}},
+		{Purl: "pkg:github/madler/zlib", Files: []dtos.SemgrepFileIssues{
+			{Issues: []dtos.IssueItem{
+				{RuleID: "c.lang.security.insecure-use-memset", Severity: "WARNING"},
+				{RuleID: "c.lang.security.insecure-use-strcpy", Severity: "WARNING"},
+			}},
+		}},
+		{Purl: "pkg:npm/left-pad"},
+	}}
+}
+
+func TestLoadEvaluate(t *testing.T) {
+	p, err := Load("./tests/policy.yml")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(p.Rules) != 5 || p.Rules[1].Severities[0] != dtos.SeverityWarning || p.Rules[0].Scope != ScopeComponent {
+		t.Fatalf("Load() rules = %+v", p.Rules)
+	}
+	result := p.Evaluate(policyOutput())
+	if result.Verdict != VerdictFail || len(result.Components) != 3 {
+		t.Fatalf("Evaluate() = %+v", result)
+	}
+	tests := []struct {
+		component string
+		verdict   string
+		rules     []string
+	}{
+		{component: "pkg:npm/lodash", verdict: VerdictFail, rules: []string{"no-errors", "lodash-review"}},
+		{component: "pkg:github/madler/zlib", verdict: VerdictFail, rules: []string{"warning-budget", "c-memory-safety"}},
+		{component: "pkg:npm/left-pad", verdict: VerdictPass},
+	}
+	for i, tt := range tests {
+		c := result.Components[i]
+		if c.Purl != tt.component || c.Verdict != tt.verdict || len(c.Triggered) != len(tt.rules) {
+			t.Errorf("Evaluate() component %v = %+v, want %v %v", i, c, tt.verdict, tt.rules)
+			continue
+		}
+		for j, trigger := range c.Triggered {
+			if trigger.Rule != tt.rules[j] {
+				t.Errorf("Evaluate() component %v trigger %v = %v, want %v", c.Purl, j, trigger.Rule, tt.rules[j])
+			}
+		}
+	}
+	if len(result.Triggered) != 1 || result.Triggered[0].Rule != "request-budget" || result.Triggered[0].Findings != 5 ||
+		result.Triggered[0].Message != "5 finding(s) exceed the request limit of 3" {
+		t.Errorf("Evaluate() request triggers = %+v", result.Triggered)
+	}
+}
+
+func TestEvaluateAccepted(t *testing.T) {
+	p, err := Parse([]byte("rules:\n  - name: no-errors\n    verdict: warn\n    severities: [ERROR]\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	output := policyOutput()
+	if result := p.Evaluate(output); result.Verdict != VerdictWarn || result.Components[0].Verdict != VerdictWarn {
+		t.Errorf("Evaluate() = %+v, want warn", result)
+	}
+	output.Purls[0].Files[0].Issues[0].Triage = &dtos.IssueTriage{State: dtos.TriageFalsePositive}
+	if result := p.Evaluate(output); result.Verdict != VerdictPass {
+		t.Errorf("Evaluate() triaged = %+v, want pass", result)
+	}
+	output.Purls[0].Files[0].Issues[0].Triage = &dtos.IssueTriage{State: dtos.TriageConfirmed}
+	output.Purls[0].Files[0].Issues[0].Suppressed = true
+	if result := p.Evaluate(output); result.Verdict != VerdictPass {
+		t.Errorf("Evaluate() suppressed = %+v, want pass", result)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "unknown field", input: "rules:\n  - name: a\n    verdict: fail\n    max_errors: 1\n"},
+		{name: "no name", input: "rules:\n  - verdict: fail\n"},
+		{name: "duplicate", input: "rules:\n  - name: a\n    verdict: fail\n  - name: a\n    verdict: warn\n"},
+		{name: "bad verdict", input: "rules:\n  - name: a\n    verdict: block\n"},
+		{name: "bad scope", input: "rules:\n  - name: a\n    verdict: fail\n    scope: file\n"},
+		{name: "bad severity", input: "rules:\n  - name: a\n    verdict: fail\n    severities: [urgent]\n"},
+		{name: "negative limit", input: "rules:\n  - name: a\n    verdict: fail\n    max_findings: -1\n"},
+		{name: "bad glob", input: "rules:\n  - name: a\n    verdict: fail\n    rules: ['[bad']\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.input)); err == nil {
+				t.Errorf("Parse(%q) should fail", tt.input)
+			}
+		})
+	}
+	if _, err := Load("./tests/missing.yml"); err == nil {
+		t.Errorf("Load() missing file should fail")
+	}
+}
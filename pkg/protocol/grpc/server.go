@@ -25,12 +25,13 @@ import (
 
 	pb "github.com/scanoss/papi/api/semgrepv2"
 	"google.golang.org/grpc"
+	pbx "scanoss.com/semgrep/api/semgrepextv2"
 )
 
 // TODO Add proper service startup/shutdown here
 
-// RunServer runs gRPC service to publish.
-func RunServer(config *myconfig.ServerConfig, v2API pb.SemgrepServer, port string,
+// RunServer runs gRPC service to publish. The triage service is only registered if triageAPI is supplied.
+func RunServer(config *myconfig.ServerConfig, v2API pb.SemgrepServer, issuesAPI pbx.SemgrepIssuesServer, triageAPI pbx.SemgrepTriageServer, port string,
 	allowedIPs, deniedIPs []string, startTLS bool, version string) (*grpc.Server, error) {
 	// Start up Open Telemetry is requested
 	var oltpShutdown = func() {}
@@ -52,6 +53,10 @@ func RunServer(config *myconfig.ServerConfig, v2API pb.SemgrepServer, port strin
 	}
 	// Register the service API and start the server in the background
 	pb.RegisterSemgrepServer(server, v2API)
+	pbx.RegisterSemgrepIssuesServer(server, issuesAPI)
+	if triageAPI != nil {
+		pbx.RegisterSemgrepTriageServer(server, triageAPI)
+	}
 	go func() {
 		gs.StartGrpcServer(listen, server, startTLS)
 		oltpShutdown()
@@ -22,15 +22,22 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	gw "github.com/scanoss/go-grpc-helper/pkg/grpc/gateway"
 	pb "github.com/scanoss/papi/api/semgrepv2"
 	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
 	myconfig "scanoss.com/semgrep/pkg/config"
 )
 
+// HandlerRegistrar registers custom (REST only) handlers on the gateway mux.
+type HandlerRegistrar interface {
+	RegisterHandlers(mux *runtime.ServeMux) error
+}
+
 // RunServer runs REST grpc gateway to forward requests onto the gRPC server.
+// Any REST only endpoints supplied by httpAPI are served directly from the gateway.
 func RunServer(config *myconfig.ServerConfig, ctx context.Context, grpcPort, httpPort string,
-	allowedIPs, deniedIPs []string, startTLS bool) (*http.Server, error) {
+	allowedIPs, deniedIPs []string, startTLS bool, httpAPI HandlerRegistrar) (*http.Server, error) {
 	// configure the gateway for forwarding to gRPC
 	srv, mux, grpcGateway, opts, err := gw.SetupGateway(grpcPort, httpPort, config.TLS.CertFile, config.TLS.CN,
 		allowedIPs, deniedIPs, config.Filtering.BlockByDefault, config.Filtering.TrustProxy,
@@ -38,6 +45,11 @@ func RunServer(config *myconfig.ServerConfig, ctx context.Context, grpcPort, htt
 	if err != nil {
 		return nil, err
 	}
+	if httpAPI != nil {
+		if err = httpAPI.RegisterHandlers(mux); err != nil {
+			return nil, err
+		}
+	}
 	// Open TCP port (in the background) and listen for requests
 	go func() {
 		ctx2, cancel := context.WithCancel(ctx)
@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package risk
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	myconfig "scanoss.com/semgrep/pkg/config"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// Weights holds the weight of a finding of each (normalised) severity.
+type Weights struct {
+	Error   float64
+	Warning float64
+	Info    float64
+}
+
+// DefaultWeights returns the weights used when nothing else is configured.
+func DefaultWeights() Weights {
+	return Weights{Error: 10, Warning: 3, Info: 1}
+}
+
+// FromConfig returns the weights configured on the server (or the defaults if there is no config), validating them.
+func FromConfig(config *myconfig.ServerConfig) (Weights, error) {
+	if config == nil {
+		return DefaultWeights(), nil
+	}
+	w := Weights{Error: config.Risk.ErrorWeight, Warning: config.Risk.WarningWeight, Info: config.Risk.InfoWeight}
+	if err := w.Validate(); err != nil {
+		return Weights{}, err
+	}
+	return w, nil
+}
+
+// Validate checks that none of the weights are negative.
+func (w Weights) Validate() error {
+	for name, weight := range map[string]float64{"error": w.Error, "warning": w.Warning, "info": w.Info} {
+		if weight < 0 || math.IsNaN(weight) {
+			return fmt.Errorf("invalid %v risk weight: %v", name, weight)
+		}
+	}
+	return nil
+}
+
+// weight returns the weight of a finding of the given severity.
+func (w Weights) weight(severity string) float64 {
+	switch dtos.NormaliseSeverity(severity) {
+	case dtos.SeverityError:
+		return w.Error
+	case dtos.SeverityInfo:
+		return w.Info
+	default:
+		return w.Warning
+	}
+}
+
+// Apply scores every component in the output, along with the whole request (updating the output in place).
+// Components without an analysed file count (i.e. from a remote lookup) are normalised by the number of files with findings.
+func (w Weights) Apply(output *dtos.SemgrepOutput) {
+	var total float64
+	totalFiles := 0
+	for i := range output.Purls {
+		item := &output.Purls[i]
+		weighted, files := w.weighted(*item)
+		item.RiskScore = score(weighted, files)
+		total += weighted
+		totalFiles += files
+	}
+	output.RiskScore = score(total, totalFiles)
+}
+
+// Score returns the risk score of a single component (i.e. when streaming components one at a time).
+func (w Weights) Score(item dtos.SemgrepOutputItem) float64 {
+	return score(w.weighted(item))
+}
+
+// weighted returns the weighted (not accepted) findings of a component, along with the number of files they are normalised by.
+func (w Weights) weighted(item dtos.SemgrepOutputItem) (float64, int) {
+	var weighted float64
+	for _, file := range item.Files {
+		for _, issue := range file.Issues {
+			if !issue.Accepted() {
+				weighted += w.weight(issue.Severity)
+			}
+		}
+	}
+	return weighted, max(item.AnalysedFiles, len(item.Files))
+}
+
+// score returns the weighted findings per 100 files (rounded to 2 decimal places).
+func score(weighted float64, files int) float64 {
+	if files == 0 {
+		return 0
+	}
+	return math.Round(weighted*100/float64(files)*100) / 100
+}
+
+// Sort orders the components of the output by descending risk score (components with the same score keep their order).
+func Sort(output *dtos.SemgrepOutput) {
+	sort.SliceStable(output.Purls, func(i, j int) bool {
+		return output.Purls[i].RiskScore > output.Purls[j].RiskScore
+	})
+}
+
+// Filter removes the components with a risk score below the given minimum from the output.
+func Filter(output *dtos.SemgrepOutput, minScore float64) {
+	kept := output.Purls[:0]
+	for _, item := range output.Purls {
+		if item.RiskScore >= minScore {
+			kept = append(kept, item)
+		}
+	}
+	output.Purls = kept
+}
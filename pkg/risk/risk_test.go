@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package risk
+
+import (
+	"testing"
+
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// scoreOutput returns zlib (2 warnings, unknown file count), lodash (1 error, 1 warning, 1 info in 20 files) and left-pad (no findings).
+func scoreOutput() dtos.SemgrepOutput {
+	return dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{
+		{Purl: "pkg:github/madler/zlib", Files: []dtos.SemgrepFileIssues{
+			{Issues: []dtos.IssueItem{{Severity: "WARNING"}, {Severity: "WARNING"}}},
+		}},
+		{Purl: "pkg:npm/lodash", AnalysedFiles: 20, Files: []dtos.SemgrepFileIssues{
+			{Issues: []dtos.IssueItem{{Severity: "ERROR"}, {Severity: "INFO"}}},
+			{Issues: []dtos.IssueItem{{Severity: "WARNING"}}},
+		}},
+		{Purl: "pkg:npm/left-pad", AnalysedFiles: 3},
+	}}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights Weights
+		want    []float64
+		request float64
+	}{
+		{name: "default", weights: DefaultWeights(), want: []float64{600, 70, 0}, request: 83.33},
+		{name: "errors only", weights: Weights{Error: 1}, want: []float64{0, 5, 0}, request: 4.17},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := scoreOutput()
+			tt.weights.Apply(&output)
+			for i, want := range tt.want {
+				if output.Purls[i].RiskScore != want {
+					t.Errorf("Apply() %v score = %v, want %v", output.Purls[i].Purl, output.Purls[i].RiskScore, want)
+				}
+				if score := tt.weights.Score(output.Purls[i]); score != want {
+					t.Errorf("Score() %v = %v, want %v", output.Purls[i].Purl, score, want)
+				}
+			}
+			if output.RiskScore != tt.request {
+				t.Errorf("Apply() request score = %v, want %v", output.RiskScore, tt.request)
+			}
+		})
+	}
+}
+
+func TestApplyAccepted(t *testing.T) {
+	output := scoreOutput()
+	output.Purls[1].Files[0].Issues[0].Suppressed = true
+	output.Purls[1].Files[1].Issues[0].Triage = &dtos.IssueTriage{State: dtos.TriageFalsePositive}
+	DefaultWeights().Apply(&output)
+	if output.Purls[1].RiskScore != 5 {
+		t.Errorf("Apply() score with accepted findings = %v, want 5", output.Purls[1].RiskScore)
+	}
+}
+
+func TestSortFilter(t *testing.T) {
+	output := scoreOutput()
+	DefaultWeights().Apply(&output)
+	output.Purls = append([]dtos.SemgrepOutputItem{output.Purls[2]}, output.Purls[:2]...)
+	Sort(&output)
+	if output.Purls[0].Purl != "pkg:github/madler/zlib" || output.Purls[2].Purl != "pkg:npm/left-pad" {
+		t.Errorf("Sort() = %v, %v, %v", output.Purls[0].Purl, output.Purls[1].Purl, output.Purls[2].Purl)
+	}
+	Filter(&output, 70)
+	if len(output.Purls) != 2 || output.Purls[1].Purl != "pkg:npm/lodash" {
+		t.Errorf("Filter() = %+v", output.Purls)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := DefaultWeights().Validate(); err != nil {
+		t.Errorf("Validate() default weights error = %v", err)
+	}
+	if err := (Weights{Error: 10, Warning: -1}).Validate(); err == nil {
+		t.Errorf("Validate() should reject negative weights")
+	}
+}
@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rules
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// Rule is a single catalog entry: a Semgrep rule ID and its metadata.
+type Rule struct {
+	ID string
+	dtos.RuleMetadata
+}
+
+// stringList is a YAML value that can either be a single string or a list of strings.
+type stringList []string
+
+func (l *stringList) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		*l = stringList{value.Value}
+		return nil
+	case yaml.SequenceNode:
+		var list []string
+		if err := value.Decode(&list); err != nil {
+			return err
+		}
+		*l = list
+		return nil
+	default:
+		return fmt.Errorf("line %d: expected a string or a list of strings", value.Line)
+	}
+}
+
+// ruleFile is the subset of a Semgrep rule file used to build the catalog.
+type ruleFile struct {
+	Rules []struct {
+		ID       string `yaml:"id"`
+		Message  string `yaml:"message"`
+		Metadata struct {
+			Title      string     `yaml:"title"`
+			CWE        stringList `yaml:"cwe"`
+			OWASP      stringList `yaml:"owasp"`
+			Confidence string     `yaml:"confidence"`
+			Likelihood string     `yaml:"likelihood"`
+			Impact     string     `yaml:"impact"`
+			References stringList `yaml:"references"`
+		} `yaml:"metadata"`
+	} `yaml:"rules"`
+}
+
+// Parse parses the contents of a Semgrep rule file. The rule IDs are prefixed with the given prefix (if any).
+func Parse(data []byte, prefix string) ([]Rule, error) {
+	var f ruleFile
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse rule file: %v", err)
+	}
+	rules := make([]Rule, 0, len(f.Rules))
+	for i, r := range f.Rules {
+		id := strings.TrimSpace(r.ID)
+		if len(id) == 0 {
+			return nil, fmt.Errorf("rule %d: an id is required", i+1)
+		}
+		if len(prefix) > 0 {
+			id = prefix + "." + id
+		}
+		title := strings.TrimSpace(r.Metadata.Title)
+		if len(title) == 0 {
+			title = titleFromID(id)
+		}
+		rules = append(rules, Rule{ID: id, RuleMetadata: dtos.RuleMetadata{
+			Title:      title,
+			Message:    strings.TrimSpace(r.Message),
+			CWE:        trimAll(r.Metadata.CWE),
+			OWASP:      trimAll(r.Metadata.OWASP),
+			Confidence: strings.ToUpper(strings.TrimSpace(r.Metadata.Confidence)),
+			Likelihood: strings.ToUpper(strings.TrimSpace(r.Metadata.Likelihood)),
+			Impact:     strings.ToUpper(strings.TrimSpace(r.Metadata.Impact)),
+			References: trimAll(r.Metadata.References),
+		}})
+	}
+	return rules, nil
+}
+
+// Load parses the given Semgrep rule file, or all the rule files (*.yml, *.yaml) under the given directory.
+// When prefixPath is set, each rule ID is prefixed with the dotted path of its file relative to the directory
+// (i.e. python/lang/security/audit/exec.yaml -> python.lang.security.audit.exec.<id>), matching the Semgrep check IDs.
+func Load(path string, prefixPath bool) ([]Rule, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules %v: %v", path, err)
+	}
+	if !info.IsDir() {
+		return loadFile(path, "")
+	}
+	var rules []Rule
+	err = filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := filepath.Ext(file)
+		if d.IsDir() || (ext != ".yml" && ext != ".yaml") || strings.HasSuffix(file, ".test"+ext) {
+			return nil
+		}
+		prefix := ""
+		if prefixPath {
+			rel, _ := filepath.Rel(path, strings.TrimSuffix(file, ext))
+			prefix = strings.ReplaceAll(filepath.ToSlash(rel), "/", ".")
+		}
+		found, err := loadFile(file, prefix)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// loadFile parses a single Semgrep rule file.
+func loadFile(filename, prefix string) ([]Rule, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rule file %v: %v", filename, err)
+	}
+	rules, err := Parse(data, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule file %v: %v", filename, err)
+	}
+	return rules, nil
+}
+
+// titleFromID builds a readable title from the last segment of a rule ID (i.e. dangerous-system-call -> Dangerous system call).
+func titleFromID(id string) string {
+	name := id[strings.LastIndex(id, ".")+1:]
+	name = strings.TrimSpace(strings.NewReplacer("-", " ", "_", " ").Replace(name))
+	if len(name) == 0 {
+		return id
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// trimAll trims all the values, dropping any empty ones.
+func trimAll(values []string) []string {
+	var trimmed []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			trimmed = append(trimmed, v)
+		}
+	}
+	return trimmed
+}
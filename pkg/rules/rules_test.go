@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rules
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		prefix  string
+		want    []Rule
+		wantErr bool
+	}{
+		{name: "empty", data: "", want: []Rule{}},
+		{name: "title from id", data: "rules:\n  - id: useless_assign\n    message: ' Useless assignment '\n", prefix: "js.lang",
+			want: []Rule{{ID: "js.lang.useless_assign"}}},
+		{name: "missing id", data: "rules:\n  - message: no id\n", wantErr: true},
+		{name: "bad cwe", data: "rules:\n  - id: a\n    metadata:\n      cwe: {id: 78}\n", wantErr: true},
+		{name: "bad yaml", data: "rules: [", wantErr: true},
+	}
+	tests[1].want[0].Title = "Useless assign"
+	tests[1].want[0].Message = "Useless assignment"
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.data), tt.prefix)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	rules, err := Load("./tests", true)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("Load() = %+v, want 2 rules", rules)
+	}
+	exec := rules[0]
+	if exec.ID != "python.lang.security.dangerous-system-call.dangerous-system-call" || exec.Title != "Dangerous system call" ||
+		len(exec.CWE) != 1 || len(exec.OWASP) != 2 || exec.Confidence != "MEDIUM" || exec.Impact != "HIGH" || len(exec.References) != 1 {
+		t.Errorf("Load() first rule = %+v", exec)
+	}
+	if sql := rules[1]; sql.Title != "Formatted SQL query" || len(sql.CWE) != 1 || sql.OWASP[0] != "A03:2021 - Injection" {
+		t.Errorf("Load() second rule = %+v", sql)
+	}
+	file := filepath.Join("tests", "python", "lang", "security", "dangerous-system-call.yaml")
+	if rules, err = Load(file, true); err != nil || len(rules) != 2 || rules[0].ID != "dangerous-system-call" {
+		t.Errorf("Load(file) = %+v, %v", rules, err)
+	}
+	if _, err = Load("./tests/missing", false); err == nil {
+		t.Errorf("Load() expected an error for a missing path")
+	}
+}
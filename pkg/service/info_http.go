@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"net/http"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// Version is the build version of the service reported by the service information endpoint (set at startup).
+var Version string
+
+// serviceInfoHTTPResponse is the REST response of the service information endpoint.
+type serviceInfoHTTPResponse struct {
+	dtos.ServiceInfo
+	Status *common.StatusResponse `json:"status"`
+}
+
+// ServiceInfo returns the build version of the service along with the details of the KB snapshot it serves (GET /v2/semgrep/service/info).
+func (c SemgrepHTTPServer) ServiceInfo(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s := httpLogger(r)
+	info := dtos.ServiceInfo{Version: Version, KB: c.semgrepUseCase.KBInfo(requestContext(r), s)}
+	if c.config != nil {
+		info.Name = c.config.App.Name
+	}
+	writeHTTPJSON(w, s, http.StatusOK, serviceInfoHTTPResponse{ServiceInfo: info, Status: httpSuccess()})
+}
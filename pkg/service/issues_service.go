@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	pbx "scanoss.com/semgrep/api/semgrepextv2"
+	myconfig "scanoss.com/semgrep/pkg/config"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+	"scanoss.com/semgrep/pkg/risk"
+	"scanoss.com/semgrep/pkg/usecase"
+)
+
+// omitMetadataKey is the gRPC metadata key (REST header) listing the fields to leave out of the issue responses (see dtos.FieldMask).
+// REST clients of the gateway forwarded endpoints can supply it as the Grpc-Metadata-X-Semgrep-Omit header.
+const omitMetadataKey = "x-semgrep-omit"
+
+// SemgrepIssuesServer implements the gRPC service for issue lookups of large components.
+type SemgrepIssuesServer struct {
+	pbx.UnimplementedSemgrepIssuesServer
+	semgrepUseCase *usecase.SemgrepUseCase // Business logic handler for Semgrep operations
+	batchSize      int                     // Number of components resolved at a time when streaming
+	riskWeights    risk.Weights            // Severity weights used to score the streamed components
+}
+
+// NewSemgrepIssuesServer creates a new instance of the Semgrep Issues Server.
+//
+// Parameters:
+//   - db: Database connection for data operations
+//   - config: Server configuration settings
+//
+// Returns:
+//   - pbx.SemgrepIssuesServer: Initialized gRPC server instance
+//   - error: If the configured risk weights are invalid
+func NewSemgrepIssuesServer(db *sqlx.DB, config *myconfig.ServerConfig) (pbx.SemgrepIssuesServer, error) {
+	weights, err := risk.FromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &SemgrepIssuesServer{semgrepUseCase: usecase.NewSemgrep(db, usecase.StoresFromConfig(db, config)), batchSize: streamBatchSize(config),
+		riskWeights: weights}, nil
+}
+
+// GetComponentIssuesPage returns a page of the files and issues of a single component.
+func (c SemgrepIssuesServer) GetComponentIssuesPage(ctx context.Context, request *pbx.ComponentIssuesPageRequest) (*pbx.ComponentIssuesPageResponse, error) {
+	s := ctxzap.Extract(ctx).Sugar()
+	mask := fieldMaskFromPb(request.GetFieldMask())
+	page, err := c.semgrepUseCase.GetComponentIssuesPage(projectContext(ctx), s,
+		dtos.ComponentDTO{Purl: request.GetPurl(), Requirement: request.GetRequirement()},
+		dtos.PageRequest{Cursor: request.GetCursor(), Limit: int(request.GetLimit()), Mask: mask})
+	if err != nil {
+		return &pbx.ComponentIssuesPageResponse{Status: extStatus(se.HandleServiceError(ctx, s, err))}, nil
+	}
+	return &pbx.ComponentIssuesPageResponse{
+		Component:   componentIssueInfoToPb(page.Component, request.GetRequirement()),
+		TotalFiles:  int32(page.TotalFiles),
+		TotalIssues: int32(page.TotalIssues),
+		NextCursor:  page.NextCursor,
+		Status:      extSuccess(),
+	}, nil
+}
+
+// StreamComponentsIssues streams the issues of a (large) list of components, sending each component as soon as it's resolved
+// and the progress after every batch of components. The last message of the stream is always the status of the request.
+func (c SemgrepIssuesServer) StreamComponentsIssues(request *pbx.ComponentsIssuesStreamRequest, stream grpc.ServerStreamingServer[pbx.ComponentsIssuesStreamResponse]) error {
+	ctx := stream.Context()
+	s := ctxzap.Extract(ctx).Sugar()
+	components := make([]dtos.ComponentDTO, 0, len(request.GetComponents()))
+	for _, c := range request.GetComponents() {
+		components = append(components, dtos.ComponentDTO{Purl: c.GetPurl(), Requirement: c.GetRequirement()})
+	}
+	return streamComponentsIssues(projectContext(ctx), s, c.semgrepUseCase, components, c.batchSize,
+		pbIssueStream{send: stream.Send, weights: c.riskWeights, mask: fieldMaskFromPb(request.GetFieldMask())})
+}
+
+// streamComponentsIssues runs a components issues stream, sending its messages (ending with the request status) to the stream.
+func streamComponentsIssues(ctx context.Context, s *zap.SugaredLogger, semgrepUseCase *usecase.SemgrepUseCase, components []dtos.ComponentDTO,
+	batchSize int, stream pbIssueStream) error {
+	status := extSuccess()
+	if err := semgrepUseCase.StreamIssues(ctx, s, components, batchSize, stream); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err() // the client has gone away, there's no one left to tell
+		}
+		status = extStatus(se.HandleServiceError(ctx, s, err))
+	}
+	return stream.send(&pbx.ComponentsIssuesStreamResponse{Message: &pbx.ComponentsIssuesStreamResponse_Status{Status: status}})
+}
+
+// pbIssueStream sends the messages of a components issues stream in their (extension) protobuf format.
+// Each component is scored before the field mask is applied, so the score covers all its findings.
+type pbIssueStream struct {
+	send    func(*pbx.ComponentsIssuesStreamResponse) error
+	weights risk.Weights
+	mask    dtos.FieldMask
+}
+
+// Component sends a resolved component.
+func (p pbIssueStream) Component(component dtos.ComponentDTO, item dtos.SemgrepOutputItem) error {
+	item.RiskScore = p.weights.Score(item)
+	p.mask.Apply(item.Files)
+	return p.send(&pbx.ComponentsIssuesStreamResponse{Message: &pbx.ComponentsIssuesStreamResponse_Component{
+		Component: componentIssueInfoToPb(item, component.Requirement),
+	}})
+}
+
+// Progress sends a progress update.
+func (p pbIssueStream) Progress(progress dtos.StreamProgress) error {
+	return p.send(&pbx.ComponentsIssuesStreamResponse{Message: &pbx.ComponentsIssuesStreamResponse_Progress{
+		Progress: &pbx.StreamProgress{Resolved: int32(progress.Resolved), Total: int32(progress.Total), Kb: kbInfoToPb(progress.KB)},
+	}})
+}
+
+// streamBatchSize returns the number of components to resolve at a time when streaming (the default if not configured).
+func streamBatchSize(config *myconfig.ServerConfig) int {
+	if config == nil {
+		return usecase.DefaultStreamBatchSize
+	}
+	return config.Stream.BatchSize
+}
+
+// componentIssueInfoToPb converts the files and issues of a component into their (extension) protobuf format.
+func componentIssueInfoToPb(item dtos.SemgrepOutputItem, requirement string) *pbx.ComponentIssueInfo {
+	info := &pbx.ComponentIssueInfo{Purl: item.Purl, Version: item.Version, Requirement: requirement, AnalysedFiles: int32(item.AnalysedFiles),
+		Files: make([]*pbx.FileIssues, 0, len(item.Files))}
+	for _, f := range item.Files {
+		file := &pbx.FileIssues{FileMd5: f.File, Path: f.Path, IssueCount: int32(f.IssueCount), Issues: make([]*pbx.IssueItem, 0, len(f.Issues))}
+		for _, issue := range f.Issues {
+			file.Issues = append(file.Issues, &pbx.IssueItem{RuleId: issue.RuleID, From: issue.From, To: issue.To, Severity: issue.Severity,
+				Fingerprint: issue.Fingerprint, Triage: issueTriageToPb(issue.Triage), Rule: ruleMetadataToPb(issue.Rule)})
+		}
+		info.Files = append(info.Files, file)
+	}
+	if item.Peer != nil {
+		info.Peer = &pbx.PeerRank{Ecosystem: item.Peer.Ecosystem, Density: item.Peer.Density, WorseThan: int32(item.Peer.WorseThan),
+			Components: int32(item.Peer.Components), Summary: item.Peer.Summary}
+	}
+	if item.Provenance != nil {
+		info.Provenance = &pbx.Provenance{Source: item.Provenance.Source, ScanDate: item.Provenance.ScanDate,
+			EngineVersion: item.Provenance.EngineVersion, RulesetVersion: item.Provenance.RulesetVersion}
+	}
+	if item.Presence != nil {
+		info.Presence = &pbx.Presence{Files: int32(item.Presence.Files), Percent: item.Presence.Percent}
+	}
+	info.RiskScore = item.RiskScore
+	return info
+}
+
+// kbInfoToPb converts the KB snapshot details (if known) into their (extension) protobuf format.
+func kbInfoToPb(kb *dtos.KBInfo) *pbx.KBInfo {
+	if kb == nil {
+		return nil
+	}
+	return &pbx.KBInfo{Version: kb.Version, SnapshotDate: kb.SnapshotDate, EngineVersion: kb.EngineVersion, RulesetVersion: kb.RulesetVersion}
+}
+
+// issueTriageToPb converts the triage decision of a finding (if any) into its (extension) protobuf format.
+func issueTriageToPb(triage *dtos.IssueTriage) *pbx.IssueTriage {
+	if triage == nil {
+		return nil
+	}
+	return &pbx.IssueTriage{Id: triage.ID, State: triage.State, Project: triage.Project, Author: triage.Author,
+		Comment: triage.Comment, UpdatedAt: triage.UpdatedAt}
+}
+
+// ruleMetadataToPb converts the rule catalog details of a finding (if any) into their (extension) protobuf format.
+func ruleMetadataToPb(rule *dtos.RuleMetadata) *pbx.RuleMetadata {
+	if rule == nil {
+		return nil
+	}
+	return &pbx.RuleMetadata{Title: rule.Title, Message: rule.Message, Cwe: rule.CWE, Owasp: rule.OWASP, Confidence: rule.Confidence,
+		Likelihood: rule.Likelihood, Impact: rule.Impact, References: rule.References}
+}
+
+// fieldMaskFromPb converts a protobuf field mask into the internal DTO format.
+func fieldMaskFromPb(mask *pbx.FieldMask) dtos.FieldMask {
+	return dtos.FieldMask{OmitPaths: mask.GetOmitPaths(), OmitIssues: mask.GetOmitIssues()}
+}
+
+// fieldMaskFromContext returns the field mask supplied in the request metadata (if any).
+func fieldMaskFromContext(ctx context.Context) (dtos.FieldMask, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return dtos.FieldMask{}, nil
+	}
+	return parseFieldMask(md.Get(omitMetadataKey))
+}
+
+// parseFieldMask parses a list of fields to omit, reporting invalid fields as a bad request.
+func parseFieldMask(omit []string) (dtos.FieldMask, error) {
+	mask, err := dtos.ParseFieldMask(omit)
+	if err != nil {
+		return dtos.FieldMask{}, se.NewBadRequestError("Request validation failed: invalid field mask", err)
+	}
+	return mask, nil
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"go.uber.org/zap"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+	"scanoss.com/semgrep/pkg/outputs"
+)
+
+// nextCursorHeader is the REST header holding the cursor of the next page of job results (see JobUseCase.Results).
+const nextCursorHeader = "X-Semgrep-Next-Cursor"
+
+// jobHTTPResponse is the REST response of a single batch job.
+type jobHTTPResponse struct {
+	Job    dtos.Job               `json:"job"`
+	Status *common.StatusResponse `json:"status"`
+}
+
+// CreateJob queues a batch job looking up the issues of the components in the request (POST /v2/semgrep/jobs).
+// The body is a components request (same as POST /v2/semgrep/issues/components), scoped to the X-Scanoss-Project header (if any).
+func (c SemgrepHTTPServer) CreateJob(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s := httpLogger(r)
+	body, err := readRequestBody(w, r)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	var request common.ComponentsRequest
+	if err = json.Unmarshal(body, &request); err != nil {
+		writeHTTPError(w, s, se.NewBadRequestError("Request validation failed: invalid components request", err))
+		return
+	}
+	components, err := componentsToComponentsDTO(&request)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	job, err := c.jobs.Create(requestContext(r), s, components)
+	writeJobResponse(w, s, http.StatusAccepted, job, err)
+}
+
+// GetJob retrieves the status and progress of a batch job (GET /v2/semgrep/jobs/{id}).
+func (c SemgrepHTTPServer) GetJob(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s := httpLogger(r)
+	job, err := c.jobs.Get(requestContext(r), s, params["id"])
+	writeJobResponse(w, s, http.StatusOK, job, err)
+}
+
+// CancelJob cancels a queued or running batch job (POST /v2/semgrep/jobs/{id}/cancel).
+func (c SemgrepHTTPServer) CancelJob(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s := httpLogger(r)
+	job, err := c.jobs.Cancel(requestContext(r), s, params["id"])
+	writeJobResponse(w, s, http.StatusOK, job, err)
+}
+
+// GetJobResults returns a page of the results of a batch job in the requested report format (GET /v2/semgrep/jobs/{id}/results).
+// The format is selected as for the export endpoint, and the page using the 'cursor' and 'limit' (components) query parameters.
+// The cursor of the next page is returned in the X-Semgrep-Next-Cursor header.
+// Fields listed in the X-Semgrep-Omit header (paths, issues) are left out of the output.
+func (c SemgrepHTTPServer) GetJobResults(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s := httpLogger(r)
+	format, err := requestedFormat(r)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	mask, err := requestFieldMask(r)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	query := r.URL.Query()
+	limit := 0
+	if value := query.Get("limit"); len(value) > 0 {
+		if limit, err = strconv.Atoi(value); err != nil {
+			writeHTTPError(w, s, se.NewBadRequestError("Request validation failed: invalid limit '"+value+"'", err))
+			return
+		}
+	}
+	output, next, err := c.jobs.Results(requestContext(r), s, params["id"], query.Get("cursor"), limit)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	c.riskWeights.Apply(&output)
+	mask.ApplyOutput(output)
+	data, err := outputs.Export(format, output)
+	if err != nil {
+		writeHTTPError(w, s, se.NewInternalError("Problem exporting Semgrep output", err))
+		return
+	}
+	if len(next) > 0 {
+		w.Header().Set(nextCursorHeader, next)
+	}
+	writeHTTPData(w, s, outputs.ContentType(format), data)
+}
+
+// writeJobResponse writes a single batch job (or the error) to the response.
+func writeJobResponse(w http.ResponseWriter, s *zap.SugaredLogger, code int, job dtos.Job, err error) {
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	writeHTTPJSON(w, s, code, jobHTTPResponse{Job: job, Status: httpSuccess()})
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+)
+
+// componentIssuesPageHTTPResponse is the REST response of a page of the files and issues of a component.
+type componentIssuesPageHTTPResponse struct {
+	dtos.ComponentIssuesPage
+	Status *common.StatusResponse `json:"status"`
+}
+
+// ComponentIssuesPage returns a page of the files and issues of a single component (POST /v2/semgrep/issues/component/page).
+// The body holds the component (purl and optional requirement), the cursor and limit of the page,
+// and an optional list of fields to 'omit' (paths, issues), also accepted in the X-Semgrep-Omit header.
+func (c SemgrepHTTPServer) ComponentIssuesPage(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s := httpLogger(r)
+	body, err := readRequestBody(w, r)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	var request struct {
+		dtos.ComponentDTO
+		dtos.PageRequest
+		Omit []string `json:"omit"`
+	}
+	if err = json.Unmarshal(body, &request); err != nil {
+		writeHTTPError(w, s, se.NewBadRequestError("Request validation failed: invalid component page request", err))
+		return
+	}
+	if len(request.Purl) == 0 {
+		writeHTTPError(w, s, se.NewBadRequestError("Request validation failed: no purl supplied", nil))
+		return
+	}
+	if request.Mask, err = parseFieldMask(append(request.Omit, r.Header.Values(omitMetadataKey)...)); err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	page, err := c.semgrepUseCase.GetComponentIssuesPage(requestContext(r), s, request.ComponentDTO, request.PageRequest)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	writeHTTPJSON(w, s, http.StatusOK, componentIssuesPageHTTPResponse{ComponentIssuesPage: page, Status: httpSuccess()})
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"fmt"
+	"net/http"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+	"scanoss.com/semgrep/pkg/policy"
+)
+
+// policyHTTPResponse is the REST response of a policy evaluation.
+type policyHTTPResponse struct {
+	policy.Result
+	KB     *dtos.KBInfo           `json:"kb,omitempty"` // KB snapshot the findings were read from (if known)
+	Status *common.StatusResponse `json:"status"`
+}
+
+// policyRejectedParams are the export query parameters that would filter findings out of a policy evaluation.
+var policyRejectedParams = []string{"sort", "min_risk", "cwe", "owasp", "group_by"}
+
+// EvaluatePolicy takes a components request (same body as the export endpoint), looks up the Semgrep issues and
+// evaluates them against the configured acceptance policy (POST /v2/semgrep/policy/evaluate).
+// It returns a pass/warn/fail verdict for the request and each component, along with the rules that triggered them.
+// The policy sees every finding left after the suppressions, so the export filters (risk, category and baseline) are rejected.
+func (c SemgrepHTTPServer) EvaluatePolicy(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s := httpLogger(r)
+	query := r.URL.Query()
+	for _, param := range policyRejectedParams {
+		if query.Has(param) {
+			writeHTTPError(w, s, se.NewBadRequestError(fmt.Sprintf("The '%v' parameter is not supported when evaluating the policy", param), nil))
+			return
+		}
+	}
+	request, err := readComponentsRequest(w, r)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	if request.baseline != nil {
+		writeHTTPError(w, s, se.NewBadRequestError("A baseline is not supported when evaluating the policy", nil))
+		return
+	}
+	output, err := c.lookupScoredIssues(r, s, request)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	result := c.policy.Evaluate(output)
+	s.Debugf("Policy verdict: %v (%v components)", result.Verdict, len(result.Components))
+	writeHTTPJSON(w, s, http.StatusOK, policyHTTPResponse{Result: result, KB: output.KB, Status: httpSuccess()})
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+)
+
+// ruleSearchHTTPResponse is the REST response of a reverse rule lookup.
+type ruleSearchHTTPResponse struct {
+	dtos.RuleSearchOutput
+	Status *common.StatusResponse `json:"status"`
+}
+
+// SearchRuleComponents returns a page of the component versions with findings of a rule (GET/POST /v2/semgrep/rules/components).
+// GET takes the rule_id, cursor and limit query parameters, along with any number of purl parameters to restrict the search to.
+// POST takes the same search as a JSON body, with the components in the same format as a components request.
+func (c SemgrepHTTPServer) SearchRuleComponents(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s := httpLogger(r)
+	search, err := readRuleSearch(w, r)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	output, err := c.ruleIndex.Search(requestContext(r), s, search)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	writeHTTPJSON(w, s, http.StatusOK, ruleSearchHTTPResponse{RuleSearchOutput: output, Status: httpSuccess()})
+}
+
+// readRuleSearch reads a reverse rule lookup from the query parameters (GET) or the request body (POST).
+func readRuleSearch(w http.ResponseWriter, r *http.Request) (dtos.RuleSearch, error) {
+	var search dtos.RuleSearch
+	if r.Method != http.MethodGet {
+		body, err := readRequestBody(w, r)
+		if err != nil {
+			return dtos.RuleSearch{}, err
+		}
+		if err = json.Unmarshal(body, &search); err != nil {
+			return dtos.RuleSearch{}, se.NewBadRequestError("Request validation failed: invalid rule search request", err)
+		}
+		return search, nil
+	}
+	query := r.URL.Query()
+	search.RuleID = query.Get("rule_id")
+	search.Cursor = query.Get("cursor")
+	if limit := query.Get("limit"); len(limit) > 0 {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			return dtos.RuleSearch{}, se.NewBadRequestError("Request validation failed: invalid limit '"+limit+"'", err)
+		}
+		search.Limit = value
+	}
+	for _, purl := range query["purl"] {
+		search.Components = append(search.Components, dtos.ComponentDTO{Purl: purl})
+	}
+	return search, nil
+}
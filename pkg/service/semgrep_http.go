@@ -0,0 +1,485 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/jmoiron/sqlx"
+	common "github.com/scanoss/papi/api/commonv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.uber.org/zap"
+	"scanoss.com/semgrep/pkg/baseline"
+	myconfig "scanoss.com/semgrep/pkg/config"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+	"scanoss.com/semgrep/pkg/ignore"
+	"scanoss.com/semgrep/pkg/inputs"
+	"scanoss.com/semgrep/pkg/outputs"
+	"scanoss.com/semgrep/pkg/policy"
+	"scanoss.com/semgrep/pkg/risk"
+	"scanoss.com/semgrep/pkg/taxonomy"
+	"scanoss.com/semgrep/pkg/usecase"
+)
+
+const maxHTTPRequestSize = 100 * 1024 * 1024 // Largest REST request body accepted (100MB)
+
+// SemgrepHTTPServer implements the REST only endpoints of the Semgrep service.
+// These endpoints have payloads that are not described by the SCANOSS papi definitions,
+// so they are registered directly on the REST gateway rather than forwarded to gRPC.
+type SemgrepHTTPServer struct {
+	config         *myconfig.ServerConfig    // Server configuration settings
+	semgrepUseCase *usecase.SemgrepUseCase   // Business logic handler for Semgrep operations
+	triageUseCase  *usecase.TriageUseCase    // Business logic handler for triage operations (nil if disabled)
+	ruleIndex      *usecase.RuleIndexUseCase // Reverse rule lookups (nil if disabled)
+	jobs           *usecase.JobUseCase       // Asynchronous batch jobs (nil if disabled)
+	policy         *policy.Policy            // Component acceptance policy (nil if not configured)
+	riskWeights    risk.Weights              // Severity weights used to score the component risk
+}
+
+// httpStatusResponse is the status block returned by the REST only endpoints (same shape as the gateway).
+type httpStatusResponse struct {
+	Status *common.StatusResponse `json:"status"`
+}
+
+// NewSemgrepHTTPServer creates a new instance of the Semgrep REST only endpoints.
+//
+// Parameters:
+//   - db: Database connection for data operations
+//   - config: Server configuration settings
+//
+// Returns:
+//   - *SemgrepHTTPServer: Initialized REST handler set
+//   - error: If the configured acceptance policy or risk weights are invalid
+func NewSemgrepHTTPServer(db *sqlx.DB, config *myconfig.ServerConfig) (*SemgrepHTTPServer, error) {
+	weights, err := risk.FromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	server := &SemgrepHTTPServer{
+		config:         config,
+		semgrepUseCase: usecase.NewSemgrep(db, usecase.StoresFromConfig(db, config)),
+		riskWeights:    weights,
+	}
+	if config != nil && config.Triage.Enabled {
+		server.triageUseCase = usecase.NewTriage(db)
+	}
+	if config != nil && config.RuleIndex.Enabled {
+		server.ruleIndex = usecase.NewRuleIndex(db)
+	}
+	if config != nil && config.Jobs.Enabled {
+		if server.jobs, err = usecase.NewJobs(db, config); err != nil {
+			return nil, err
+		}
+	}
+	if config != nil && len(config.Policy.File) > 0 {
+		p, err := policy.Load(config.Policy.File)
+		if err != nil {
+			return nil, err
+		}
+		zlog.S.Infof("Loaded acceptance policy %v with %v rule(s)", config.Policy.File, len(p.Rules))
+		server.policy = p
+	}
+	return server, nil
+}
+
+// RegisterHandlers adds all the REST only endpoints to the supplied gateway mux.
+func (c SemgrepHTTPServer) RegisterHandlers(mux *runtime.ServeMux) error {
+	handlers := []struct {
+		method  string
+		path    string
+		handler runtime.HandlerFunc
+	}{
+		{http.MethodPost, "/v2/semgrep/issues/scanoss", c.ScanossResultsIssues},
+		{http.MethodPost, "/v2/semgrep/issues/components/export", c.ExportComponentsIssues},
+		{http.MethodPost, "/v2/semgrep/issues/files", c.FilesIssues},
+		{http.MethodPost, "/v2/semgrep/issues/component/page", c.ComponentIssuesPage},
+		{http.MethodPost, "/v2/semgrep/issues/components/stream", c.StreamComponentsIssues},
+		{http.MethodGet, "/v2/semgrep/service/info", c.ServiceInfo},
+	}
+	if c.policy != nil {
+		handlers = append(handlers, struct {
+			method  string
+			path    string
+			handler runtime.HandlerFunc
+		}{http.MethodPost, "/v2/semgrep/policy/evaluate", c.EvaluatePolicy})
+	}
+	if c.triageUseCase != nil {
+		handlers = append(handlers, []struct {
+			method  string
+			path    string
+			handler runtime.HandlerFunc
+		}{
+			{http.MethodPost, "/v2/semgrep/triage", c.CreateTriageDecision},
+			{http.MethodGet, "/v2/semgrep/triage", c.ListTriageDecisions},
+			{http.MethodGet, "/v2/semgrep/triage/{id}", c.GetTriageDecision},
+			{http.MethodPut, "/v2/semgrep/triage/{id}", c.UpdateTriageDecision},
+			{http.MethodDelete, "/v2/semgrep/triage/{id}", c.DeleteTriageDecision},
+		}...)
+	}
+	if c.ruleIndex != nil {
+		handlers = append(handlers, []struct {
+			method  string
+			path    string
+			handler runtime.HandlerFunc
+		}{
+			{http.MethodGet, "/v2/semgrep/rules/components", c.SearchRuleComponents},
+			{http.MethodPost, "/v2/semgrep/rules/components", c.SearchRuleComponents},
+		}...)
+	}
+	if c.jobs != nil {
+		handlers = append(handlers, []struct {
+			method  string
+			path    string
+			handler runtime.HandlerFunc
+		}{
+			{http.MethodPost, "/v2/semgrep/jobs", c.CreateJob},
+			{http.MethodGet, "/v2/semgrep/jobs/{id}", c.GetJob},
+			{http.MethodGet, "/v2/semgrep/jobs/{id}/results", c.GetJobResults},
+			{http.MethodPost, "/v2/semgrep/jobs/{id}/cancel", c.CancelJob},
+		}...)
+	}
+	for _, h := range handlers {
+		if err := mux.HandlePath(h.method, h.path, h.handler); err != nil {
+			return fmt.Errorf("failed to register REST handler %v %v: %v", h.method, h.path, err)
+		}
+	}
+	return nil
+}
+
+// ScanossResultsIssues takes a SCANOSS scan result (scanoss.py JSON), looks up the Semgrep issues of all
+// matched components and returns the same scan result with each matched file annotated with its findings.
+func (c SemgrepHTTPServer) ScanossResultsIssues(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s := httpLogger(r)
+	body, err := readRequestBody(w, r)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	results, err := inputs.ParseScanossResults(body)
+	if err != nil {
+		writeHTTPError(w, s, se.NewBadRequestError("Request validation failed: invalid SCANOSS scan results", err))
+		return
+	}
+	annotated, err := c.semgrepUseCase.AnnotateScanossResults(requestContext(r), s, results)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	writeHTTPJSON(w, s, http.StatusOK, annotated)
+}
+
+// fileIssuesHTTPResponse is the REST response of a file MD5 lookup.
+type fileIssuesHTTPResponse struct {
+	dtos.FileIssuesOutput
+	Status *common.StatusResponse `json:"status"`
+}
+
+// FilesIssues takes a list of file MD5s (each with an optional URL hash used for the path lookup) and returns their Semgrep issues
+// and paths directly, without resolving any purls (i.e. for the files already identified by SCANOSS file and snippet matches).
+// Fields listed in the X-Semgrep-Omit header (paths, issues) are left out of the response.
+func (c SemgrepHTTPServer) FilesIssues(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s := httpLogger(r)
+	mask, err := requestFieldMask(r)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	body, err := readRequestBody(w, r)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	var request struct {
+		Files []dtos.FileQuery `json:"files"`
+	}
+	if err = json.Unmarshal(body, &request); err != nil {
+		writeHTTPError(w, s, se.NewBadRequestError("Request validation failed: invalid files request", err))
+		return
+	}
+	output, err := c.semgrepUseCase.GetFileIssues(requestContext(r), s, request.Files)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	mask.Apply(output.Files)
+	writeHTTPJSON(w, s, http.StatusOK, fileIssuesHTTPResponse{FileIssuesOutput: output, Status: httpSuccess()})
+}
+
+// ExportComponentsIssues takes a components request (same body as POST /v2/semgrep/issues/components),
+// looks up the Semgrep issues and returns them in the requested report format.
+// The format is selected using the 'format' query parameter or, failing that, the Accept header (default JSON).
+// The body may also contain a list of 'suppressions' (same entries as an ignore file) to mark accepted findings,
+// and a 'baseline' (previous SemgrepOutput or SARIF log) in which case only the findings not in it are returned.
+// An 'inventory' of file MD5s restricts the findings to the component files present in the caller's repository.
+// Fields listed in the X-Semgrep-Omit header (paths, issues) are left out of the output.
+func (c SemgrepHTTPServer) ExportComponentsIssues(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s := httpLogger(r)
+	format, err := requestedFormat(r)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	mask, err := requestFieldMask(r)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	output, err := c.lookupRequestIssues(w, r, s)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	mask.ApplyOutput(output)
+	data, err := outputs.Export(format, output)
+	if err != nil {
+		writeHTTPError(w, s, se.NewInternalError("Problem exporting Semgrep output", err))
+		return
+	}
+	writeHTTPData(w, s, outputs.ContentType(format), data)
+}
+
+// lookupRequestIssues reads a components request from the REST body and looks up the Semgrep issues,
+// applying any suppressions, baseline and file inventory supplied in the same body, and scoring the component risk.
+// The components can be sorted by risk score ('sort=risk') and filtered by a minimum score ('min_risk') using query parameters.
+// Findings can also be filtered by CWE ('cwe') and OWASP category ('owasp') and grouped by either ('group_by') if the rule catalog is enabled.
+func (c SemgrepHTTPServer) lookupRequestIssues(w http.ResponseWriter, r *http.Request, s *zap.SugaredLogger) (dtos.SemgrepOutput, error) {
+	sortByRisk, minRisk, err := riskOptions(r)
+	if err != nil {
+		return dtos.SemgrepOutput{}, err
+	}
+	categories, groupBy, err := categoryOptions(r)
+	if err != nil {
+		return dtos.SemgrepOutput{}, err
+	}
+	if (!categories.Empty() || len(groupBy) > 0) && (c.config == nil || !c.config.RuleCatalog.Enabled) {
+		return dtos.SemgrepOutput{}, se.NewBadRequestError("Filtering or grouping by CWE/OWASP category requires the rule catalog to be enabled", nil)
+	}
+	request, err := readComponentsRequest(w, r)
+	if err != nil {
+		return dtos.SemgrepOutput{}, err
+	}
+	output, err := c.lookupScoredIssues(r, s, request)
+	if err != nil {
+		return dtos.SemgrepOutput{}, err
+	}
+	if request.baseline != nil {
+		summary := request.baseline.Compare(&output)
+		s.Debugf("Baseline comparison: %v new, %v unchanged, %v fixed findings", summary.New, summary.Unchanged, summary.Fixed)
+	}
+	if !categories.Empty() || len(groupBy) > 0 {
+		// Don't report a clean (or uncategorised) result when the rule metadata couldn't be attached
+		if err = taxonomy.CheckRuleMetadata(output); err != nil {
+			return dtos.SemgrepOutput{}, se.NewInternalError("Rule metadata is not available to filter or group findings by category", err)
+		}
+	}
+	if removed := categories.Apply(&output); removed > 0 {
+		s.Debugf("Removed %v findings outside the requested categories", removed)
+	}
+	if minRisk > 0 {
+		risk.Filter(&output, minRisk)
+	}
+	if sortByRisk {
+		risk.Sort(&output)
+	}
+	output.Groups, _ = taxonomy.Group(output, groupBy) // grouping already validated
+	return output, nil
+}
+
+// lookupScoredIssues looks up the Semgrep issues of a components request (restricted to its file inventory, if any),
+// applying its suppressions and scoring the component risk.
+func (c SemgrepHTTPServer) lookupScoredIssues(r *http.Request, s *zap.SugaredLogger, request exportRequest) (dtos.SemgrepOutput, error) {
+	output, err := c.semgrepUseCase.GetIssuesInInventory(requestContext(r), s, request.components, request.inventory)
+	if err != nil {
+		return dtos.SemgrepOutput{}, err
+	}
+	if summary := request.suppressions.Apply(output, time.Now()); summary.Suppressed > 0 || summary.Expired > 0 {
+		s.Debugf("Suppressed %v findings (%v matched expired suppressions)", summary.Suppressed, summary.Expired)
+	}
+	c.riskWeights.Apply(&output)
+	return output, nil
+}
+
+// categoryOptions reads the CWE/OWASP category filter ('cwe' & 'owasp', repeated or comma separated) and grouping ('group_by')
+// query parameters of a REST request.
+func categoryOptions(r *http.Request) (taxonomy.Filter, string, error) {
+	query := r.URL.Query()
+	filter, err := taxonomy.ParseFilter(query["cwe"], query["owasp"])
+	if err != nil {
+		return taxonomy.Filter{}, "", se.NewBadRequestError(err.Error(), err)
+	}
+	groupBy := strings.ToLower(query.Get("group_by"))
+	if err = taxonomy.ValidateGroupBy(groupBy); err != nil {
+		return taxonomy.Filter{}, "", se.NewBadRequestError(err.Error(), err)
+	}
+	return filter, groupBy, nil
+}
+
+// riskOptions reads the risk score sort and filter query parameters of a REST request.
+func riskOptions(r *http.Request) (bool, float64, error) {
+	query := r.URL.Query()
+	sortBy := query.Get("sort")
+	if len(sortBy) > 0 && sortBy != "risk" {
+		return false, 0, se.NewBadRequestError(fmt.Sprintf("Unsupported sort order '%v'. Supported: risk", sortBy), nil)
+	}
+	var minRisk float64
+	if value := query.Get("min_risk"); len(value) > 0 {
+		var err error
+		if minRisk, err = strconv.ParseFloat(value, 64); err != nil || minRisk < 0 {
+			return false, 0, se.NewBadRequestError(fmt.Sprintf("Invalid min_risk '%v'", value), err)
+		}
+	}
+	return len(sortBy) > 0, minRisk, nil
+}
+
+// requestedFormat determines the output format requested by the client (query parameter or Accept header).
+func requestedFormat(r *http.Request) (string, error) {
+	if format := r.URL.Query().Get("format"); len(format) > 0 {
+		if !outputs.IsSupported(format) {
+			return "", se.NewBadRequestError(fmt.Sprintf("Unsupported output format '%v'. Supported formats: %v",
+				format, strings.Join(outputs.Formats(), ", ")), nil)
+		}
+		return strings.ToLower(format), nil
+	}
+	if format, ok := outputs.FormatFromAccept(r.Header.Get("Accept")); ok {
+		return format, nil
+	}
+	return outputs.FormatJSON, nil
+}
+
+// exportRequest is a components request along with the optional extras accepted by the export endpoint.
+type exportRequest struct {
+	components   []dtos.ComponentDTO
+	suppressions *ignore.File
+	baseline     *baseline.Baseline // nil if no baseline was supplied
+	inventory    usecase.Inventory  // nil if no file inventory was supplied
+}
+
+// readComponentsRequest reads a components request from the REST body and converts it to the internal DTO format,
+// along with any (optional) suppressions, baseline and file inventory supplied in the same body.
+func readComponentsRequest(w http.ResponseWriter, r *http.Request) (exportRequest, error) {
+	body, err := readRequestBody(w, r)
+	if err != nil {
+		return exportRequest{}, err
+	}
+	var request common.ComponentsRequest
+	if err = json.Unmarshal(body, &request); err != nil {
+		return exportRequest{}, se.NewBadRequestError("Request validation failed: invalid components request", err)
+	}
+	var suppressions ignore.File
+	if err = json.Unmarshal(body, &suppressions); err != nil {
+		return exportRequest{}, se.NewBadRequestError("Request validation failed: invalid suppressions", err)
+	}
+	if err = suppressions.Compile(); err != nil {
+		return exportRequest{}, se.NewBadRequestError(fmt.Sprintf("Request validation failed: %v", err), err)
+	}
+	var extras struct {
+		Baseline  json.RawMessage `json:"baseline"`
+		Inventory []string        `json:"inventory"`
+	}
+	if err = json.Unmarshal(body, &extras); err != nil {
+		return exportRequest{}, se.NewBadRequestError("Request validation failed: invalid baseline", err)
+	}
+	export := exportRequest{suppressions: &suppressions}
+	if len(extras.Baseline) > 0 && string(extras.Baseline) != "null" {
+		if export.baseline, err = baseline.Parse(extras.Baseline); err != nil {
+			return exportRequest{}, se.NewBadRequestError(fmt.Sprintf("Request validation failed: %v", err), err)
+		}
+	}
+	if extras.Inventory != nil {
+		if export.inventory, err = usecase.NewInventory(extras.Inventory); err != nil {
+			return exportRequest{}, err
+		}
+	}
+	export.components, err = componentsToComponentsDTO(&request)
+	return export, err
+}
+
+// httpLogger returns a logger decorated with the details of the incoming REST request.
+func httpLogger(r *http.Request) *zap.SugaredLogger {
+	return zlog.S.With("method", r.Method, "path", r.URL.Path)
+}
+
+// requestContext returns the request context, scoped to the project supplied in the X-Scanoss-Project header (if any).
+func requestContext(r *http.Request) context.Context {
+	if project := r.Header.Get(projectMetadataKey); len(project) > 0 {
+		return usecase.ContextWithProject(r.Context(), project)
+	}
+	return r.Context()
+}
+
+// requestFieldMask returns the field mask supplied in the X-Semgrep-Omit header(s) of the request (if any).
+func requestFieldMask(r *http.Request) (dtos.FieldMask, error) {
+	return parseFieldMask(r.Header.Values(omitMetadataKey))
+}
+
+// readRequestBody reads the full (size limited) body of a REST request.
+func readRequestBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxHTTPRequestSize))
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return nil, se.NewBadRequestError(fmt.Sprintf("Request body exceeds %v bytes", maxErr.Limit), err)
+		}
+		return nil, se.NewBadRequestError("Failed to read request body", err)
+	}
+	if len(body) == 0 {
+		return nil, se.NewBadRequestError("Request validation failed: request body is empty", nil)
+	}
+	return body, nil
+}
+
+// writeHTTPJSON writes the supplied data to the response as JSON.
+func writeHTTPJSON(w http.ResponseWriter, s *zap.SugaredLogger, code int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		s.Errorf("Failed to write REST response: %v", err)
+	}
+}
+
+// writeHTTPData writes the supplied (already encoded) data to the response.
+func writeHTTPData(w http.ResponseWriter, s *zap.SugaredLogger, contentType string, data []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		s.Errorf("Failed to write REST response: %v", err)
+	}
+}
+
+// writeHTTPError converts an error into a REST status response with the appropriate HTTP code.
+func writeHTTPError(w http.ResponseWriter, s *zap.SugaredLogger, err error) {
+	code := http.StatusInternalServerError
+	status := &common.StatusResponse{Status: common.StatusCode_FAILED, Message: "internal server error"}
+	if serviceErr, ok := se.GetServiceError(err); ok {
+		code = serviceErr.GetHTTPCode()
+		status.Message = serviceErr.Message
+		s.Errorw("service error", "error", serviceErr.Error(), "http_code", code)
+	} else {
+		s.Errorw("unhandled error", "error", err.Error())
+	}
+	writeHTTPJSON(w, s, code, httpStatusResponse{Status: status})
+}
@@ -0,0 +1,557 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	myconfig "scanoss.com/semgrep/pkg/config"
+	"scanoss.com/semgrep/pkg/models"
+	"scanoss.com/semgrep/pkg/usecase"
+)
+
+func TestReadComponentsRequest(t *testing.T) {
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	tests := []struct {
+		name         string
+		body         string
+		wantErr      bool
+		wantBaseline int
+	}{
+		{name: "components only", body: `{"components":[{"purl":"pkg:npm/lodash"}]}`},
+		{name: "output baseline", body: `{"components":[{"purl":"pkg:npm/lodash"}],"baseline":{"purls":[{"purl":"pkg:npm/lodash",` +
+			`"version":"4.17.21","files":[{"fileMD5":"abc123","path":"lodash.js","issues":[{"ruleID":"js.rule","from":"1","to":"2"}]}]}]}}`,
+			wantBaseline: 1},
+		{name: "sarif baseline", body: `{"components":[{"purl":"pkg:npm/lodash"}],"baseline":{"runs":[{"results":[` +
+			`{"ruleId":"js.rule","partialFingerprints":{"scanossFingerprint/v1":"0123abcd"}}]}]}}`, wantBaseline: 1},
+		{name: "null baseline", body: `{"components":[{"purl":"pkg:npm/lodash"}],"baseline":null}`},
+		{name: "invalid baseline", body: `{"components":[{"purl":"pkg:npm/lodash"}],"baseline":{"findings":[]}}`, wantErr: true},
+		{name: "inventory", body: `{"components":[{"purl":"pkg:npm/lodash"}],"inventory":["4d66775f503b1e76582e7e5b2ea54d92"]}`},
+		{name: "invalid inventory", body: `{"components":[{"purl":"pkg:npm/lodash"}],"inventory":["lodash.js"]}`, wantErr: true},
+		{name: "invalid suppressions", body: `{"components":[{"purl":"pkg:npm/lodash"}],"suppressions":[{"purl":"pkg:npm/lodash"}]}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/v2/semgrep/issues/components/export", strings.NewReader(tt.body))
+			request, err := readComponentsRequest(httptest.NewRecorder(), r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readComponentsRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (len(request.components) != 1 || request.baseline.Len() != tt.wantBaseline) {
+				t.Errorf("readComponentsRequest() = %+v", request)
+			}
+		})
+	}
+}
+
+func TestPolicyHandler(t *testing.T) {
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	evaluate := func(query, body string) *httptest.ResponseRecorder {
+		server, err := NewSemgrepHTTPServer(nil, cfg)
+		if err != nil {
+			t.Fatalf("NewSemgrepHTTPServer() error = %v", err)
+		}
+		mux := runtime.NewServeMux()
+		if err = server.RegisterHandlers(mux); err != nil {
+			t.Fatalf("RegisterHandlers() error = %v", err)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v2/semgrep/policy/evaluate"+query, strings.NewReader(body)))
+		return rec
+	}
+	if rec := evaluate("", `{"components":[]}`); rec.Code != http.StatusNotFound {
+		t.Errorf("POST policy/evaluate without a policy = %v, want %v", rec.Code, http.StatusNotFound)
+	}
+	cfg.Policy.File = "../policy/tests/policy.yml"
+	if rec := evaluate("", `{"components":[]}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("POST policy/evaluate without components = %v, want %v", rec.Code, http.StatusBadRequest)
+	}
+	// Findings can't be filtered out of the evaluation
+	components := `{"components":[{"purl":"pkg:npm/lodash"}]`
+	for _, tt := range []struct{ query, body, want string }{
+		{query: "?min_risk=999", body: components + "}", want: "'min_risk'"},
+		{query: "?cwe=CWE-78", body: components + "}", want: "'cwe'"},
+		{query: "?sort=risk", body: components + "}", want: "'sort'"},
+		{body: components + `,"baseline":{"purls":[]}}`, want: "A baseline is not supported"},
+	} {
+		if rec := evaluate(tt.query, tt.body); rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), tt.want) {
+			t.Errorf("POST policy/evaluate%v %v = %v, want %v (%v)", tt.query, tt.body, rec.Code, http.StatusBadRequest, rec.Body.String())
+		}
+	}
+	cfg.Policy.File = "../policy/tests/missing.yml"
+	if _, err = NewSemgrepHTTPServer(nil, cfg); err == nil {
+		t.Errorf("NewSemgrepHTTPServer() should fail with a missing policy file")
+	}
+}
+
+func TestCategoryOptions(t *testing.T) {
+	tests := []struct {
+		query     string
+		wantCWE   []string
+		wantOWASP []string
+		wantGroup string
+		wantErr   bool
+	}{
+		{query: ""},
+		{query: "cwe=CWE-78,89&cwe=cwe-22&owasp=A3&group_by=OWASP", wantCWE: []string{"CWE-78", "CWE-89", "CWE-22"}, wantOWASP: []string{"A03"}, wantGroup: "owasp"},
+		{query: "cwe=injection", wantErr: true},
+		{query: "owasp=Injection", wantErr: true},
+		{query: "group_by=file", wantErr: true},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/v2/semgrep/issues/components/export?"+tt.query, nil)
+		filter, groupBy, err := categoryOptions(r)
+		if (err != nil) != tt.wantErr || !reflect.DeepEqual(filter.CWE, tt.wantCWE) || !reflect.DeepEqual(filter.OWASP, tt.wantOWASP) || groupBy != tt.wantGroup {
+			t.Errorf("categoryOptions(%v) = %+v, %v, %v", tt.query, filter, groupBy, err)
+		}
+	}
+}
+
+func TestRiskOptions(t *testing.T) {
+	tests := []struct {
+		query    string
+		wantSort bool
+		wantMin  float64
+		wantErr  bool
+	}{
+		{query: ""},
+		{query: "sort=risk&min_risk=12.5", wantSort: true, wantMin: 12.5},
+		{query: "sort=name", wantErr: true},
+		{query: "min_risk=lots", wantErr: true},
+		{query: "min_risk=-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/v2/semgrep/issues/components/export?"+tt.query, nil)
+		sortByRisk, minRisk, err := riskOptions(r)
+		if (err != nil) != tt.wantErr || sortByRisk != tt.wantSort || minRisk != tt.wantMin {
+			t.Errorf("riskOptions(%v) = %v, %v, %v", tt.query, sortByRisk, minRisk, err)
+		}
+	}
+}
+
+func TestCategoryFilterHandler(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	db.SetMaxOpenConns(1) // keep the in-memory DB alive across calls
+	defer models.CloseDB(db)
+	local := models.NewLocalStoreModel(db)
+	rules := models.NewRuleModel(db)
+	if err = local.CreateTables(ctx); err != nil {
+		t.Fatalf("CreateTables() error = %v", err)
+	}
+	if err = rules.CreateTable(ctx); err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+	err = local.Import(ctx, models.LocalURL{URLHash: "url1", PurlName: "acme/tool", PurlType: "github", Version: "1.0.0", ImportedAt: time.Now()},
+		[]models.LocalFile{{URLHash: "url1", FileMD5: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", Path: "src/a.c"}},
+		[]models.SemgrepItem{{MD5: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", RuleID: "c.exec", From: "1", To: "2", Severity: "ERROR"}})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	cfg.LocalStore.Enabled = true
+	cfg.RuleCatalog.Enabled = true
+	server, err := NewSemgrepHTTPServer(db, cfg)
+	if err != nil {
+		t.Fatalf("NewSemgrepHTTPServer() error = %v", err)
+	}
+	mux := runtime.NewServeMux()
+	if err = server.RegisterHandlers(mux); err != nil {
+		t.Fatalf("RegisterHandlers() error = %v", err)
+	}
+	export := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v2/semgrep/issues/components/export?"+query,
+			strings.NewReader(`{"components":[{"purl":"pkg:github/acme/tool","requirement":"1.0.0"}]}`)))
+		return rec
+	}
+	// The rule catalog is enabled, but hasn't been imported: filtering or grouping must not report a clean result
+	for _, query := range []string{"cwe=CWE-78", "group_by=owasp"} {
+		if rec := export(query); rec.Code != http.StatusInternalServerError || !strings.Contains(rec.Body.String(), "Rule metadata is not available") {
+			t.Errorf("POST export?%v without rule metadata = %v (%v)", query, rec.Code, rec.Body.String())
+		}
+	}
+	if rec := export(""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"ruleID":"c.exec"`) {
+		t.Errorf("POST export without categories = %v (%v)", rec.Code, rec.Body.String())
+	}
+	if err = rules.Upsert(ctx, []models.Rule{{RuleID: "c.exec", CWE: `["CWE-78"]`, OWASP: `[]`, References: `[]`, UpdatedAt: time.Now()}}); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	if rec := export("cwe=CWE-78"); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"ruleID":"c.exec"`) {
+		t.Errorf("POST export?cwe=CWE-78 = %v (%v)", rec.Code, rec.Body.String())
+	}
+}
+
+func TestServiceInfoHandler(t *testing.T) {
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	Version = "1.2.3"
+	cfg.KB.ManifestFile = "../models/tests/semgrep-manifest.json"
+	server, err := NewSemgrepHTTPServer(nil, cfg)
+	if err != nil {
+		t.Fatalf("NewSemgrepHTTPServer() error = %v", err)
+	}
+	mux := runtime.NewServeMux()
+	if err = server.RegisterHandlers(mux); err != nil {
+		t.Fatalf("RegisterHandlers() error = %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/semgrep/service/info", nil))
+	if body := rec.Body.String(); rec.Code != http.StatusOK || !strings.Contains(body, `"version":"1.2.3"`) ||
+		!strings.Contains(body, `"kb":{"version":"25.09","snapshotDate":"2025-09-01"`) {
+		t.Errorf("GET service/info = %v, %v", rec.Code, body)
+	}
+}
+
+func TestFilesIssuesHandler(t *testing.T) {
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	server, err := NewSemgrepHTTPServer(nil, cfg)
+	if err != nil {
+		t.Fatalf("NewSemgrepHTTPServer() error = %v", err)
+	}
+	mux := runtime.NewServeMux()
+	if err = server.RegisterHandlers(mux); err != nil {
+		t.Fatalf("RegisterHandlers() error = %v", err)
+	}
+	tests := []struct {
+		body     string
+		wantCode int
+	}{
+		{body: `{"files":[{"fileMD5":"4D66775F503B1E76582E7E5B2EA54D92"}]}`, wantCode: http.StatusOK},
+		{body: `{"files":[]}`, wantCode: http.StatusBadRequest},
+		{body: `{"files":[{"fileMD5":"main.c"}]}`, wantCode: http.StatusBadRequest},
+		{body: `{"files":{}}`, wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v2/semgrep/issues/files", strings.NewReader(tt.body)))
+		if rec.Code != tt.wantCode {
+			t.Errorf("POST issues/files %v = %v, want %v (%v)", tt.body, rec.Code, tt.wantCode, rec.Body.String())
+		}
+	}
+}
+
+func TestSearchRuleComponentsHandler(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	db.SetMaxOpenConns(1) // keep the in-memory DB alive across calls
+	defer models.CloseDB(db)
+	index := models.NewRuleIndexModel(db)
+	if err = index.CreateTable(ctx); err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+	writer, err := index.Rebuild(ctx)
+	if err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+	if err = writer.Add(ctx, []models.RuleHit{
+		{RuleID: "js.eval", URLHash: "u1", FileMD5: "f1", PurlType: "npm", PurlName: "lodash", Version: "4.17.20", Severity: "ERROR", Findings: 1},
+		{RuleID: "js.eval", URLHash: "u2", FileMD5: "f2", PurlType: "npm", PurlName: "express", Version: "4.0.0", Severity: "ERROR", Findings: 1},
+	}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err = writer.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	cfg.RuleIndex.Enabled = true
+	server, err := NewSemgrepHTTPServer(db, cfg)
+	if err != nil {
+		t.Fatalf("NewSemgrepHTTPServer() error = %v", err)
+	}
+	mux := runtime.NewServeMux()
+	if err = server.RegisterHandlers(mux); err != nil {
+		t.Fatalf("RegisterHandlers() error = %v", err)
+	}
+	tests := []struct {
+		method   string
+		target   string
+		body     string
+		wantCode int
+		want     string
+	}{
+		{method: http.MethodGet, target: "?rule_id=js.eval&limit=1", wantCode: http.StatusOK, want: `"urlHash":"u1","files":[{"fileMD5":"f1"`},
+		{method: http.MethodGet, target: "?rule_id=js.eval&limit=1&cursor=u1", wantCode: http.StatusOK, want: `"purl":"pkg:npm/express"`},
+		{method: http.MethodGet, target: "?rule_id=js.eval&purl=pkg:npm/lodash@4.17.20", wantCode: http.StatusOK, want: `"version":"4.17.20"`},
+		{method: http.MethodGet, target: "?rule_id=js.eval&limit=ten", wantCode: http.StatusBadRequest},
+		{method: http.MethodGet, target: "", wantCode: http.StatusBadRequest},
+		{method: http.MethodPost, body: `{"ruleId":"js.eval","components":[{"purl":"pkg:npm/express"}]}`, wantCode: http.StatusOK, want: `"urlHash":"u2"`},
+		{method: http.MethodPost, body: `{"ruleId":["js.eval"]}`, wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(tt.method, "/v2/semgrep/rules/components"+tt.target, strings.NewReader(tt.body)))
+		if rec.Code != tt.wantCode || !strings.Contains(rec.Body.String(), tt.want) {
+			t.Errorf("%v rules/components%v %v = %v, want %v (%v)", tt.method, tt.target, tt.body, rec.Code, tt.wantCode, rec.Body.String())
+		}
+	}
+}
+
+func TestComponentIssuesPageHandler(t *testing.T) {
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	server, err := NewSemgrepHTTPServer(nil, cfg)
+	if err != nil {
+		t.Fatalf("NewSemgrepHTTPServer() error = %v", err)
+	}
+	mux := runtime.NewServeMux()
+	if err = server.RegisterHandlers(mux); err != nil {
+		t.Fatalf("RegisterHandlers() error = %v", err)
+	}
+	tests := []struct {
+		body     string
+		omit     string
+		wantCode int
+	}{
+		{body: `{"purl":"pkg:npm/lodash","cursor":"not-a-cursor"}`, wantCode: http.StatusBadRequest},
+		{body: `{"purl":"pkg:npm/lodash","limit":-1}`, wantCode: http.StatusBadRequest},
+		{body: `{"purl":"pkg:npm/lodash","omit":["licenses"]}`, wantCode: http.StatusBadRequest},
+		{body: `{"purl":"pkg:npm/lodash"}`, omit: "paths,versions", wantCode: http.StatusBadRequest},
+		{body: `{"requirement":"^4.0.0"}`, wantCode: http.StatusBadRequest},
+		{body: `{"purl":["pkg:npm/lodash"]}`, wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/v2/semgrep/issues/component/page", strings.NewReader(tt.body))
+		if len(tt.omit) > 0 {
+			r.Header.Set("X-Semgrep-Omit", tt.omit)
+		}
+		mux.ServeHTTP(rec, r)
+		if rec.Code != tt.wantCode {
+			t.Errorf("POST issues/component/page %v (omit %v) = %v, want %v (%v)", tt.body, tt.omit, rec.Code, tt.wantCode, rec.Body.String())
+		}
+	}
+}
+
+func TestStreamComponentsIssuesHandler(t *testing.T) {
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	db.SetMaxOpenConns(1) // keep the in-memory DB alive across calls
+	defer models.CloseDB(db)
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	server, err := NewSemgrepHTTPServer(db, cfg)
+	if err != nil {
+		t.Fatalf("NewSemgrepHTTPServer() error = %v", err)
+	}
+	mux := runtime.NewServeMux()
+	if err = server.RegisterHandlers(mux); err != nil {
+		t.Fatalf("RegisterHandlers() error = %v", err)
+	}
+	tests := []struct {
+		body      string
+		omit      string
+		wantCode  int
+		wantLines []string
+	}{
+		{body: `{"components":[{"purl":"pkg:npm/a"},{"purl":"pkg:npm/b"}]}`, wantCode: http.StatusOK, wantLines: []string{
+			`{"component":{"purl":"pkg:npm/a"}}`, `{"component":{"purl":"pkg:npm/b"}}`,
+			`{"progress":{"resolved":2,"total":2}}`, `{"status":{"status":"SUCCESS","message":"Success"}}`,
+		}},
+		{body: `{"components":[{"purl":"@1.0"}]}`, wantCode: http.StatusOK, wantLines: []string{
+			`{"status":{"status":"FAILED","message":"Request validation failed: no components supplied"}}`,
+		}},
+		{body: `{"components":[]}`, wantCode: http.StatusBadRequest},
+		{body: `{"components":[{"purl":"pkg:npm/a"}]}`, omit: "licenses", wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/v2/semgrep/issues/components/stream", strings.NewReader(tt.body))
+		if len(tt.omit) > 0 {
+			r.Header.Set("X-Semgrep-Omit", tt.omit)
+		}
+		mux.ServeHTTP(rec, r)
+		if rec.Code != tt.wantCode {
+			t.Errorf("POST issues/components/stream %v = %v, want %v (%v)", tt.body, rec.Code, tt.wantCode, rec.Body.String())
+			continue
+		}
+		if tt.wantLines == nil {
+			continue
+		}
+		var got []string
+		for _, line := range strings.Split(strings.TrimSpace(rec.Body.String()), "\n") {
+			var compact bytes.Buffer
+			if err = json.Compact(&compact, []byte(line)); err != nil {
+				t.Fatalf("POST issues/components/stream invalid line %v: %v", line, err)
+			}
+			got = append(got, compact.String())
+		}
+		if !reflect.DeepEqual(got, tt.wantLines) {
+			t.Errorf("POST issues/components/stream %v lines = %v, want %v", tt.body, got, tt.wantLines)
+		}
+		if contentType := rec.Header().Get("Content-Type"); contentType != ndjsonContentType {
+			t.Errorf("POST issues/components/stream Content-Type = %v, want %v", contentType, ndjsonContentType)
+		}
+	}
+}
+
+func TestJobsHTTPHandlers(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	db.SetMaxOpenConns(1) // keep the in-memory DB alive across calls
+	defer models.CloseDB(db)
+	if err = models.NewJobModel(db).CreateTables(ctx); err != nil {
+		t.Fatalf("CreateTables() error = %v", err)
+	}
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	cfg.Jobs.Enabled = true
+	server, err := NewSemgrepHTTPServer(db, cfg)
+	if err != nil {
+		t.Fatalf("NewSemgrepHTTPServer() error = %v", err)
+	}
+	mux := runtime.NewServeMux()
+	if err = server.RegisterHandlers(mux); err != nil {
+		t.Fatalf("RegisterHandlers() error = %v", err)
+	}
+	do := func(method, path, body, project string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		if len(project) > 0 {
+			r.Header.Set("X-Scanoss-Project", project)
+		}
+		mux.ServeHTTP(rec, r)
+		return rec
+	}
+	rec := do(http.MethodPost, "/v2/semgrep/jobs", `{"components":[{"purl":"pkg:npm/a"},{"purl":"pkg:npm/b"}]}`, "web")
+	var created jobHTTPResponse
+	if err = json.Unmarshal(rec.Body.Bytes(), &created); rec.Code != http.StatusAccepted || err != nil || created.Job.State != models.JobQueued || created.Job.Project != "web" {
+		t.Fatalf("POST jobs = %v %v", rec.Code, rec.Body.String())
+	}
+	id := created.Job.ID
+	runner, err := usecase.NewJobRunner(db, usecase.NewSemgrep(db, usecase.SemgrepStores{}), cfg)
+	if err != nil {
+		t.Fatalf("NewJobRunner() error = %v", err)
+	}
+	if !runner.RunNext(ctx) {
+		t.Fatalf("RunNext() did not process the job")
+	}
+	tests := []struct {
+		method     string
+		path       string
+		body       string
+		project    string // Defaults to the job project
+		wantCode   int
+		want       string
+		wantCursor bool
+	}{
+		{method: http.MethodGet, path: "/v2/semgrep/jobs/" + id, wantCode: http.StatusOK, want: `"state":"completed","resolved":2,"total":2`},
+		{method: http.MethodGet, path: "/v2/semgrep/jobs/" + id + "/results?limit=1", wantCode: http.StatusOK, want: `"purl":"pkg:npm/a"`, wantCursor: true},
+		{method: http.MethodGet, path: "/v2/semgrep/jobs/" + id + "/results?cursor=0&format=sarif", wantCode: http.StatusOK, want: `"version": "2.1.0"`, wantCursor: true},
+		{method: http.MethodGet, path: "/v2/semgrep/jobs/" + id + "/results?cursor=1", wantCode: http.StatusOK, want: `"purls":[]`, wantCursor: true},
+		{method: http.MethodGet, path: "/v2/semgrep/jobs/" + id + "/results?limit=ten", wantCode: http.StatusBadRequest},
+		{method: http.MethodGet, path: "/v2/semgrep/jobs/" + id + "/results?format=docx", wantCode: http.StatusBadRequest},
+		{method: http.MethodPost, path: "/v2/semgrep/jobs/" + id + "/cancel", wantCode: http.StatusBadRequest, want: "already finished"},
+		{method: http.MethodGet, path: "/v2/semgrep/jobs/missing", wantCode: http.StatusNotFound},
+		{method: http.MethodPost, path: "/v2/semgrep/jobs/missing/cancel", wantCode: http.StatusNotFound},
+		{method: http.MethodPost, path: "/v2/semgrep/jobs", body: `{"components":[]}`, wantCode: http.StatusBadRequest},
+		// Jobs of other projects are not found
+		{method: http.MethodGet, path: "/v2/semgrep/jobs/" + id, project: "mobile", wantCode: http.StatusNotFound},
+		{method: http.MethodGet, path: "/v2/semgrep/jobs/" + id + "/results", project: "mobile", wantCode: http.StatusNotFound},
+		{method: http.MethodPost, path: "/v2/semgrep/jobs/" + id + "/cancel", project: "mobile", wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		project := tt.project
+		if len(project) == 0 {
+			project = "web"
+		}
+		rec = do(tt.method, tt.path, tt.body, project)
+		if rec.Code != tt.wantCode || !strings.Contains(rec.Body.String(), tt.want) {
+			t.Errorf("%v %v = %v, want %v (%v)", tt.method, tt.path, rec.Code, tt.wantCode, rec.Body.String())
+		}
+		if cursor := rec.Header().Get(nextCursorHeader); (len(cursor) > 0) != tt.wantCursor {
+			t.Errorf("%v %v next cursor = %q, want cursor %v", tt.method, tt.path, cursor, tt.wantCursor)
+		}
+	}
+}
@@ -56,7 +56,7 @@ func NewSemgrepServer(db *sqlx.DB, config *myconfig.ServerConfig) pb.SemgrepServ
 	return &SemgrepServer{
 		db:             db,
 		config:         config,
-		semgrepUseCase: usecase.NewSemgrep(db),
+		semgrepUseCase: usecase.NewSemgrep(db, usecase.StoresFromConfig(db, config)),
 	}
 }
 
@@ -89,6 +89,7 @@ type UseCaseHandler func(context.Context, *zap.SugaredLogger, []dtos.ComponentDT
 
 // handleLegacyRequest provides a generic request handling pattern for legacy endpoints.
 // It orchestrates the request conversion, business logic execution, and response building.
+// Any fields listed in the request metadata (see omitMetadataKey) are left out of the output.
 //
 // Type Parameters:
 //   - R: Request type
@@ -111,12 +112,20 @@ func handleLegacyRequest[R any, T any](
 	responseBuilder ResponseBuilder[T],
 ) T {
 	s := ctxzap.Extract(ctx).Sugar()
+	ctx = projectContext(ctx)
+	mask, err := fieldMaskFromContext(ctx)
+	if err != nil {
+		return responseBuilder(ctx, s, dtos.SemgrepOutput{}, err)
+	}
 	dtoRequest, err := requestConverter(req) // Convert to internal DTO for processing
 	if err != nil {
 		responseBuilder(ctx, s, dtos.SemgrepOutput{}, err)
 	}
 
 	dtoSemgrep, err := useCaseHandler(ctx, s, dtoRequest)
+	if err == nil {
+		mask.ApplyOutput(dtoSemgrep) // leave out any fields masked in the request metadata
+	}
 	return responseBuilder(ctx, s, dtoSemgrep, err)
 }
 
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"google.golang.org/protobuf/encoding/protojson"
+	pbx "scanoss.com/semgrep/api/semgrepextv2"
+	se "scanoss.com/semgrep/pkg/errors"
+)
+
+// ndjsonContentType is the content type of newline delimited JSON streams.
+const ndjsonContentType = "application/x-ndjson"
+
+// StreamComponentsIssues streams the issues of a (large) list of components as NDJSON (POST /v2/semgrep/issues/components/stream).
+// Each line holds a message of the gRPC stream (a component, a progress update or the final status), flushed as soon as it's ready.
+// Fields listed in the X-Semgrep-Omit header (paths, issues) are left out of the components.
+func (c SemgrepHTTPServer) StreamComponentsIssues(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s := httpLogger(r)
+	mask, err := requestFieldMask(r)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	body, err := readRequestBody(w, r)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	var request common.ComponentsRequest
+	if err = json.Unmarshal(body, &request); err != nil {
+		writeHTTPError(w, s, se.NewBadRequestError("Request validation failed: invalid components request", err))
+		return
+	}
+	components, err := componentsToComponentsDTO(&request)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	flusher, _ := w.(http.Flusher)
+	w.Header().Set("Content-Type", ndjsonContentType)
+	w.WriteHeader(http.StatusOK)
+	send := func(message *pbx.ComponentsIssuesStreamResponse) error {
+		data, err := protojson.Marshal(message)
+		if err != nil {
+			return se.NewInternalError("Problem marshalling stream message", err)
+		}
+		if _, err = w.Write(append(data, '\n')); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	}
+	stream := pbIssueStream{send: send, weights: c.riskWeights, mask: mask}
+	if err = streamComponentsIssues(requestContext(r), s, c.semgrepUseCase, components, streamBatchSize(c.config), stream); err != nil {
+		s.Errorf("Failed to write REST stream: %v", err)
+	}
+}
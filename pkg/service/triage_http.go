@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"go.uber.org/zap"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+)
+
+// triageHTTPResponse is the REST response of a single triage decision.
+type triageHTTPResponse struct {
+	Decision dtos.TriageDecision    `json:"decision"`
+	Status   *common.StatusResponse `json:"status"`
+}
+
+// triageListHTTPResponse is the REST response of a list of triage decisions.
+type triageListHTTPResponse struct {
+	Decisions []dtos.TriageDecision  `json:"decisions"`
+	Status    *common.StatusResponse `json:"status"`
+}
+
+// CreateTriageDecision records a new triage decision (POST /v2/semgrep/triage).
+func (c SemgrepHTTPServer) CreateTriageDecision(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s := httpLogger(r)
+	decision, err := readTriageDecision(w, r)
+	if err == nil {
+		decision, err = c.triageUseCase.Create(r.Context(), s, decision)
+	}
+	writeTriageResponse(w, s, http.StatusCreated, decision, err)
+}
+
+// GetTriageDecision retrieves a triage decision by ID (GET /v2/semgrep/triage/{id}).
+func (c SemgrepHTTPServer) GetTriageDecision(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s := httpLogger(r)
+	decision, err := c.triageUseCase.Get(r.Context(), s, params["id"])
+	writeTriageResponse(w, s, http.StatusOK, decision, err)
+}
+
+// ListTriageDecisions lists the triage decisions matching the query parameters (GET /v2/semgrep/triage).
+// Supported filters: project, global_only, file_md5, rule_id and state.
+func (c SemgrepHTTPServer) ListTriageDecisions(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s := httpLogger(r)
+	query := r.URL.Query()
+	decisions, err := c.triageUseCase.List(r.Context(), s, dtos.TriageFilter{
+		Project:    query.Get("project"),
+		GlobalOnly: query.Get("global_only") == "true",
+		FileMD5:    query.Get("file_md5"),
+		RuleID:     query.Get("rule_id"),
+		State:      query.Get("state"),
+	})
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	writeHTTPJSON(w, s, http.StatusOK, triageListHTTPResponse{Decisions: decisions, Status: httpSuccess()})
+}
+
+// UpdateTriageDecision updates the state, author and comment of a triage decision (PUT /v2/semgrep/triage/{id}).
+func (c SemgrepHTTPServer) UpdateTriageDecision(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s := httpLogger(r)
+	decision, err := readTriageDecision(w, r)
+	if err == nil {
+		decision.ID = params["id"]
+		decision, err = c.triageUseCase.Update(r.Context(), s, decision)
+	}
+	writeTriageResponse(w, s, http.StatusOK, decision, err)
+}
+
+// DeleteTriageDecision deletes a triage decision, returning the deleted decision (DELETE /v2/semgrep/triage/{id}).
+func (c SemgrepHTTPServer) DeleteTriageDecision(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s := httpLogger(r)
+	decision, err := c.triageUseCase.Delete(r.Context(), s, params["id"])
+	writeTriageResponse(w, s, http.StatusOK, decision, err)
+}
+
+// readTriageDecision reads a triage decision from the REST body. Both {"decision": {...}} and a bare decision are accepted.
+func readTriageDecision(w http.ResponseWriter, r *http.Request) (dtos.TriageDecision, error) {
+	body, err := readRequestBody(w, r)
+	if err != nil {
+		return dtos.TriageDecision{}, err
+	}
+	var request struct {
+		Decision *dtos.TriageDecision `json:"decision"`
+	}
+	if err = json.Unmarshal(body, &request); err != nil {
+		return dtos.TriageDecision{}, se.NewBadRequestError("Request validation failed: invalid triage decision", err)
+	}
+	if request.Decision != nil {
+		return *request.Decision, nil
+	}
+	var decision dtos.TriageDecision
+	if err = json.Unmarshal(body, &decision); err != nil {
+		return dtos.TriageDecision{}, se.NewBadRequestError("Request validation failed: invalid triage decision", err)
+	}
+	return decision, nil
+}
+
+// writeTriageResponse writes a single triage decision (or the error) to the response.
+func writeTriageResponse(w http.ResponseWriter, s *zap.SugaredLogger, code int, decision dtos.TriageDecision, err error) {
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	writeHTTPJSON(w, s, code, triageHTTPResponse{Decision: decision, Status: httpSuccess()})
+}
+
+// httpSuccess returns a successful REST response status.
+func httpSuccess() *common.StatusResponse {
+	return &common.StatusResponse{Status: common.StatusCode_SUCCESS, Message: "Success"}
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	common "github.com/scanoss/papi/api/commonv2"
+	"google.golang.org/grpc/metadata"
+	pbx "scanoss.com/semgrep/api/semgrepextv2"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+	"scanoss.com/semgrep/pkg/usecase"
+)
+
+// projectMetadataKey is the gRPC metadata key (REST header) scoping a request to a project.
+// REST clients of the gateway forwarded endpoints can supply it as the Grpc-Metadata-X-Scanoss-Project header.
+const projectMetadataKey = "x-scanoss-project"
+
+// SemgrepTriageServer implements the gRPC service for central triage of Semgrep findings.
+type SemgrepTriageServer struct {
+	pbx.UnimplementedSemgrepTriageServer
+	triageUseCase *usecase.TriageUseCase // Business logic handler for triage operations
+}
+
+// NewSemgrepTriageServer creates a new instance of the Semgrep Triage Server.
+//
+// Parameters:
+//   - db: Database connection holding the triage tables
+//
+// Returns:
+//   - pbx.SemgrepTriageServer: Initialized gRPC server instance
+func NewSemgrepTriageServer(db *sqlx.DB) pbx.SemgrepTriageServer {
+	return &SemgrepTriageServer{triageUseCase: usecase.NewTriage(db)}
+}
+
+// CreateTriageDecision records a new triage decision.
+func (c SemgrepTriageServer) CreateTriageDecision(ctx context.Context, request *pbx.TriageDecisionRequest) (*pbx.TriageDecisionResponse, error) {
+	s := ctxzap.Extract(ctx).Sugar()
+	decision, err := c.triageUseCase.Create(ctx, s, triageFromPb(request.GetDecision()))
+	return triageResponse(ctx, decision, err), nil
+}
+
+// GetTriageDecision retrieves a triage decision by ID.
+func (c SemgrepTriageServer) GetTriageDecision(ctx context.Context, request *pbx.TriageDecisionIdRequest) (*pbx.TriageDecisionResponse, error) {
+	s := ctxzap.Extract(ctx).Sugar()
+	decision, err := c.triageUseCase.Get(ctx, s, request.GetId())
+	return triageResponse(ctx, decision, err), nil
+}
+
+// ListTriageDecisions lists the triage decisions matching the request filters.
+func (c SemgrepTriageServer) ListTriageDecisions(ctx context.Context, request *pbx.TriageDecisionListRequest) (*pbx.TriageDecisionListResponse, error) {
+	s := ctxzap.Extract(ctx).Sugar()
+	decisions, err := c.triageUseCase.List(ctx, s, dtos.TriageFilter{
+		Project:    request.GetProject(),
+		GlobalOnly: request.GetGlobalOnly(),
+		FileMD5:    request.GetFileMd5(),
+		RuleID:     request.GetRuleId(),
+		State:      request.GetState(),
+	})
+	if err != nil {
+		return &pbx.TriageDecisionListResponse{Status: extStatus(se.HandleServiceError(ctx, s, err))}, nil
+	}
+	resp := &pbx.TriageDecisionListResponse{Decisions: make([]*pbx.TriageDecision, 0, len(decisions)), Status: extSuccess()}
+	for _, d := range decisions {
+		resp.Decisions = append(resp.Decisions, triageToPb(d))
+	}
+	return resp, nil
+}
+
+// UpdateTriageDecision updates the state, author and comment of a triage decision.
+func (c SemgrepTriageServer) UpdateTriageDecision(ctx context.Context, request *pbx.TriageDecisionRequest) (*pbx.TriageDecisionResponse, error) {
+	s := ctxzap.Extract(ctx).Sugar()
+	decision, err := c.triageUseCase.Update(ctx, s, triageFromPb(request.GetDecision()))
+	return triageResponse(ctx, decision, err), nil
+}
+
+// DeleteTriageDecision deletes a triage decision, returning the deleted decision.
+func (c SemgrepTriageServer) DeleteTriageDecision(ctx context.Context, request *pbx.TriageDecisionIdRequest) (*pbx.TriageDecisionResponse, error) {
+	s := ctxzap.Extract(ctx).Sugar()
+	decision, err := c.triageUseCase.Delete(ctx, s, request.GetId())
+	return triageResponse(ctx, decision, err), nil
+}
+
+// triageResponse builds a single decision response, converting any error into a failed status.
+func triageResponse(ctx context.Context, decision dtos.TriageDecision, err error) *pbx.TriageDecisionResponse {
+	if err != nil {
+		return &pbx.TriageDecisionResponse{Status: extStatus(se.HandleServiceError(ctx, ctxzap.Extract(ctx).Sugar(), err))}
+	}
+	return &pbx.TriageDecisionResponse{Decision: triageToPb(decision), Status: extSuccess()}
+}
+
+// extSuccess returns a successful extension response status.
+func extSuccess() *pbx.StatusResponse {
+	return &pbx.StatusResponse{Status: pbx.StatusCode_SUCCESS, Message: "Success"}
+}
+
+// extStatus converts a common status response into the (equivalent) extension status response.
+func extStatus(status *common.StatusResponse) *pbx.StatusResponse {
+	return &pbx.StatusResponse{Status: pbx.StatusCode(status.GetStatus()), Message: status.GetMessage()}
+}
+
+// triageFromPb converts a protobuf triage decision into the internal DTO format.
+func triageFromPb(d *pbx.TriageDecision) dtos.TriageDecision {
+	return dtos.TriageDecision{
+		ID:      d.GetId(),
+		Project: d.GetProject(),
+		FileMD5: d.GetFileMd5(),
+		RuleID:  d.GetRuleId(),
+		From:    d.GetFrom(),
+		To:      d.GetTo(),
+		State:   d.GetState(),
+		Author:  d.GetAuthor(),
+		Comment: d.GetComment(),
+	}
+}
+
+// triageToPb converts an internal triage decision DTO into its protobuf format.
+func triageToPb(d dtos.TriageDecision) *pbx.TriageDecision {
+	return &pbx.TriageDecision{
+		Id:        d.ID,
+		Project:   d.Project,
+		FileMd5:   d.FileMD5,
+		RuleId:    d.RuleID,
+		From:      d.From,
+		To:        d.To,
+		State:     d.State,
+		Author:    d.Author,
+		Comment:   d.Comment,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
+
+// projectContext scopes the request context to the project supplied in the gRPC metadata (if any).
+func projectContext(ctx context.Context) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(projectMetadataKey); len(values) > 0 {
+			return usecase.ContextWithProject(ctx, values[0])
+		}
+	}
+	return ctx
+}
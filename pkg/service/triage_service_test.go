@@ -0,0 +1,286 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	pbx "scanoss.com/semgrep/api/semgrepextv2"
+	myconfig "scanoss.com/semgrep/pkg/config"
+	"scanoss.com/semgrep/pkg/dtos"
+	"scanoss.com/semgrep/pkg/models"
+	"scanoss.com/semgrep/pkg/risk"
+	"scanoss.com/semgrep/pkg/usecase"
+)
+
+// triageTestDB opens an in-memory DB with the triage table created.
+func triageTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	db.SetMaxOpenConns(1) // keep the in-memory DB alive across calls
+	t.Cleanup(func() { models.CloseDB(db) })
+	if err = models.NewTriageModel(db).CreateTable(context.Background()); err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+	return db
+}
+
+func TestSemgrepTriageServer(t *testing.T) {
+	ctx := context.Background()
+	server := NewSemgrepTriageServer(triageTestDB(t))
+	created, err := server.CreateTriageDecision(ctx, &pbx.TriageDecisionRequest{Decision: &pbx.TriageDecision{
+		FileMd5: "abc123", RuleId: "rule.a", State: "false_positive", Author: "alice",
+	}})
+	if err != nil || created.GetStatus().GetStatus() != pbx.StatusCode_SUCCESS || len(created.GetDecision().GetId()) == 0 {
+		t.Fatalf("CreateTriageDecision() = %v, %v", created, err)
+	}
+	invalid, _ := server.CreateTriageDecision(ctx, &pbx.TriageDecisionRequest{Decision: &pbx.TriageDecision{FileMd5: "abc123"}})
+	if invalid.GetStatus().GetStatus() != pbx.StatusCode_FAILED {
+		t.Errorf("CreateTriageDecision() invalid status = %v", invalid.GetStatus())
+	}
+	list, _ := server.ListTriageDecisions(ctx, &pbx.TriageDecisionListRequest{FileMd5: "abc123"})
+	if len(list.GetDecisions()) != 1 || list.GetDecisions()[0].GetAuthor() != "alice" {
+		t.Errorf("ListTriageDecisions() = %v", list)
+	}
+	id := created.GetDecision().GetId()
+	updated, _ := server.UpdateTriageDecision(ctx, &pbx.TriageDecisionRequest{Decision: &pbx.TriageDecision{Id: id, State: "confirmed", Author: "bob"}})
+	if updated.GetDecision().GetState() != "confirmed" {
+		t.Errorf("UpdateTriageDecision() = %v", updated)
+	}
+	if deleted, _ := server.DeleteTriageDecision(ctx, &pbx.TriageDecisionIdRequest{Id: id}); deleted.GetDecision().GetId() != id {
+		t.Errorf("DeleteTriageDecision() = %v", deleted)
+	}
+	if missing, _ := server.GetTriageDecision(ctx, &pbx.TriageDecisionIdRequest{Id: id}); missing.GetStatus().GetStatus() != pbx.StatusCode_FAILED {
+		t.Errorf("GetTriageDecision() deleted status = %v", missing.GetStatus())
+	}
+}
+
+func TestTriageHTTPHandlers(t *testing.T) {
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	cfg.Triage.Enabled = true
+	mux := runtime.NewServeMux()
+	server, err := NewSemgrepHTTPServer(triageTestDB(t), cfg)
+	if err != nil {
+		t.Fatalf("NewSemgrepHTTPServer() error = %v", err)
+	}
+	if err = server.RegisterHandlers(mux); err != nil {
+		t.Fatalf("RegisterHandlers() error = %v", err)
+	}
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+	rec := do(http.MethodPost, "/v2/semgrep/triage", `{"decision": {"fileMd5": "abc123", "ruleId": "rule.a", "state": "accepted_risk", "author": "alice"}}`)
+	if rec.Code != http.StatusCreated || !strings.Contains(rec.Body.String(), `"state":"accepted_risk"`) {
+		t.Fatalf("POST triage = %v %v", rec.Code, rec.Body.String())
+	}
+	if rec = do(http.MethodPost, "/v2/semgrep/triage", `{"fileMd5": "abc123", "ruleId": "rule.a", "state": "accepted_risk", "author": "bob"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("POST duplicate triage = %v %v", rec.Code, rec.Body.String())
+	}
+	if rec = do(http.MethodGet, "/v2/semgrep/triage?file_md5=abc123", ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"author":"alice"`) {
+		t.Errorf("GET triage list = %v %v", rec.Code, rec.Body.String())
+	}
+	if rec = do(http.MethodGet, "/v2/semgrep/triage/missing", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("GET missing triage = %v %v", rec.Code, rec.Body.String())
+	}
+	if rec = do(http.MethodPut, "/v2/semgrep/triage/missing", `{"state": "confirmed", "author": "bob"}`); rec.Code != http.StatusNotFound {
+		t.Errorf("PUT missing triage = %v %v", rec.Code, rec.Body.String())
+	}
+}
+
+func TestProjectContext(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(projectMetadataKey, "web"))
+	if project := usecase.ProjectFromContext(projectContext(ctx)); project != "web" {
+		t.Errorf("projectContext() project = %v, want web", project)
+	}
+	if project := usecase.ProjectFromContext(projectContext(context.Background())); project != "" {
+		t.Errorf("projectContext() project = %v, want empty", project)
+	}
+}
+
+func TestSemgrepIssuesServer(t *testing.T) {
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	server, err := NewSemgrepIssuesServer(nil, cfg)
+	if err != nil {
+		t.Fatalf("NewSemgrepIssuesServer() error = %v", err)
+	}
+	response, err := server.GetComponentIssuesPage(context.Background(), &pbx.ComponentIssuesPageRequest{Purl: "pkg:npm/lodash", Cursor: "not-a-cursor"})
+	if err != nil || response.GetStatus().GetStatus() != pbx.StatusCode_FAILED {
+		t.Errorf("GetComponentIssuesPage() invalid cursor = %v, %v", response, err)
+	}
+}
+
+func TestFieldMaskFromContext(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    dtos.FieldMask
+		wantErr bool
+	}{
+		{name: "no metadata", ctx: context.Background()},
+		{name: "paths", ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(omitMetadataKey, "paths")), want: dtos.FieldMask{OmitPaths: true}},
+		{name: "both", ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(omitMetadataKey, "paths, issues")), want: dtos.FieldMask{OmitPaths: true, OmitIssues: true}},
+		{name: "unknown", ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(omitMetadataKey, "licenses")), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fieldMaskFromContext(tt.ctx)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("fieldMaskFromContext() = %v, %v, want %v (error %v)", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestComponentIssueInfoToPb(t *testing.T) {
+	item := dtos.SemgrepOutputItem{Purl: "pkg:npm/a", Version: "1.0.0", AnalysedFiles: 12, RiskScore: 8.33,
+		Presence:   &dtos.Presence{Files: 6, Percent: 50},
+		Peer:       &dtos.PeerRank{Ecosystem: "npm", Density: 0.5, WorseThan: 80, Components: 10, Summary: "worse than 80% of npm packages"},
+		Provenance: &dtos.Provenance{Source: dtos.ProvenanceKB, ScanDate: "2025-01-01"},
+		Files: []dtos.SemgrepFileIssues{{File: "md5", Path: "a/index.js", Issues: []dtos.IssueItem{
+			{RuleID: "r1", From: "1", To: "2", Severity: "ERROR", Fingerprint: "fp1",
+				Triage: &dtos.IssueTriage{ID: "7", State: dtos.TriageAcceptedRisk, Author: "alice"},
+				Rule:   &dtos.RuleMetadata{Title: "Command injection", CWE: []string{"CWE-78"}, OWASP: []string{"A03:2021"}}},
+			{RuleID: "r2", From: "5", To: "5", Severity: "INFO", Fingerprint: "fp2"},
+		}}},
+	}
+	info := componentIssueInfoToPb(item, "^1.0")
+	if info.GetAnalysedFiles() != 12 || info.GetPeer().GetWorseThan() != 80 || info.GetProvenance().GetSource() != dtos.ProvenanceKB ||
+		info.GetRiskScore() != 8.33 || info.GetPresence().GetFiles() != 6 || info.GetPresence().GetPercent() != 50 {
+		t.Errorf("componentIssueInfoToPb() component annotations = %v", info)
+	}
+	issues := info.GetFiles()[0].GetIssues()
+	first := issues[0]
+	if first.GetFingerprint() != "fp1" || first.GetTriage().GetState() != dtos.TriageAcceptedRisk || first.GetTriage().GetId() != "7" ||
+		!reflect.DeepEqual(first.GetRule().GetCwe(), []string{"CWE-78"}) || first.GetRule().GetTitle() != "Command injection" {
+		t.Errorf("componentIssueInfoToPb() issue annotations = %v", first)
+	}
+	if second := issues[1]; second.GetFingerprint() != "fp2" || second.GetTriage() != nil || second.GetRule() != nil {
+		t.Errorf("componentIssueInfoToPb() issue without annotations = %v", second)
+	}
+}
+
+func TestPbIssueStream(t *testing.T) {
+	var sent []*pbx.ComponentsIssuesStreamResponse
+	stream := pbIssueStream{
+		send:    func(m *pbx.ComponentsIssuesStreamResponse) error { sent = append(sent, m); return nil },
+		weights: risk.DefaultWeights(),
+		mask:    dtos.FieldMask{OmitIssues: true},
+	}
+	item := dtos.SemgrepOutputItem{Purl: "pkg:npm/a", AnalysedFiles: 10, Files: []dtos.SemgrepFileIssues{{File: "md5", Issues: []dtos.IssueItem{
+		{RuleID: "r1", Severity: "ERROR"}, {RuleID: "r2", Severity: "WARNING"},
+	}}}}
+	if err := stream.Component(dtos.ComponentDTO{Purl: "pkg:npm/a"}, item); err != nil {
+		t.Fatalf("Component() error = %v", err)
+	}
+	if err := stream.Progress(dtos.StreamProgress{Resolved: 1, Total: 1, KB: &dtos.KBInfo{Version: "2025.10"}}); err != nil {
+		t.Fatalf("Progress() error = %v", err)
+	}
+	// The component is scored on all its findings, before the issue details are masked
+	component := sent[0].GetComponent()
+	if component.GetRiskScore() != 130 || len(component.GetFiles()[0].GetIssues()) != 0 || component.GetFiles()[0].GetIssueCount() != 2 {
+		t.Errorf("Component() sent %v", component)
+	}
+	if kb := sent[1].GetProgress().GetKb(); kb.GetVersion() != "2025.10" {
+		t.Errorf("Progress() sent KB %v", kb)
+	}
+}
+
+// recordingServerStream collects the messages sent to a server stream.
+type recordingServerStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	messages []*pbx.ComponentsIssuesStreamResponse
+}
+
+func (r *recordingServerStream) Context() context.Context { return r.ctx }
+
+func (r *recordingServerStream) Send(m *pbx.ComponentsIssuesStreamResponse) error {
+	r.messages = append(r.messages, m)
+	return nil
+}
+
+func TestStreamComponentsIssues(t *testing.T) {
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	cfg.Stream.BatchSize = 1
+	server, err := NewSemgrepIssuesServer(triageTestDB(t), cfg)
+	if err != nil {
+		t.Fatalf("NewSemgrepIssuesServer() error = %v", err)
+	}
+	tests := []struct {
+		name       string
+		components []*pbx.ComponentRequest
+		want       []string
+	}{
+		{name: "components", components: []*pbx.ComponentRequest{{Purl: "pkg:npm/a", Requirement: "1.0"}, {Purl: "pkg:npm/b"}},
+			want: []string{"component:pkg:npm/a@1.0", "progress:1/2", "component:pkg:npm/b@", "progress:2/2", "status:SUCCESS"}},
+		{name: "no components", want: []string{"status:FAILED"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &recordingServerStream{ctx: context.Background()}
+			if err := server.StreamComponentsIssues(&pbx.ComponentsIssuesStreamRequest{Components: tt.components}, stream); err != nil {
+				t.Fatalf("StreamComponentsIssues() error = %v", err)
+			}
+			var got []string
+			for _, m := range stream.messages {
+				switch {
+				case m.GetComponent() != nil:
+					got = append(got, "component:"+m.GetComponent().GetPurl()+"@"+m.GetComponent().GetRequirement())
+				case m.GetProgress() != nil:
+					got = append(got, fmt.Sprintf("progress:%d/%d", m.GetProgress().GetResolved(), m.GetProgress().GetTotal()))
+				default:
+					got = append(got, "status:"+m.GetStatus().GetStatus().String())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StreamComponentsIssues() messages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package taxonomy
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// Supported finding groupings.
+const (
+	GroupCWE   = "cwe"
+	GroupOWASP = "owasp"
+)
+
+// Uncategorised is the category of the findings whose rule has no CWE (or OWASP) category.
+const Uncategorised = "uncategorised"
+
+var (
+	cweRegexp   = regexp.MustCompile(`(?i)^\s*(?:CWE-)?0*(\d+)(?:\s*:.*)?$`)
+	owaspRegexp = regexp.MustCompile(`(?i)^\s*A0?(\d{1,2})(?::(\d{4}))?(?:\s*-.*)?$`)
+)
+
+// Filter selects the findings belonging to any of the listed CWE IDs (i.e. CWE-78) or OWASP categories.
+// An OWASP category without a year (i.e. A03) matches that category in every OWASP Top 10 edition.
+type Filter struct {
+	CWE   []string
+	OWASP []string
+}
+
+// ParseFilter builds a filter from the supplied CWE and OWASP values (each value can hold a comma separated list).
+func ParseFilter(cwe, owasp []string) (Filter, error) {
+	f := Filter{}
+	for _, value := range splitValues(cwe) {
+		id := CWEID(value)
+		if len(id) == 0 {
+			return Filter{}, fmt.Errorf("invalid CWE '%v' (expected i.e. CWE-78)", value)
+		}
+		f.CWE = append(f.CWE, id)
+	}
+	for _, value := range splitValues(owasp) {
+		id := OWASPID(value)
+		if len(id) == 0 {
+			return Filter{}, fmt.Errorf("invalid OWASP category '%v' (expected i.e. A03 or A03:2021)", value)
+		}
+		f.OWASP = append(f.OWASP, id)
+	}
+	return f, nil
+}
+
+// Empty checks if the filter has no criteria (in which case it selects everything).
+func (f Filter) Empty() bool {
+	return len(f.CWE) == 0 && len(f.OWASP) == 0
+}
+
+// Matches checks if the rule belongs to any of the filter categories.
+func (f Filter) Matches(rule *dtos.RuleMetadata) bool {
+	if f.Empty() {
+		return true
+	}
+	if rule == nil {
+		return false
+	}
+	for _, cwe := range rule.CWE {
+		id := CWEID(cwe)
+		for _, want := range f.CWE {
+			if id == want {
+				return true
+			}
+		}
+	}
+	for _, owasp := range rule.OWASP {
+		id := OWASPID(owasp)
+		for _, want := range f.OWASP {
+			if id == want || strings.HasPrefix(id, want+":") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Apply removes the findings not matching the filter from the output (dropping any files left without findings).
+// It returns the number of findings removed.
+func (f Filter) Apply(output *dtos.SemgrepOutput) int {
+	removed := 0
+	if f.Empty() {
+		return removed
+	}
+	for i := range output.Purls {
+		item := &output.Purls[i]
+		var files []dtos.SemgrepFileIssues
+		for _, file := range item.Files {
+			var issues []dtos.IssueItem
+			for _, issue := range file.Issues {
+				if !f.Matches(issue.Rule) {
+					removed++
+					continue
+				}
+				issues = append(issues, issue)
+			}
+			if len(issues) > 0 {
+				file.Issues = issues
+				files = append(files, file)
+			}
+		}
+		item.Files = files
+	}
+	return removed
+}
+
+// ErrNoRuleMetadata is returned when findings are filtered or grouped by category, but none of them carry rule metadata.
+var ErrNoRuleMetadata = errors.New("none of the findings have rule metadata to filter or group by category " +
+	"(the rule catalog must be enabled with SEMGREP_RULE_CATALOG_ENABLED and imported with import-rules)")
+
+// CheckRuleMetadata makes sure the findings of the output can be filtered or grouped by category,
+// rather than all being dropped (or reported as uncategorised) because the rule catalog isn't available.
+// It fails if there are findings, but none of them carry rule metadata.
+func CheckRuleMetadata(output dtos.SemgrepOutput) error {
+	findings := false
+	for _, item := range output.Purls {
+		for _, file := range item.Files {
+			for _, issue := range file.Issues {
+				if issue.Rule != nil {
+					return nil
+				}
+				findings = true
+			}
+		}
+	}
+	if findings {
+		return ErrNoRuleMetadata
+	}
+	return nil
+}
+
+// ValidateGroupBy checks that the grouping is supported (empty means no grouping).
+func ValidateGroupBy(by string) error {
+	switch by {
+	case "", GroupCWE, GroupOWASP:
+		return nil
+	default:
+		return fmt.Errorf("unsupported grouping '%v'. Supported: %v, %v", by, GroupCWE, GroupOWASP)
+	}
+}
+
+// Group lists the findings of the output by CWE or OWASP category, ordered by descending number of findings.
+func Group(output dtos.SemgrepOutput, by string) ([]dtos.CategoryGroup, error) {
+	if err := ValidateGroupBy(by); err != nil || len(by) == 0 {
+		return nil, err
+	}
+	groups := make(map[string]*dtos.CategoryGroup)
+	for _, item := range output.Purls {
+		for _, file := range item.Files {
+			for _, issue := range file.Issues {
+				finding := dtos.CategoryFinding{Purl: item.Purl, Version: item.Version, FileMD5: file.File, Path: file.Path, Issue: issue}
+				categories := issueCategories(issue, by)
+				if len(categories) == 0 {
+					categories = []string{Uncategorised}
+				}
+				seen := make(map[string]bool)
+				for _, category := range categories {
+					id := categoryID(category, by)
+					if seen[id] {
+						continue
+					}
+					seen[id] = true
+					group, found := groups[id]
+					if !found {
+						group = &dtos.CategoryGroup{Category: id}
+						if category != id {
+							group.Name = category
+						}
+						groups[id] = group
+					}
+					group.Count++
+					group.Findings = append(group.Findings, finding)
+				}
+			}
+		}
+	}
+	result := make([]dtos.CategoryGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, *group)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Category < result[j].Category
+	})
+	return result, nil
+}
+
+// CWEID returns the normalised CWE ID of a CWE category (i.e. "CWE-078: OS Command Injection" -> "CWE-78"), or an empty string.
+func CWEID(category string) string {
+	match := cweRegexp.FindStringSubmatch(category)
+	if match == nil {
+		return ""
+	}
+	return "CWE-" + match[1]
+}
+
+// OWASPID returns the normalised OWASP ID of an OWASP category (i.e. "A3:2021 - Injection" -> "A03:2021"), or an empty string.
+func OWASPID(category string) string {
+	match := owaspRegexp.FindStringSubmatch(category)
+	if match == nil {
+		return ""
+	}
+	number, _ := strconv.Atoi(match[1])
+	if number == 0 {
+		return ""
+	}
+	id := fmt.Sprintf("A%02d", number)
+	if len(match[2]) > 0 {
+		id += ":" + match[2]
+	}
+	return id
+}
+
+// issueCategories returns the CWE or OWASP categories of the rule of an issue.
+func issueCategories(issue dtos.IssueItem, by string) []string {
+	if issue.Rule == nil {
+		return nil
+	}
+	if by == GroupOWASP {
+		return issue.Rule.OWASP
+	}
+	return issue.Rule.CWE
+}
+
+// categoryID returns the normalised ID of a category, falling back to the category itself if it cannot be parsed.
+func categoryID(category, by string) string {
+	id := ""
+	switch {
+	case category == Uncategorised:
+		return category
+	case by == GroupOWASP:
+		id = OWASPID(category)
+	default:
+		id = CWEID(category)
+	}
+	if len(id) == 0 {
+		return strings.TrimSpace(category)
+	}
+	return id
+}
+
+// splitValues splits each value on commas, dropping any empty entries.
+func splitValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); len(v) > 0 {
+				result = append(result, v)
+			}
+		}
+	}
+	return result
+}
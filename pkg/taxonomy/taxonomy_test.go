@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package taxonomy
+
+import (
+	"reflect"
+	"testing"
+
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+var (
+	commandInjection = &dtos.RuleMetadata{
+		CWE:   []string{"CWE-78: Improper Neutralization of Special Elements used in an OS Command ('OS Command Injection')"},
+		OWASP: []string{"A01:2017 - Injection", "A03:2021 - Injection"},
+	}
+	sqlInjection = &dtos.RuleMetadata{
+		CWE:   []string{"CWE-89: Improper Neutralization of Special Elements used in an SQL Command ('SQL Injection')"},
+		OWASP: []string{"A03:2021 - Injection"},
+	}
+	weakHash = &dtos.RuleMetadata{CWE: []string{"CWE-327: Use of a Broken or Risky Cryptographic Algorithm"}, OWASP: []string{"A02:2021 - Cryptographic Failures"}}
+)
+
+// testOutput returns a sample output with categorised and uncategorised findings.
+func testOutput() dtos.SemgrepOutput {
+	return dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{
+		{Purl: "pkg:pypi/app", Version: "1.0.0", Files: []dtos.SemgrepFileIssues{
+			{File: "abc123", Path: "app/db.py", Issues: []dtos.IssueItem{{RuleID: "sql", Rule: sqlInjection}, {RuleID: "style"}}},
+			{File: "def456", Path: "app/run.py", Issues: []dtos.IssueItem{{RuleID: "exec", Rule: commandInjection}}},
+		}},
+		{Purl: "pkg:pypi/crypto", Version: "2.0.0", Files: []dtos.SemgrepFileIssues{
+			{File: "0011aa", Path: "crypto/hash.py", Issues: []dtos.IssueItem{{RuleID: "md5", Rule: weakHash}}},
+		}},
+	}}
+}
+
+func TestIDs(t *testing.T) {
+	tests := []struct {
+		category string
+		cwe      string
+		owasp    string
+	}{
+		{category: "CWE-78: OS Command Injection", cwe: "CWE-78"},
+		{category: "cwe-078", cwe: "CWE-78"},
+		{category: "89", cwe: "CWE-89"},
+		{category: "A03:2021 - Injection", owasp: "A03:2021"},
+		{category: "a3", owasp: "A03"},
+		{category: "A10:2017", owasp: "A10:2017"},
+		{category: "A0", owasp: ""},
+		{category: "Injection", cwe: "", owasp: ""},
+	}
+	for _, tt := range tests {
+		if got := CWEID(tt.category); got != tt.cwe {
+			t.Errorf("CWEID(%v) = %v, want %v", tt.category, got, tt.cwe)
+		}
+		if got := OWASPID(tt.category); got != tt.owasp {
+			t.Errorf("OWASPID(%v) = %v, want %v", tt.category, got, tt.owasp)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		cwe     []string
+		owasp   []string
+		want    []string // remaining rule IDs
+		removed int
+		wantErr bool
+	}{
+		{name: "no filter", want: []string{"sql", "style", "exec", "md5"}},
+		{name: "cwe list", cwe: []string{"CWE-78, cwe-89"}, want: []string{"sql", "exec"}, removed: 2},
+		{name: "owasp any year", owasp: []string{"A3"}, want: []string{"sql", "exec"}, removed: 2},
+		{name: "owasp edition", owasp: []string{"A01:2017"}, want: []string{"exec"}, removed: 3},
+		{name: "cwe or owasp", cwe: []string{"CWE-327"}, owasp: []string{"A01:2017"}, want: []string{"exec", "md5"}, removed: 2},
+		{name: "bad cwe", cwe: []string{"injection"}, wantErr: true},
+		{name: "bad owasp", owasp: []string{"Injection"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFilter(tt.cwe, tt.owasp)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			output := testOutput()
+			if removed := f.Apply(&output); removed != tt.removed {
+				t.Errorf("Apply() removed = %v, want %v", removed, tt.removed)
+			}
+			var got []string
+			for _, item := range output.Purls {
+				for _, file := range item.Files {
+					if len(file.Issues) == 0 {
+						t.Errorf("Apply() left an empty file %v", file.Path)
+					}
+					for _, issue := range file.Issues {
+						got = append(got, issue.RuleID)
+					}
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckRuleMetadata(t *testing.T) {
+	uncategorised := dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{{Purl: "pkg:pypi/app", Files: []dtos.SemgrepFileIssues{
+		{File: "abc123", Issues: []dtos.IssueItem{{RuleID: "sql"}, {RuleID: "style"}}},
+	}}}}
+	tests := []struct {
+		name    string
+		output  dtos.SemgrepOutput
+		wantErr bool
+	}{
+		{name: "rule metadata", output: testOutput()},
+		{name: "no findings", output: dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{{Purl: "pkg:pypi/clean"}}}},
+		{name: "no rule metadata", output: uncategorised, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckRuleMetadata(tt.output); (err != nil) != tt.wantErr {
+				t.Errorf("CheckRuleMetadata() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGroup(t *testing.T) {
+	groups, err := Group(testOutput(), GroupOWASP)
+	if err != nil {
+		t.Fatalf("Group() error = %v", err)
+	}
+	var got []string
+	for _, g := range groups {
+		got = append(got, g.Category)
+	}
+	if want := []string{"A03:2021", "A01:2017", "A02:2021", Uncategorised}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Group(owasp) categories = %v, want %v", got, want)
+	}
+	if groups[0].Count != 2 || groups[0].Name != "A03:2021 - Injection" || groups[0].Findings[0].Path != "app/db.py" || groups[3].Name != "" {
+		t.Errorf("Group(owasp) first group = %+v", groups[0])
+	}
+	if groups, err = Group(testOutput(), GroupCWE); err != nil || len(groups) != 4 || groups[0].Category != "CWE-327" {
+		t.Errorf("Group(cwe) = %+v, %v", groups, err)
+	}
+	if groups, err = Group(testOutput(), ""); err != nil || groups != nil {
+		t.Errorf("Group() without grouping = %+v, %v", groups, err)
+	}
+	if _, err = Group(testOutput(), "file"); err == nil {
+		t.Errorf("Group(file) expected an error")
+	}
+}
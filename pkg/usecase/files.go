@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+	"scanoss.com/semgrep/pkg/models"
+)
+
+// GetFileIssues returns the Semgrep issues of the given files, skipping the purl to URL resolution entirely.
+// Each file's path is read for its URL hash if supplied, otherwise from the first URL the file is known under.
+// Every requested file is returned (once), even if it has no issues.
+func (d SemgrepUseCase) GetFileIssues(ctx context.Context, s *zap.SugaredLogger, files []dtos.FileQuery) (dtos.FileIssuesOutput, error) {
+	queries, err := normaliseFileQueries(files)
+	if err != nil {
+		return dtos.FileIssuesOutput{}, err
+	}
+	md5s := make([]string, 0, len(queries))
+	var pairs []string // file-url pairs, for the files with a URL hash
+	for _, q := range queries {
+		md5s = append(md5s, q.FileMD5)
+		if len(q.URLHash) > 0 {
+			pairs = append(pairs, fmt.Sprintf("%s-%s", q.FileMD5, q.URLHash))
+		}
+	}
+	semgrep := models.QueryBulkSemgrepLDB(map[string][]string{"files": md5s})
+	urlPaths := models.QueryBulkFilePairsLDB(pairs)
+	anyPaths := models.QueryBulkFilePathsLDB(md5s)
+	var localFiles []models.LocalFile
+	if d.stores.Local != nil {
+		mergeLocalIssues(ctx, s, d.stores.Local, md5s, semgrep)
+		if localFiles, err = d.stores.Local.GetFilesByMD5(ctx, md5s); err != nil {
+			s.Warnf("Failed to query the local store files: %v", err)
+		}
+	}
+	output := dtos.FileIssuesOutput{Files: make([]dtos.SemgrepFileIssues, 0, len(queries))}
+	for _, q := range queries {
+		file := dtos.SemgrepFileIssues{File: q.FileMD5, Path: filePath(q, urlPaths, anyPaths, localFiles), Issues: []dtos.IssueItem{}}
+		for _, issue := range semgrep[q.FileMD5] {
+			file.Issues = append(file.Issues, dtos.IssueItem{RuleID: issue.RuleID, From: issue.From, To: issue.To, Severity: issue.Severity})
+		}
+		output.Files = append(output.Files, file)
+	}
+	if d.stores.Rules != nil {
+		annotateRules(ctx, s, d.stores.Rules, dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{{Files: output.Files}}})
+	}
+	return output, nil
+}
+
+// normaliseFileQueries validates the requested file MD5s and URL hashes (lower casing them) and removes any duplicates.
+func normaliseFileQueries(files []dtos.FileQuery) ([]dtos.FileQuery, error) {
+	if len(files) == 0 {
+		return nil, se.NewBadRequestError("Request validation failed: no files supplied", nil)
+	}
+	seen := make(map[dtos.FileQuery]bool, len(files))
+	queries := make([]dtos.FileQuery, 0, len(files))
+	for _, f := range files {
+		q := dtos.FileQuery{FileMD5: strings.ToLower(strings.TrimSpace(f.FileMD5)), URLHash: strings.ToLower(strings.TrimSpace(f.URLHash))}
+		if !isMD5(q.FileMD5) {
+			return nil, se.NewBadRequestError(fmt.Sprintf("Request validation failed: invalid file MD5 '%v'", f.FileMD5), nil)
+		}
+		if len(q.URLHash) > 0 && !isMD5(q.URLHash) {
+			return nil, se.NewBadRequestError(fmt.Sprintf("Request validation failed: invalid URL hash '%v'", f.URLHash), nil)
+		}
+		if !seen[q] {
+			seen[q] = true
+			queries = append(queries, q)
+		}
+	}
+	return queries, nil
+}
+
+// filePath returns the path of the queried file, preferring its path within the requested URL (KB first, then the local store).
+// The URL paths are keyed by <file md5>-<url md5> pair, as the same file can be requested within several URLs.
+func filePath(q dtos.FileQuery, urlPaths, anyPaths map[string]string, localFiles []models.LocalFile) string {
+	if len(q.URLHash) > 0 {
+		if path, found := urlPaths[fmt.Sprintf("%s-%s", q.FileMD5, q.URLHash)]; found {
+			return path
+		}
+		for _, f := range localFiles {
+			if f.FileMD5 == q.FileMD5 && f.URLHash == q.URLHash {
+				return f.Path
+			}
+		}
+	}
+	if path, found := anyPaths[q.FileMD5]; found {
+		return path
+	}
+	for _, f := range localFiles {
+		if f.FileMD5 == q.FileMD5 {
+			return f.Path
+		}
+	}
+	return ""
+}
+
+// isMD5 checks if the value is a hex encoded MD5.
+func isMD5(value string) bool {
+	_, err := hex.DecodeString(value)
+	return len(value) == 32 && err == nil
+}
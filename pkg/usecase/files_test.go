@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+	"scanoss.com/semgrep/pkg/models"
+)
+
+func TestGetFileIssues(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	local := models.NewLocalStoreModel(db)
+	if err = local.CreateTables(ctx); err != nil {
+		t.Fatalf("CreateTables() error = %v", err)
+	}
+	const (
+		url1  = "11111111111111111111111111111111"
+		url2  = "22222222222222222222222222222222"
+		file1 = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		file2 = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	)
+	for _, url := range []string{url1, url2} {
+		err = local.Import(ctx, models.LocalURL{URLHash: url, PurlName: "acme/tool", Version: url[:1], ImportedAt: time.Now()},
+			[]models.LocalFile{{URLHash: url, FileMD5: file1, Path: url[:1] + "/main.py"}},
+			[]models.SemgrepItem{{MD5: file1, RuleID: "python.exec", From: "1", To: "2", Severity: "ERROR"}})
+		if err != nil {
+			t.Fatalf("Import() error = %v", err)
+		}
+	}
+	uc := NewSemgrep(db, SemgrepStores{Local: local})
+	output, err := uc.GetFileIssues(ctx, zlog.S, []dtos.FileQuery{
+		{FileMD5: file1, URLHash: url2}, {FileMD5: file1}, {FileMD5: file2}, {FileMD5: " " + file1 + " ", URLHash: url2},
+	})
+	if err != nil {
+		t.Fatalf("GetFileIssues() error = %v", err)
+	}
+	files := output.Files
+	if len(files) != 3 || files[0].Path != "2/main.py" || files[1].Path != "1/main.py" || len(files[0].Issues) != 1 || files[0].Issues[0].RuleID != "python.exec" {
+		t.Errorf("GetFileIssues() = %+v", files)
+	}
+	if files[2].File != file2 || files[2].Path != "" || files[2].Issues == nil || len(files[2].Issues) != 0 {
+		t.Errorf("GetFileIssues() clean file = %+v", files[2])
+	}
+	for _, bad := range [][]dtos.FileQuery{nil, {{FileMD5: "main.py"}}, {{FileMD5: file1, URLHash: "zz"}}} {
+		if _, err = uc.GetFileIssues(ctx, zlog.S, bad); err == nil {
+			t.Errorf("GetFileIssues(%v) expected an error", bad)
+		}
+	}
+}
+
+func TestFilePath(t *testing.T) {
+	const (
+		url1  = "11111111111111111111111111111111"
+		url2  = "22222222222222222222222222222222"
+		url3  = "33333333333333333333333333333333"
+		file1 = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	)
+	urlPaths := map[string]string{file1 + "-" + url1: "1/main.py", file1 + "-" + url2: "2/main.py"}
+	anyPaths := map[string]string{file1: "any/main.py"}
+	localFiles := []models.LocalFile{{URLHash: url3, FileMD5: file1, Path: "3/main.py"}}
+	tests := []struct {
+		query dtos.FileQuery
+		want  string
+	}{
+		{query: dtos.FileQuery{FileMD5: file1, URLHash: url1}, want: "1/main.py"},
+		{query: dtos.FileQuery{FileMD5: file1, URLHash: url2}, want: "2/main.py"},
+		{query: dtos.FileQuery{FileMD5: file1, URLHash: url3}, want: "3/main.py"},
+		{query: dtos.FileQuery{FileMD5: file1}, want: "any/main.py"},
+	}
+	for _, tt := range tests {
+		if got := filePath(tt.query, urlPaths, anyPaths, localFiles); got != tt.want {
+			t.Errorf("filePath(%+v) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
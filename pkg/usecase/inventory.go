@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+)
+
+// Inventory is the set of file MD5s present in a codebase. Lookups against an inventory only report the findings
+// of the component files it contains, along with how much of each component is present.
+type Inventory map[string]bool
+
+// NewInventory builds an inventory from a list of file MD5s, validating them.
+func NewInventory(md5s []string) (Inventory, error) {
+	inventory := make(Inventory, len(md5s))
+	for _, md5 := range md5s {
+		value := strings.ToLower(strings.TrimSpace(md5))
+		if !isMD5(value) {
+			return nil, se.NewBadRequestError(fmt.Sprintf("Request validation failed: invalid inventory file MD5 '%v'", md5), nil)
+		}
+		inventory[value] = true
+	}
+	return inventory, nil
+}
+
+// presence reports how many of the distinct component files (from all its selected URLs) are in the inventory.
+func (inv Inventory) presence(urlFiles [][]string) *dtos.Presence {
+	distinct := make(map[string]bool)
+	for _, files := range urlFiles {
+		for _, md5 := range files {
+			distinct[md5] = true
+		}
+	}
+	p := &dtos.Presence{}
+	for md5 := range distinct {
+		if inv[md5] {
+			p.Files++
+		}
+	}
+	if len(distinct) > 0 {
+		p.Percent = math.Round(float64(p.Files)*100/float64(len(distinct))*100) / 100
+	}
+	return p
+}
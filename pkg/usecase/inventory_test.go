@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+	"scanoss.com/semgrep/pkg/models"
+)
+
+func TestGetIssuesInInventory(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	local := models.NewLocalStoreModel(db)
+	if err = local.CreateTables(ctx); err != nil {
+		t.Fatalf("CreateTables() error = %v", err)
+	}
+	const (
+		vendored = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		missing  = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+		clean    = "cccccccccccccccccccccccccccccccc"
+	)
+	err = local.Import(ctx, models.LocalURL{URLHash: "url1", PurlName: "acme/tool", PurlType: "github", Version: "1.0.0", ImportedAt: time.Now()},
+		[]models.LocalFile{{URLHash: "url1", FileMD5: vendored, Path: "src/a.c"}, {URLHash: "url1", FileMD5: missing, Path: "src/b.c"}, {URLHash: "url1", FileMD5: clean, Path: "src/c.c"}},
+		[]models.SemgrepItem{{MD5: vendored, RuleID: "c.rule", From: "1", To: "2", Severity: "ERROR"}, {MD5: missing, RuleID: "c.rule", From: "3", To: "4", Severity: "ERROR"}})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	uc := NewSemgrep(db, SemgrepStores{Local: local})
+	components := []dtos.ComponentDTO{{Purl: "pkg:github/acme/tool", Requirement: "1.0.0"}, {Purl: "pkg:github/acme/unknown"}}
+
+	output, err := uc.GetIssues(ctx, zlog.S, components)
+	if err != nil || len(output.Purls) != 2 || len(output.Purls[0].Files) != 2 || output.Purls[0].AnalysedFiles != 3 || output.Purls[0].Presence != nil {
+		t.Fatalf("GetIssues() = %+v, %v", output, err)
+	}
+	inventory, err := NewInventory([]string{" AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA", clean, "dddddddddddddddddddddddddddddddd"})
+	if err != nil {
+		t.Fatalf("NewInventory() error = %v", err)
+	}
+	output, err = uc.GetIssuesInInventory(ctx, zlog.S, components, inventory)
+	if err != nil {
+		t.Fatalf("GetIssuesInInventory() error = %v", err)
+	}
+	item := output.Purls[0]
+	// Only the files present in the inventory are analysed (the findings are counted the same way)
+	if len(item.Files) != 1 || item.Files[0].File != vendored || item.AnalysedFiles != 2 {
+		t.Errorf("GetIssuesInInventory() files = %+v", item)
+	}
+	if want := (&dtos.Presence{Files: 2, Percent: 66.67}); !reflect.DeepEqual(item.Presence, want) {
+		t.Errorf("GetIssuesInInventory() presence = %+v, want %+v", item.Presence, want)
+	}
+	if output.Purls[1].Presence != nil {
+		t.Errorf("GetIssuesInInventory() presence of an unknown component = %+v", output.Purls[1].Presence)
+	}
+	if _, err = NewInventory([]string{"src/a.c"}); err == nil {
+		t.Errorf("NewInventory() expected an error for an invalid MD5")
+	}
+}
@@ -0,0 +1,291 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.uber.org/zap"
+	myconfig "scanoss.com/semgrep/pkg/config"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+	"scanoss.com/semgrep/pkg/models"
+)
+
+const (
+	// jobPollInterval is how often idle workers check for queued jobs.
+	jobPollInterval = time.Second
+	// jobCleanupInterval is how often the jobs past their retention are removed.
+	jobCleanupInterval = time.Hour
+)
+
+// JobRunner processes the queued batch jobs using a bounded pool of workers, and removes the jobs past their retention.
+// Running jobs are leased to the runner that claimed them, which keeps them alive with heartbeats, so several
+// server replicas can share the job tables: only the jobs of a runner that stopped heartbeating are queued again.
+type JobRunner struct {
+	jobs      *models.JobModel
+	semgrep   *SemgrepUseCase
+	owner     string        // Identifies this runner as the owner of the jobs it claims
+	workers   int           // Number of jobs processed at the same time
+	batchSize int           // Number of components resolved at a time (between progress updates)
+	retention time.Duration // How long finished jobs are kept (forever if zero)
+	lease     time.Duration // How long a running job is held without a heartbeat
+	cancel    context.CancelFunc
+	running   sync.WaitGroup // Background goroutines started by Start
+}
+
+// NewJobRunner creates a new Job Runner, looking up the job components with the given Semgrep Use Case.
+func NewJobRunner(db *sqlx.DB, semgrep *SemgrepUseCase, config *myconfig.ServerConfig) (*JobRunner, error) {
+	retention, err := JobRetention(config)
+	if err != nil {
+		return nil, err
+	}
+	lease, err := JobLease(config)
+	if err != nil {
+		return nil, err
+	}
+	runner := &JobRunner{jobs: models.NewJobModel(db), semgrep: semgrep, owner: jobOwner(), workers: 1, batchSize: DefaultStreamBatchSize,
+		retention: retention, lease: lease}
+	if config != nil {
+		runner.workers = max(config.Jobs.Workers, 1)
+		if config.Stream.BatchSize > 0 {
+			runner.batchSize = config.Stream.BatchSize
+		}
+	}
+	return runner, nil
+}
+
+// jobOwner returns a unique identifier for a job runner, prefixed with the host name to help tracing jobs to replicas.
+func jobOwner() string {
+	if host, err := os.Hostname(); err == nil && len(host) > 0 {
+		return host + "-" + uuid.NewString()
+	}
+	return uuid.NewString()
+}
+
+// Start puts the jobs whose lease expired (i.e. interrupted by a crash) back in the queue and starts the workers in the background,
+// along with a periodic check for expired leases. The workers stop when the context is cancelled or Stop is called,
+// returning the jobs they were processing to the queue.
+func (r *JobRunner) Start(ctx context.Context) error {
+	requeued, err := r.RequeueExpired(ctx)
+	if err != nil {
+		return err
+	}
+	if requeued > 0 {
+		zlog.S.Infof("Requeued %v interrupted job(s)", requeued)
+	}
+	ctx, r.cancel = context.WithCancel(ctx)
+	background := []func(context.Context){r.reclaim}
+	for i := 0; i < r.workers; i++ {
+		background = append(background, r.work)
+	}
+	if r.retention > 0 {
+		background = append(background, r.cleanup)
+	}
+	for _, run := range background {
+		r.running.Add(1)
+		go func() {
+			defer r.running.Done()
+			run(ctx)
+		}()
+	}
+	zlog.S.Infof("Started %v job worker(s)", r.workers)
+	return nil
+}
+
+// Stop stops the workers and waits for them to return the jobs they were processing to the queue.
+// It must be called before closing the DB connection.
+func (r *JobRunner) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.running.Wait()
+	zlog.S.Infof("Stopped job worker(s)")
+}
+
+// work processes queued jobs until the context is cancelled.
+func (r *JobRunner) work(ctx context.Context) {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+	for {
+		for r.RunNext(ctx) {
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// cleanup periodically removes the jobs past their retention until the context is cancelled.
+func (r *JobRunner) cleanup(ctx context.Context) {
+	ticker := time.NewTicker(jobCleanupInterval)
+	defer ticker.Stop()
+	for {
+		if removed, err := r.RemoveExpired(ctx); err == nil && removed > 0 {
+			zlog.S.Infof("Removed %v expired job(s)", removed)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reclaim periodically puts the jobs whose lease expired back in the queue until the context is cancelled.
+func (r *JobRunner) reclaim(ctx context.Context) {
+	ticker := time.NewTicker(r.lease / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if requeued, err := r.RequeueExpired(ctx); err == nil && requeued > 0 {
+			zlog.S.Infof("Requeued %v job(s) with an expired lease", requeued)
+		}
+	}
+}
+
+// RequeueExpired puts the running jobs that have not had a heartbeat for longer than the lease back in the queue.
+func (r *JobRunner) RequeueExpired(ctx context.Context) (int64, error) {
+	return r.jobs.RequeueExpired(ctx, time.Now().UTC().Add(-r.lease))
+}
+
+// RemoveExpired removes the jobs (and their results) that finished longer ago than the retention period.
+func (r *JobRunner) RemoveExpired(ctx context.Context) (int64, error) {
+	if r.retention <= 0 {
+		return 0, nil
+	}
+	return r.jobs.DeleteFinishedBefore(ctx, time.Now().UTC().Add(-r.retention))
+}
+
+// RunNext claims and processes the next queued job. It returns false if there was no job to process.
+func (r *JobRunner) RunNext(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	job, ok, err := r.jobs.ClaimNext(ctx, r.owner, time.Now().UTC())
+	if err != nil || !ok {
+		return false
+	}
+	r.process(ctx, zlog.S.With("job", job.ID), job)
+	return true
+}
+
+// process looks up the issues of the job components, recording each component and the job progress as they are resolved.
+func (r *JobRunner) process(ctx context.Context, s *zap.SugaredLogger, job models.Job) {
+	s.Infof("Processing job %v (%v component(s))", job.ID, job.Total)
+	var components []dtos.ComponentDTO
+	err := json.Unmarshal([]byte(job.Request), &components)
+	if err != nil {
+		err = se.NewInternalError("Problem unmarshalling job request", err)
+	} else {
+		// The lookup stops if the job can no longer be kept alive (i.e. cancelled, or its lease was lost)
+		lookupCtx, stop := context.WithCancel(ctx)
+		go r.heartbeat(lookupCtx, stop, s, job.ID)
+		err = r.semgrep.StreamIssues(ContextWithProject(lookupCtx, job.Project), s, components, r.batchSize,
+			&jobStream{ctx: lookupCtx, jobs: r.jobs, id: job.ID, owner: r.owner})
+		stop()
+	}
+	state, message := models.JobCompleted, ""
+	switch {
+	case ctx.Err() != nil:
+		// Shutting down: put the job back in the queue for another worker
+		if err = r.jobs.Requeue(context.Background(), job.ID, r.owner); err != nil && !errors.Is(err, models.ErrJobNotRunning) {
+			s.Errorf("Failed to requeue interrupted job %v: %v", job.ID, err)
+		}
+		return
+	case errors.Is(err, models.ErrJobNotRunning), errors.Is(err, context.Canceled):
+		s.Infof("Job %v was cancelled or is no longer held by this worker", job.ID)
+		return
+	case err != nil:
+		state, message = models.JobFailed, "internal server error"
+		if serviceErr, ok := se.GetServiceError(err); ok {
+			message = serviceErr.Message
+		}
+		s.Errorf("Job %v failed: %v", job.ID, err)
+	}
+	if err = r.jobs.Finish(ctx, job.ID, r.owner, state, message, time.Now().UTC()); err != nil {
+		if errors.Is(err, models.ErrJobNotRunning) {
+			s.Infof("Job %v was cancelled or is no longer held by this worker", job.ID)
+		} else {
+			s.Errorf("Failed to record job %v as %v: %v", job.ID, state, err)
+		}
+		return
+	}
+	s.Infof("Job %v %v", job.ID, state)
+}
+
+// heartbeat extends the lease of a running job until the context is cancelled.
+// If the job is no longer running or held by this runner, the lookup is stopped.
+func (r *JobRunner) heartbeat(ctx context.Context, stop context.CancelFunc, s *zap.SugaredLogger, id string) {
+	ticker := time.NewTicker(r.lease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := r.jobs.Heartbeat(ctx, id, r.owner, time.Now().UTC()); errors.Is(err, models.ErrJobNotRunning) {
+			stop()
+			return
+		} else if err != nil && ctx.Err() == nil {
+			s.Warnf("Failed to record job %v heartbeat: %v", id, err)
+		}
+	}
+}
+
+// jobStream records the components of a job as they are resolved. Recording the progress of a job that is no longer
+// running (i.e. cancelled) or held by the owner fails with models.ErrJobNotRunning, stopping the lookup.
+type jobStream struct {
+	ctx   context.Context
+	jobs  *models.JobModel
+	id    string
+	owner string
+	seq   int
+}
+
+// Component records the issues of a resolved component.
+func (j *jobStream) Component(_ dtos.ComponentDTO, item dtos.SemgrepOutputItem) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return se.NewInternalError("Problem marshalling job result", err)
+	}
+	if err = j.jobs.AddResult(j.ctx, models.JobResult{JobID: j.id, Seq: j.seq, Purl: item.Purl, Result: string(data)}, j.owner); err != nil {
+		return err
+	}
+	j.seq++
+	return nil
+}
+
+// Progress records the progress of the job.
+func (j *jobStream) Progress(progress dtos.StreamProgress) error {
+	return j.jobs.UpdateProgress(j.ctx, j.id, j.owner, progress.Resolved, progress.Total, time.Now().UTC())
+}
@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+	myconfig "scanoss.com/semgrep/pkg/config"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+	"scanoss.com/semgrep/pkg/models"
+)
+
+const (
+	// DefaultJobResultsLimit is the number of components returned per page of job results, if not specified.
+	DefaultJobResultsLimit = 100
+	// MaxJobResultsLimit is the maximum number of components returned per page of job results.
+	MaxJobResultsLimit = 1000
+	// DefaultJobLease is how long a running job is held by a worker without a heartbeat, unless configured.
+	DefaultJobLease = 5 * time.Minute
+)
+
+// JobUseCase handles the asynchronous batch jobs submitted by clients. The jobs are processed by a JobRunner.
+type JobUseCase struct {
+	jobs      *models.JobModel
+	retention time.Duration // How long finished jobs are kept (forever if zero)
+}
+
+// NewJobs creates a new instance of the Job Use Case, using the retention policy of the server configuration.
+func NewJobs(db *sqlx.DB, config *myconfig.ServerConfig) (*JobUseCase, error) {
+	retention, err := JobRetention(config)
+	if err != nil {
+		return nil, err
+	}
+	return &JobUseCase{jobs: models.NewJobModel(db), retention: retention}, nil
+}
+
+// JobRetention returns how long finished jobs are kept according to the server configuration (zero to keep them forever).
+func JobRetention(config *myconfig.ServerConfig) (time.Duration, error) {
+	if config == nil || len(strings.TrimSpace(config.Jobs.Retention)) == 0 {
+		return 0, nil
+	}
+	retention, err := time.ParseDuration(strings.TrimSpace(config.Jobs.Retention))
+	if err != nil || retention < 0 {
+		return 0, fmt.Errorf("invalid job retention '%v' (expected a duration, i.e. 168h)", config.Jobs.Retention)
+	}
+	return retention, nil
+}
+
+// JobLease returns how long a running job is held by a worker without a heartbeat according to the server configuration
+// (DefaultJobLease if not set). Once the lease expires, the job is queued again for another worker.
+func JobLease(config *myconfig.ServerConfig) (time.Duration, error) {
+	if config == nil || len(strings.TrimSpace(config.Jobs.Lease)) == 0 {
+		return DefaultJobLease, nil
+	}
+	lease, err := time.ParseDuration(strings.TrimSpace(config.Jobs.Lease))
+	if err != nil || lease <= 0 {
+		return 0, fmt.Errorf("invalid job lease '%v' (expected a positive duration, i.e. 5m)", config.Jobs.Lease)
+	}
+	return lease, nil
+}
+
+// Create queues a new job looking up the issues of the given components, scoped to the project of the request context.
+// Components without a purl are left out.
+func (d JobUseCase) Create(ctx context.Context, s *zap.SugaredLogger, components []dtos.ComponentDTO) (dtos.Job, error) {
+	valid := make([]dtos.ComponentDTO, 0, len(components))
+	for _, c := range components {
+		if len(strings.Split(c.Purl, "@")[0]) > 0 {
+			valid = append(valid, c)
+		}
+	}
+	if len(valid) == 0 {
+		return dtos.Job{}, se.NewBadRequestError("Request validation failed: no components supplied", nil)
+	}
+	request, err := json.Marshal(valid)
+	if err != nil {
+		return dtos.Job{}, se.NewInternalError("Problem marshalling job request", err)
+	}
+	job := models.Job{
+		ID:        uuid.NewString(),
+		Project:   ProjectFromContext(ctx),
+		State:     models.JobQueued,
+		Request:   string(request),
+		Total:     len(valid),
+		CreatedAt: time.Now().UTC(),
+	}
+	if err = d.jobs.Create(ctx, job); err != nil {
+		return dtos.Job{}, se.NewInternalError("Problem creating job", err)
+	}
+	s.Infof("Queued job %v for %v component(s)", job.ID, job.Total)
+	return jobToDTO(job, d.retention), nil
+}
+
+// Get retrieves the status of a job by ID, as long as it belongs to the project of the request context.
+func (d JobUseCase) Get(ctx context.Context, _ *zap.SugaredLogger, id string) (dtos.Job, error) {
+	job, err := d.get(ctx, id)
+	if err != nil {
+		return dtos.Job{}, err
+	}
+	return jobToDTO(job, d.retention), nil
+}
+
+// get retrieves a job by ID. Jobs of other projects than the one of the request context are reported as not found.
+func (d JobUseCase) get(ctx context.Context, id string) (models.Job, error) {
+	job, err := d.jobs.Get(ctx, strings.TrimSpace(id))
+	if err == nil && job.Project != ProjectFromContext(ctx) {
+		err = models.ErrJobNotFound
+	}
+	if err != nil {
+		return models.Job{}, jobError(id, err)
+	}
+	return job, nil
+}
+
+// Cancel cancels a queued or running job of the request context project, returning its status.
+// Running jobs stop once their current batch of components is resolved.
+func (d JobUseCase) Cancel(ctx context.Context, s *zap.SugaredLogger, id string) (dtos.Job, error) {
+	id = strings.TrimSpace(id)
+	if _, err := d.get(ctx, id); err != nil {
+		return dtos.Job{}, err
+	}
+	if err := d.jobs.Cancel(ctx, id, time.Now().UTC()); err != nil {
+		if errors.Is(err, models.ErrJobNotRunning) {
+			job, _ := d.Get(ctx, s, id)
+			return dtos.Job{}, se.NewBadRequestError(fmt.Sprintf("Job '%v' has already finished (%v)", id, job.State), err)
+		}
+		return dtos.Job{}, jobError(id, err)
+	}
+	s.Infof("Cancelled job %v", id)
+	return d.Get(ctx, s, id)
+}
+
+// Results returns a page of up to limit components of the job results (in request order) starting at the given cursor,
+// along with the cursor of the next page: the last returned result, or the given cursor if there are no new results yet.
+// The results of a running job are partial, so pages are polled using the cursor until the job state reports it has finished
+// and a page comes back empty. Only the jobs of the request context project are returned.
+func (d JobUseCase) Results(ctx context.Context, s *zap.SugaredLogger, id, cursor string, limit int) (dtos.SemgrepOutput, string, error) {
+	job, err := d.Get(ctx, s, id)
+	if err != nil {
+		return dtos.SemgrepOutput{}, "", err
+	}
+	afterSeq := -1
+	if len(cursor) > 0 {
+		if afterSeq, err = strconv.Atoi(cursor); err != nil || afterSeq < 0 {
+			return dtos.SemgrepOutput{}, "", se.NewBadRequestError("Request validation failed: invalid cursor", err)
+		}
+	}
+	switch {
+	case limit < 0:
+		return dtos.SemgrepOutput{}, "", se.NewBadRequestError(fmt.Sprintf("Request validation failed: invalid limit %d", limit), nil)
+	case limit == 0:
+		limit = DefaultJobResultsLimit
+	case limit > MaxJobResultsLimit:
+		limit = MaxJobResultsLimit
+	}
+	results, err := d.jobs.Results(ctx, job.ID, afterSeq, limit)
+	if err != nil {
+		return dtos.SemgrepOutput{}, "", se.NewInternalError("Problem retrieving job results", err)
+	}
+	next := cursor
+	if len(results) > 0 {
+		next = strconv.Itoa(results[len(results)-1].Seq)
+	}
+	output := dtos.SemgrepOutput{Purls: make([]dtos.SemgrepOutputItem, 0, len(results))}
+	for _, r := range results {
+		var item dtos.SemgrepOutputItem
+		if err = json.Unmarshal([]byte(r.Result), &item); err != nil {
+			return dtos.SemgrepOutput{}, "", se.NewInternalError("Problem unmarshalling job result", err)
+		}
+		output.Purls = append(output.Purls, item)
+	}
+	return output, next, nil
+}
+
+// jobError converts a job model error into a service error.
+func jobError(id string, err error) error {
+	if errors.Is(err, models.ErrJobNotFound) {
+		return se.NewNotFoundError(fmt.Sprintf("Job '%v' not found", id))
+	}
+	return se.NewInternalError("Problem accessing jobs", err)
+}
+
+// jobToDTO converts a job table row into a job DTO, including when it expires under the given retention.
+func jobToDTO(j models.Job, retention time.Duration) dtos.Job {
+	job := dtos.Job{
+		ID:        j.ID,
+		Project:   j.Project,
+		State:     j.State,
+		Resolved:  j.Resolved,
+		Total:     j.Total,
+		Message:   j.Message,
+		CreatedAt: j.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if j.StartedAt.Valid {
+		job.StartedAt = j.StartedAt.Time.UTC().Format(time.RFC3339)
+	}
+	if j.FinishedAt.Valid {
+		job.FinishedAt = j.FinishedAt.Time.UTC().Format(time.RFC3339)
+		if retention > 0 {
+			job.ExpiresAt = j.FinishedAt.Time.Add(retention).UTC().Format(time.RFC3339)
+		}
+	}
+	return job
+}
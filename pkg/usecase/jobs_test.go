@@ -0,0 +1,303 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	myconfig "scanoss.com/semgrep/pkg/config"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+	"scanoss.com/semgrep/pkg/models"
+)
+
+// httpCode returns the HTTP code of a service error (0 if not a service error).
+func httpCode(err error) int {
+	if serviceErr, ok := se.GetServiceError(err); ok {
+		return serviceErr.GetHTTPCode()
+	}
+	return 0
+}
+
+func TestJobs(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	db.SetMaxOpenConns(1) // keep the in-memory DB alive across calls
+	defer models.CloseDB(db)
+	if err = models.NewJobModel(db).CreateTables(ctx); err != nil {
+		t.Fatalf("CreateTables() error = %v", err)
+	}
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	cfg.Stream.BatchSize = 1
+	uc, err := NewJobs(db, cfg)
+	if err != nil {
+		t.Fatalf("NewJobs() error = %v", err)
+	}
+	runner, err := NewJobRunner(db, NewSemgrep(db, SemgrepStores{}), cfg)
+	if err != nil {
+		t.Fatalf("NewJobRunner() error = %v", err)
+	}
+	s := zlog.S
+
+	if _, err = uc.Create(ctx, s, []dtos.ComponentDTO{{Purl: "@1.0"}}); httpCode(err) != http.StatusBadRequest {
+		t.Errorf("Create() without purls error = %v", err)
+	}
+	web := ContextWithProject(ctx, "web")
+	job, err := uc.Create(web, s, []dtos.ComponentDTO{{Purl: "pkg:npm/a"}, {Purl: ""}, {Purl: "pkg:npm/b", Requirement: "1.0"}})
+	if err != nil || job.State != models.JobQueued || job.Total != 2 || job.Project != "web" || len(job.StartedAt) > 0 {
+		t.Fatalf("Create() = %+v, %v", job, err)
+	}
+	queued, err := uc.Create(ctx, s, []dtos.ComponentDTO{{Purl: "pkg:npm/c"}})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if !runner.RunNext(ctx) {
+		t.Fatalf("RunNext() did not process the queued job")
+	}
+	if job, err = uc.Get(web, s, job.ID); err != nil || job.State != models.JobCompleted || job.Resolved != 2 || len(job.FinishedAt) == 0 || len(job.ExpiresAt) == 0 {
+		t.Errorf("Get() processed job = %+v, %v", job, err)
+	}
+	// Results are returned in request order, a page at a time
+	output, next, err := uc.Results(web, s, job.ID, "", 1)
+	if err != nil || len(output.Purls) != 1 || output.Purls[0].Purl != "pkg:npm/a" || len(next) == 0 {
+		t.Fatalf("Results() first page = %+v, %q, %v", output, next, err)
+	}
+	if output, next, err = uc.Results(web, s, job.ID, next, 1); err != nil || len(output.Purls) != 1 || output.Purls[0].Purl != "pkg:npm/b" || next != "1" {
+		t.Errorf("Results() last page = %+v, %q, %v", output, next, err)
+	}
+	// Once all the results have been read, the cursor stays put (completion is reported by the job state)
+	if output, next, err = uc.Results(web, s, job.ID, next, 1); err != nil || len(output.Purls) != 0 || next != "1" {
+		t.Errorf("Results() after the last page = %+v, %q, %v", output, next, err)
+	}
+	for _, bad := range []struct {
+		id     string
+		cursor string
+		limit  int
+		code   int
+	}{
+		{id: job.ID, cursor: "first", code: http.StatusBadRequest},
+		{id: job.ID, limit: -1, code: http.StatusBadRequest},
+		{id: "missing", code: http.StatusNotFound},
+	} {
+		if _, _, err = uc.Results(web, s, bad.id, bad.cursor, bad.limit); httpCode(err) != bad.code {
+			t.Errorf("Results(%v, %q, %v) error = %v, want HTTP %v", bad.id, bad.cursor, bad.limit, err, bad.code)
+		}
+	}
+	// Jobs of other projects are not found
+	for _, other := range []context.Context{ctx, ContextWithProject(ctx, "mobile")} {
+		if _, err = uc.Get(other, s, job.ID); httpCode(err) != http.StatusNotFound {
+			t.Errorf("Get() other project error = %v", err)
+		}
+		if _, _, err = uc.Results(other, s, job.ID, "", 1); httpCode(err) != http.StatusNotFound {
+			t.Errorf("Results() other project error = %v", err)
+		}
+		if _, err = uc.Cancel(other, s, job.ID); httpCode(err) != http.StatusNotFound {
+			t.Errorf("Cancel() other project error = %v", err)
+		}
+	}
+	if _, err = uc.Cancel(web, s, queued.ID); httpCode(err) != http.StatusNotFound {
+		t.Errorf("Cancel() other project queued job error = %v", err)
+	}
+	// Only queued and running jobs can be cancelled, and cancelled jobs are never processed
+	if _, err = uc.Cancel(web, s, job.ID); httpCode(err) != http.StatusBadRequest {
+		t.Errorf("Cancel() completed job error = %v", err)
+	}
+	if _, err = uc.Cancel(ctx, s, "missing"); httpCode(err) != http.StatusNotFound {
+		t.Errorf("Cancel() missing job error = %v", err)
+	}
+	if queued, err = uc.Cancel(ctx, s, queued.ID); err != nil || queued.State != models.JobCancelled {
+		t.Errorf("Cancel() queued job = %+v, %v", queued, err)
+	}
+	if runner.RunNext(ctx) {
+		t.Errorf("RunNext() processed a cancelled job")
+	}
+	// Finished jobs are removed once past their retention
+	runner.retention = time.Nanosecond
+	if removed, err := runner.RemoveExpired(ctx); err != nil || removed != 2 {
+		t.Errorf("RemoveExpired() = %v, %v", removed, err)
+	}
+	if _, err = uc.Get(web, s, job.ID); httpCode(err) != http.StatusNotFound {
+		t.Errorf("Get() expired job error = %v", err)
+	}
+}
+
+func TestJobRunnerCancelled(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	db.SetMaxOpenConns(1) // keep the in-memory DB alive across calls
+	defer models.CloseDB(db)
+	model := models.NewJobModel(db)
+	if err = model.CreateTables(ctx); err != nil {
+		t.Fatalf("CreateTables() error = %v", err)
+	}
+	runner, err := NewJobRunner(db, NewSemgrep(db, SemgrepStores{}), nil)
+	if err != nil {
+		t.Fatalf("NewJobRunner() error = %v", err)
+	}
+	if err = model.Create(ctx, models.Job{ID: "1", State: models.JobQueued, Request: `[{"purl":"pkg:npm/a"},{"purl":"pkg:npm/b"}]`, Total: 2, CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	job, _, err := model.ClaimNext(ctx, runner.owner, time.Now())
+	if err != nil {
+		t.Fatalf("ClaimNext() error = %v", err)
+	}
+	// A job cancelled while running stops at its next progress update
+	if err = model.Cancel(ctx, job.ID, time.Now()); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	runner.process(ctx, zlog.S, job)
+	if job, err = model.Get(ctx, job.ID); err != nil || job.State != models.JobCancelled || job.Resolved != 0 {
+		t.Errorf("Get() cancelled job = %+v, %v", job, err)
+	}
+	// A job interrupted by a shutdown goes back in the queue
+	if err = model.Create(ctx, models.Job{ID: "2", State: models.JobQueued, Request: `[{"purl":"pkg:npm/c"}]`, Total: 1, CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if job, _, err = model.ClaimNext(ctx, runner.owner, time.Now()); err != nil || job.ID != "2" {
+		t.Fatalf("ClaimNext() = %v, %v", job.ID, err)
+	}
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	runner.process(cancelled, zlog.S, job)
+	if job, err = model.Get(ctx, job.ID); err != nil || job.State != models.JobQueued {
+		t.Errorf("Get() interrupted job = %+v, %v", job, err)
+	}
+	// Jobs held by another live runner are left alone, while those whose lease expired are queued again
+	if job, _, err = model.ClaimNext(ctx, "other", time.Now().UTC()); err != nil || job.ID != "2" {
+		t.Fatalf("ClaimNext() = %v, %v", job.ID, err)
+	}
+	if requeued, err := runner.RequeueExpired(ctx); err != nil || requeued != 0 {
+		t.Errorf("RequeueExpired() live job = %v, %v", requeued, err)
+	}
+	runner.process(ctx, zlog.S, job)
+	if job, err = model.Get(ctx, job.ID); err != nil || job.State != models.JobRunning || job.Owner != "other" {
+		t.Errorf("Get() job held by another runner = %+v, %v", job, err)
+	}
+	if err = model.Heartbeat(ctx, job.ID, "other", time.Now().UTC().Add(-2*DefaultJobLease)); err != nil {
+		t.Fatalf("Heartbeat() error = %v", err)
+	}
+	if requeued, err := runner.RequeueExpired(ctx); err != nil || requeued != 1 {
+		t.Errorf("RequeueExpired() expired job = %v, %v", requeued, err)
+	}
+	if job, err = model.Get(ctx, job.ID); err != nil || job.State != models.JobQueued || job.Owner != "" {
+		t.Errorf("Get() expired job = %+v, %v", job, err)
+	}
+}
+
+func TestJobRetention(t *testing.T) {
+	tests := []struct {
+		retention string
+		want      time.Duration
+		wantErr   bool
+	}{
+		{retention: "", want: 0},
+		{retention: "168h", want: 168 * time.Hour},
+		{retention: "30m", want: 30 * time.Minute},
+		{retention: "7d", wantErr: true},
+		{retention: "-1h", wantErr: true},
+	}
+	for _, tt := range tests {
+		cfg := &myconfig.ServerConfig{}
+		cfg.Jobs.Retention = tt.retention
+		got, err := JobRetention(cfg)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("JobRetention(%q) = %v, %v, want %v (error %v)", tt.retention, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestJobLease(t *testing.T) {
+	tests := []struct {
+		lease   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{lease: "", want: DefaultJobLease},
+		{lease: "90s", want: 90 * time.Second},
+		{lease: "0s", wantErr: true},
+		{lease: "-1m", wantErr: true},
+		{lease: "5", wantErr: true},
+	}
+	for _, tt := range tests {
+		cfg := &myconfig.ServerConfig{}
+		cfg.Jobs.Lease = tt.lease
+		got, err := JobLease(cfg)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("JobLease(%q) = %v, %v, want %v (error %v)", tt.lease, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestJobRunnerStop(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	db.SetMaxOpenConns(1) // keep the in-memory DB alive across calls
+	defer models.CloseDB(db)
+	model := models.NewJobModel(db)
+	if err = model.CreateTables(ctx); err != nil {
+		t.Fatalf("CreateTables() error = %v", err)
+	}
+	runner, err := NewJobRunner(db, NewSemgrep(db, SemgrepStores{}), nil)
+	if err != nil {
+		t.Fatalf("NewJobRunner() error = %v", err)
+	}
+	for _, id := range []string{"1", "2", "3"} {
+		if err = model.Create(ctx, models.Job{ID: id, State: models.JobQueued, Request: `[{"purl":"pkg:npm/a"}]`, Total: 1, CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if err = runner.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	// Once stopped, no job is left running: each has either been processed or returned to the queue
+	runner.Stop()
+	for _, id := range []string{"1", "2", "3"} {
+		if job, err := model.Get(ctx, id); err != nil || (job.State != models.JobCompleted && job.State != models.JobQueued) {
+			t.Errorf("Get(%v) after Stop() = %+v, %v", id, job, err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"scanoss.com/semgrep/pkg/models"
+	"scanoss.com/semgrep/pkg/utils"
+)
+
+// localURLs returns the versions of the requested components imported into the local store.
+func localURLs(ctx context.Context, s *zap.SugaredLogger, local *models.LocalStoreModel, purls []utils.PurlReq) []models.AllURL {
+	names := make([]string, 0, len(purls))
+	for _, p := range purls {
+		names = append(names, p.Purl)
+	}
+	urls, err := local.GetURLsByPurlNames(ctx, names)
+	if err != nil {
+		s.Warnf("Failed to query the local store, only the KB will be used: %v", err)
+		return nil
+	}
+	return urls
+}
+
+// mergeLocalFiles adds the files of any locally imported URLs to the pivot results (updating them in place).
+// It returns the paths of the local files, keyed by file MD5.
+func mergeLocalFiles(ctx context.Context, s *zap.SugaredLogger, local *models.LocalStoreModel, urlHashes []string, files map[string][]string) map[string]string {
+	paths := make(map[string]string)
+	localFiles, err := local.GetFiles(ctx, urlHashes)
+	if err != nil {
+		s.Warnf("Failed to query the local store files: %v", err)
+		return paths
+	}
+	for _, f := range localFiles {
+		files[f.URLHash] = append(files[f.URLHash], f.FileMD5)
+		paths[f.FileMD5] = f.Path
+	}
+	return paths
+}
+
+// mergeLocalIssues adds the imported issues of the given files to the Semgrep results (updating them in place).
+// Files already holding issues from the KB keep them.
+func mergeLocalIssues(ctx context.Context, s *zap.SugaredLogger, local *models.LocalStoreModel, md5s []string, semgrep map[string][]models.SemgrepItem) {
+	if len(md5s) == 0 {
+		return
+	}
+	issues, err := local.GetIssues(ctx, md5s)
+	if err != nil {
+		s.Warnf("Failed to query the local store issues: %v", err)
+		return
+	}
+	for md5, found := range issues {
+		if len(semgrep[md5]) == 0 {
+			semgrep[md5] = found
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/models"
+	"scanoss.com/semgrep/pkg/utils"
+)
+
+func TestLocalStoreMerge(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	local := models.NewLocalStoreModel(db)
+	if err = local.CreateTables(ctx); err != nil {
+		t.Fatalf("CreateTables() error = %v", err)
+	}
+	err = local.Import(ctx, models.LocalURL{URLHash: "url1", PurlName: "acme/tool", PurlType: "github", Version: "1.0.0"},
+		[]models.LocalFile{{URLHash: "url1", FileMD5: "aaa", Path: "main.py"}, {URLHash: "url1", FileMD5: "bbb", Path: "util.py"}},
+		[]models.SemgrepItem{{MD5: "aaa", RuleID: "python.exec", From: "1", To: "2", Severity: "ERROR"}, {MD5: "bbb", RuleID: "python.style", From: "3", To: "3", Severity: "INFO"}})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	urls := localURLs(ctx, zlog.S, local, []utils.PurlReq{{Purl: "acme/tool"}, {Purl: "other"}})
+	if len(urls) != 1 || urls[0].URLHash != "url1" || urls[0].Version != "1.0.0" {
+		t.Fatalf("localURLs() = %+v", urls)
+	}
+	files := map[string][]string{"kb1": {"ccc"}}
+	paths := mergeLocalFiles(ctx, zlog.S, local, []string{"kb1", "url1"}, files)
+	if !reflect.DeepEqual(files["url1"], []string{"aaa", "bbb"}) || len(files["kb1"]) != 1 || paths["bbb"] != "util.py" {
+		t.Errorf("mergeLocalFiles() = %v, paths = %v", files, paths)
+	}
+	kbIssue := []models.SemgrepItem{{MD5: "bbb", RuleID: "python.kb", Severity: "WARNING"}}
+	semgrep := map[string][]models.SemgrepItem{"bbb": kbIssue}
+	mergeLocalIssues(ctx, zlog.S, local, []string{"aaa", "bbb"}, semgrep)
+	if len(semgrep["aaa"]) != 1 || semgrep["aaa"][0].RuleID != "python.exec" || !reflect.DeepEqual(semgrep["bbb"], kbIssue) {
+		t.Errorf("mergeLocalIssues() = %+v", semgrep)
+	}
+}
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+)
+
+const (
+	// DefaultPageLimit is the number of issues (or files, if the issue details are omitted) returned per page, if not specified.
+	DefaultPageLimit = 500
+	// MaxPageLimit is the maximum number of issues (or files, if the issue details are omitted) returned per page.
+	MaxPageLimit = 10000
+)
+
+// pageCursor is the key of the last finding returned by a page: the path and MD5 of its file, and its fingerprint
+// (empty if the whole file was returned). The next page resumes right after it, so files or findings added to or
+// removed from the KB between pages are neither skipped nor repeated.
+type pageCursor struct {
+	Path        string `json:"p"`
+	File        string `json:"f"`
+	Fingerprint string `json:"i,omitempty"`
+}
+
+// GetComponentIssuesPage looks up the issues of a single component and returns a page of its files and issues,
+// with the masked fields left out. Files are returned in path (then file MD5) order, and their issues in fingerprint order.
+// Nothing is kept between pages: every page repeats the full component lookup (LDB, local store and annotations) and
+// then cuts out the requested page, so paging bounds the response size, not the lookup cost.
+func (d SemgrepUseCase) GetComponentIssuesPage(ctx context.Context, s *zap.SugaredLogger, component dtos.ComponentDTO, page dtos.PageRequest) (dtos.ComponentIssuesPage, error) {
+	cursor, err := decodePageCursor(page.Cursor)
+	if err != nil {
+		return dtos.ComponentIssuesPage{}, err
+	}
+	limit := page.Limit
+	switch {
+	case limit < 0:
+		return dtos.ComponentIssuesPage{}, se.NewBadRequestError(fmt.Sprintf("Request validation failed: invalid limit %d", limit), nil)
+	case limit == 0:
+		limit = DefaultPageLimit
+	case limit > MaxPageLimit:
+		limit = MaxPageLimit
+	}
+	output, err := d.GetIssues(ctx, s, []dtos.ComponentDTO{component})
+	if err != nil {
+		return dtos.ComponentIssuesPage{}, err
+	}
+	if len(output.Purls) == 0 {
+		return dtos.ComponentIssuesPage{}, se.NewNotFoundError("Component not found")
+	}
+	return pageComponent(output.Purls[0], cursor, limit, page.Mask), nil
+}
+
+// pageComponent returns the page of the component files following the cursor (nil for the first page), holding up to limit issues
+// (or files, if the issue details are omitted). A file that doesn't fit is split, continuing in the next page.
+func pageComponent(item dtos.SemgrepOutputItem, cursor *pageCursor, limit int, mask dtos.FieldMask) dtos.ComponentIssuesPage {
+	files := make([]dtos.SemgrepFileIssues, len(item.Files))
+	copy(files, item.Files)
+	sort.SliceStable(files, func(i, j int) bool {
+		return compareFileKey(files[i], pageCursor{Path: files[j].Path, File: files[j].File}) < 0
+	})
+	result := dtos.ComponentIssuesPage{TotalFiles: len(files)}
+	for i := range files {
+		result.TotalIssues += len(files[i].Issues)
+		files[i].Issues = slices.Clone(files[i].Issues)
+		sort.SliceStable(files[i].Issues, func(a, b int) bool { return files[i].Issues[a].Fingerprint < files[i].Issues[b].Fingerprint })
+	}
+	result.Component = item
+	result.Component.Files = []dtos.SemgrepFileIssues{}
+	var last *pageCursor // key of the last finding (or whole file) added to the page
+	used := 0
+	for _, file := range files {
+		if cursor != nil {
+			switch c := compareFileKey(file, *cursor); {
+			case c < 0, c == 0 && (mask.OmitIssues || len(cursor.Fingerprint) == 0):
+				continue // already returned
+			case c == 0:
+				file.Issues = issuesAfter(file.Issues, cursor.Fingerprint)
+			}
+		}
+		if !mask.OmitIssues && len(file.Issues) == 0 {
+			continue
+		}
+		if used >= limit {
+			result.NextCursor = encodePageCursor(*last)
+			break
+		}
+		if mask.OmitIssues {
+			result.Component.Files = append(result.Component.Files, file)
+			used++
+			last = &pageCursor{Path: file.Path, File: file.File}
+			continue
+		}
+		take := min(len(file.Issues), limit-used)
+		remaining := len(file.Issues) - take
+		file.Issues = file.Issues[:take]
+		result.Component.Files = append(result.Component.Files, file)
+		used += take
+		last = &pageCursor{Path: file.Path, File: file.File}
+		if remaining > 0 {
+			last.Fingerprint = file.Issues[take-1].Fingerprint
+			result.NextCursor = encodePageCursor(*last)
+			break
+		}
+	}
+	mask.Apply(result.Component.Files)
+	return result
+}
+
+// compareFileKey orders a file against a cursor key by path, then file MD5.
+func compareFileKey(file dtos.SemgrepFileIssues, key pageCursor) int {
+	if c := strings.Compare(file.Path, key.Path); c != 0 {
+		return c
+	}
+	return strings.Compare(file.File, key.File)
+}
+
+// issuesAfter returns the issues (in fingerprint order) following the given fingerprint.
+func issuesAfter(issues []dtos.IssueItem, fingerprint string) []dtos.IssueItem {
+	i := sort.Search(len(issues), func(i int) bool { return issues[i].Fingerprint > fingerprint })
+	return issues[i:]
+}
+
+// encodePageCursor returns the opaque form of a page cursor.
+func encodePageCursor(c pageCursor) string {
+	data, _ := json.Marshal(c) // plain strings always marshal
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageCursor parses an opaque page cursor (nil for the first page).
+func decodePageCursor(cursor string) (*pageCursor, error) {
+	if len(cursor) == 0 {
+		return nil, nil
+	}
+	c := &pageCursor{}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		err = json.Unmarshal(data, c)
+	}
+	if err != nil {
+		return nil, se.NewBadRequestError("Request validation failed: invalid cursor", err)
+	}
+	return c, nil
+}
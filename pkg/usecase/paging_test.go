@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// pageIssues returns issues of the given rules, fingerprinted by their rule ID.
+func pageIssues(rules ...string) []dtos.IssueItem {
+	var items []dtos.IssueItem
+	for _, rule := range rules {
+		items = append(items, dtos.IssueItem{RuleID: rule, Fingerprint: rule})
+	}
+	return items
+}
+
+func TestPageComponent(t *testing.T) {
+	item := dtos.SemgrepOutputItem{Purl: "pkg:npm/lodash", Version: "4.17.20", Files: []dtos.SemgrepFileIssues{
+		{File: "cc", Path: "c.js", Issues: pageIssues("c1")},
+		{File: "aa", Path: "a.js", Issues: pageIssues("a3", "a1", "a2")},
+		{File: "bb", Path: "b.js", Issues: pageIssues("b1")},
+	}}
+	tests := []struct {
+		name  string
+		limit int
+		mask  dtos.FieldMask
+		pages [][]dtos.SemgrepFileIssues
+	}{
+		{
+			name:  "split files",
+			limit: 2,
+			pages: [][]dtos.SemgrepFileIssues{
+				{{File: "aa", Path: "a.js", Issues: pageIssues("a1", "a2")}},
+				{{File: "aa", Path: "a.js", Issues: pageIssues("a3")}, {File: "bb", Path: "b.js", Issues: pageIssues("b1")}},
+				{{File: "cc", Path: "c.js", Issues: pageIssues("c1")}},
+			},
+		},
+		{
+			name:  "single page",
+			limit: 10,
+			mask:  dtos.FieldMask{OmitPaths: true},
+			pages: [][]dtos.SemgrepFileIssues{
+				{{File: "aa", Issues: pageIssues("a1", "a2", "a3")}, {File: "bb", Issues: pageIssues("b1")}, {File: "cc", Issues: pageIssues("c1")}},
+			},
+		},
+		{
+			name:  "counts only",
+			limit: 2,
+			mask:  dtos.FieldMask{OmitIssues: true},
+			pages: [][]dtos.SemgrepFileIssues{
+				{{File: "aa", Path: "a.js", Issues: []dtos.IssueItem{}, IssueCount: 3}, {File: "bb", Path: "b.js", Issues: []dtos.IssueItem{}, IssueCount: 1}},
+				{{File: "cc", Path: "c.js", Issues: []dtos.IssueItem{}, IssueCount: 1}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor := ""
+			for i, want := range tt.pages {
+				c, err := decodePageCursor(cursor)
+				if err != nil {
+					t.Fatalf("decodePageCursor(%v) error = %v", cursor, err)
+				}
+				page := pageComponent(item, c, tt.limit, tt.mask)
+				if page.TotalFiles != 3 || page.TotalIssues != 5 || page.Component.Purl != item.Purl {
+					t.Errorf("pageComponent() page %d totals = %v files, %v issues", i+1, page.TotalFiles, page.TotalIssues)
+				}
+				dtos.FieldMask{OmitPaths: tt.mask.OmitPaths}.Apply(want) // flag the expected paths as left out too
+				if !reflect.DeepEqual(page.Component.Files, want) {
+					t.Errorf("pageComponent() page %d = %+v, want %+v", i+1, page.Component.Files, want)
+				}
+				if last := i == len(tt.pages)-1; last != (len(page.NextCursor) == 0) {
+					t.Fatalf("pageComponent() page %d next cursor = '%v'", i+1, page.NextCursor)
+				}
+				cursor = page.NextCursor
+			}
+		})
+	}
+	if item.Files[1].Issues[0].RuleID != "a3" || item.Files[0].Path != "c.js" {
+		t.Errorf("pageComponent() modified the component files: %+v", item.Files)
+	}
+}
+
+func TestPageComponentChanges(t *testing.T) {
+	before := dtos.SemgrepOutputItem{Files: []dtos.SemgrepFileIssues{
+		{File: "aa", Path: "a.js", Issues: pageIssues("a1", "a2", "a3")},
+		{File: "bb", Path: "b.js", Issues: pageIssues("b1")},
+		{File: "dd", Path: "d.js", Issues: pageIssues("d1")},
+	}}
+	first := pageComponent(before, nil, 2, dtos.FieldMask{})
+	c, err := decodePageCursor(first.NextCursor)
+	if err != nil {
+		t.Fatalf("decodePageCursor() error = %v", err)
+	}
+	// The KB changes between pages: a file before the cursor disappears, and findings are added before and after it
+	after := dtos.SemgrepOutputItem{Files: []dtos.SemgrepFileIssues{
+		{File: "aa", Path: "a.js", Issues: pageIssues("a0", "a2", "a3")},
+		{File: "00", Path: "0.js", Issues: pageIssues("z1")},
+		{File: "cc", Path: "c.js", Issues: pageIssues("c1")},
+		{File: "dd", Path: "d.js", Issues: pageIssues("d1")},
+	}}
+	second := pageComponent(after, c, 10, dtos.FieldMask{})
+	want := []dtos.SemgrepFileIssues{
+		{File: "aa", Path: "a.js", Issues: pageIssues("a3")},
+		{File: "cc", Path: "c.js", Issues: pageIssues("c1")},
+		{File: "dd", Path: "d.js", Issues: pageIssues("d1")},
+	}
+	if !reflect.DeepEqual(second.Component.Files, want) || len(second.NextCursor) != 0 {
+		t.Errorf("pageComponent() after changes = %+v (next '%v'), want %+v", second.Component.Files, second.NextCursor, want)
+	}
+}
+
+func TestPageCursorErrors(t *testing.T) {
+	for _, cursor := range []string{"!", "LTE6MA", base64.RawURLEncoding.EncodeToString([]byte(`{"p":1}`))} {
+		if _, err := decodePageCursor(cursor); err == nil {
+			t.Errorf("cursor '%v' was accepted", cursor)
+		}
+	}
+	if c, err := decodePageCursor(""); err != nil || c != nil {
+		t.Errorf("decodePageCursor() empty cursor = %v, %v", c, err)
+	}
+}
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+	"scanoss.com/semgrep/pkg/dtos"
+	"scanoss.com/semgrep/pkg/models"
+)
+
+const (
+	peerPercentiles = 100 // Number of cut points stored per purl type
+
+	// DefaultPeerBatchSize is the number of KB URLs looked up in the LDB at a time when refreshing the peer statistics.
+	DefaultPeerBatchSize = 1000
+)
+
+// PeerStatsUseCase pre-computes the finding density distribution of every ecosystem in the KB.
+type PeerStatsUseCase struct {
+	allUrls      *models.AllUrlsModel
+	peers        *models.PeerStatsModel
+	queryPivot   func(keys []string) map[string][]string
+	querySemgrep func(items map[string][]string) map[string][]models.SemgrepItem
+}
+
+// NewPeerStats creates a new instance of the Peer Stats Use Case.
+func NewPeerStats(db *sqlx.DB) *PeerStatsUseCase {
+	return &PeerStatsUseCase{
+		allUrls:      models.NewAllURLModel(db, models.NewProjectModel(db)),
+		peers:        models.NewPeerStatsModel(db),
+		queryPivot:   models.QueryBulkPivotLDB,
+		querySemgrep: models.QueryBulkSemgrepLDB,
+	}
+}
+
+// Refresh walks all the mined URLs in the KB, computes their finding density (findings per analysed file)
+// and replaces the stored percentiles of each purl type. It returns the number of analysed components per purl type.
+func (p PeerStatsUseCase) Refresh(ctx context.Context, s *zap.SugaredLogger, batchSize int) (map[string]int, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultPeerBatchSize
+	}
+	densities := make(map[string][]float64)
+	after := ""
+	for {
+		page, err := p.allUrls.ListMinedURLs(ctx, after, batchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			break
+		}
+		purlTypes := make(map[string]string, len(page))
+		var hashes []string
+		for _, u := range page {
+			if _, found := purlTypes[u.URLHash]; !found && len(u.PurlType) > 0 {
+				purlTypes[u.URLHash] = u.PurlType
+				hashes = append(hashes, u.URLHash)
+			}
+		}
+		files := p.queryPivot(hashes)
+		issues := p.querySemgrep(files)
+		for _, hash := range hashes {
+			if len(files[hash]) == 0 {
+				continue // not analysed
+			}
+			findings := 0
+			for _, file := range files[hash] {
+				findings += len(issues[file])
+			}
+			densities[purlTypes[hash]] = append(densities[purlTypes[hash]], float64(findings)/float64(len(files[hash])))
+		}
+		after = page[len(page)-1].URLHash
+		s.Debugf("Processed %v URLs for peer stats (up to %v)", len(page), after)
+		if len(page) < batchSize {
+			break
+		}
+	}
+	now := time.Now().UTC()
+	counts := make(map[string]int, len(densities))
+	var stats []models.PeerStat
+	for purlType, values := range densities {
+		counts[purlType] = len(values)
+		for i, density := range peerCutPoints(values) {
+			stats = append(stats, models.PeerStat{PurlType: purlType, Percentile: i + 1, Density: density, Components: len(values), UpdatedAt: now})
+		}
+	}
+	if err := p.peers.Replace(ctx, stats); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// peerCutPoints sorts the densities and returns the density at each percentile (1 to 100).
+func peerCutPoints(densities []float64) []float64 {
+	sort.Float64s(densities)
+	cuts := make([]float64, peerPercentiles)
+	for i := range cuts {
+		index := int(math.Ceil(float64(i+1)*float64(len(densities))/peerPercentiles)) - 1
+		cuts[i] = densities[max(index, 0)]
+	}
+	return cuts
+}
+
+// peerRank returns the percentage of components with a lower density, using the (percentile ordered) statistics of a purl type.
+func peerRank(stats []models.PeerStat, density float64) int {
+	worseThan := 0
+	for _, stat := range stats {
+		if stat.Density < density {
+			worseThan = stat.Percentile
+		}
+	}
+	return worseThan
+}
+
+// annotatePeers ranks every analysed component in the output against the peer statistics of its purl type (updating it in place).
+// The purl types are supplied in the same order as the output components.
+func annotatePeers(ctx context.Context, s *zap.SugaredLogger, model *models.PeerStatsModel, output dtos.SemgrepOutput, purlTypes []string) {
+	var types []string
+	for _, t := range purlTypes {
+		if len(t) > 0 && !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+	stats, err := model.GetByTypes(ctx, types)
+	if err != nil {
+		s.Warnf("Failed to get peer stats, skipping peer ranks: %v", err)
+		return
+	}
+	for i := range output.Purls {
+		item := &output.Purls[i]
+		typeStats := stats[purlTypes[i]]
+		if item.AnalysedFiles == 0 || len(typeStats) == 0 {
+			continue
+		}
+		findings := 0
+		for _, file := range item.Files {
+			findings += len(file.Issues)
+		}
+		density := float64(findings) / float64(item.AnalysedFiles)
+		worseThan := peerRank(typeStats, density)
+		item.Peer = &dtos.PeerRank{
+			Ecosystem:  purlTypes[i],
+			Density:    math.Round(density*10000) / 10000,
+			WorseThan:  worseThan,
+			Components: typeStats[0].Components,
+			Summary:    fmt.Sprintf("worse than %d%% of %v packages", worseThan, purlTypes[i]),
+		}
+	}
+}
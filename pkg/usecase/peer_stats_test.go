@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+	"scanoss.com/semgrep/pkg/models"
+)
+
+func TestPeerCutPoints(t *testing.T) {
+	cuts := peerCutPoints([]float64{1, 0, 0, 0})
+	if len(cuts) != peerPercentiles || cuts[0] != 0 || cuts[74] != 0 || cuts[75] != 1 || cuts[99] != 1 {
+		t.Errorf("peerCutPoints() = %v", cuts)
+	}
+	var stats []models.PeerStat
+	for i, density := range cuts {
+		stats = append(stats, models.PeerStat{Percentile: i + 1, Density: density})
+	}
+	tests := []struct {
+		density float64
+		want    int
+	}{
+		{density: 0, want: 0},
+		{density: 0.5, want: 75},
+		{density: 1, want: 75},
+		{density: 2, want: 100},
+	}
+	for _, tt := range tests {
+		if got := peerRank(stats, tt.density); got != tt.want {
+			t.Errorf("peerRank(%v) = %v, want %v", tt.density, got, tt.want)
+		}
+	}
+}
+
+func TestPeerStatsRefresh(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	db.MustExec("CREATE TABLE mines (id INTEGER, purl_type TEXT);" +
+		"INSERT INTO mines VALUES (1, 'npm'), (2, 'pypi');" +
+		"CREATE TABLE all_urls (package_hash TEXT, purl_name TEXT, mine_id INTEGER, is_mined BOOLEAN);" +
+		"INSERT INTO all_urls VALUES ('u1', 'lodash', 1, true), ('u2', 'express', 1, true), ('u3', 'left-pad', 1, true)," +
+		" ('u4', 'requests', 2, true), ('u5', 'react', 1, false);")
+	uc := NewPeerStats(db)
+	if err = uc.peers.CreateTable(ctx); err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+	files := map[string][]string{"u1": {"f1", "f2"}, "u2": {"f3"}, "u4": {"f4"}} // u3 not analysed
+	uc.queryPivot = func(keys []string) map[string][]string {
+		found := make(map[string][]string)
+		for _, k := range keys {
+			if len(files[k]) > 0 {
+				found[k] = files[k]
+			}
+		}
+		return found
+	}
+	uc.querySemgrep = func(_ map[string][]string) map[string][]models.SemgrepItem {
+		return map[string][]models.SemgrepItem{"f1": {{RuleID: "a"}, {RuleID: "b"}}, "f3": {{RuleID: "c"}}}
+	}
+	counts, err := uc.Refresh(ctx, zlog.S, 2)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if !reflect.DeepEqual(counts, map[string]int{"npm": 2, "pypi": 1}) {
+		t.Errorf("Refresh() counts = %v", counts)
+	}
+	stats, err := uc.peers.GetByTypes(ctx, []string{"npm", "pypi"})
+	if err != nil || len(stats["npm"]) != peerPercentiles || len(stats["pypi"]) != peerPercentiles {
+		t.Fatalf("GetByTypes() = %v, %v", stats, err)
+	}
+	output := dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{
+		{Purl: "pkg:npm/left-pad", AnalysedFiles: 4, Files: []dtos.SemgrepFileIssues{{Issues: []dtos.IssueItem{{RuleID: "a"}, {RuleID: "b"}}}}},
+		{Purl: "pkg:npm/unknown"},
+		{Purl: "pkg:cargo/serde", AnalysedFiles: 3},
+	}}
+	annotatePeers(ctx, zlog.S, uc.peers, output, []string{"npm", "npm", "cargo"})
+	want := &dtos.PeerRank{Ecosystem: "npm", Density: 0.5, WorseThan: 0, Components: 2, Summary: "worse than 0% of npm packages"}
+	if !reflect.DeepEqual(output.Purls[0].Peer, want) {
+		t.Errorf("annotatePeers() = %+v, want %+v", output.Purls[0].Peer, want)
+	}
+	if output.Purls[1].Peer != nil || output.Purls[2].Peer != nil {
+		t.Errorf("annotatePeers() unexpected peer ranks: %+v, %+v", output.Purls[1].Peer, output.Purls[2].Peer)
+	}
+	output.Purls[0].Files[0].Issues = append(output.Purls[0].Files[0].Issues, dtos.IssueItem{RuleID: "c"}, dtos.IssueItem{RuleID: "d"}, dtos.IssueItem{RuleID: "e"})
+	annotatePeers(ctx, zlog.S, uc.peers, output, []string{"npm", "npm", "cargo"})
+	if output.Purls[0].Peer.WorseThan != 100 {
+		t.Errorf("annotatePeers() worse than = %v, want 100", output.Purls[0].Peer.WorseThan)
+	}
+}
+
+func TestPurlType(t *testing.T) {
+	tests := []struct {
+		query InternalQuery
+		want  string
+	}{
+		{query: InternalQuery{PurlName: "pkg:npm/lodash", SelectedURLS: []models.AllURL{{PurlType: "npm"}}}, want: "npm"},
+		{query: InternalQuery{PurlName: "pkg:github/madler/zlib"}, want: "github"},
+		{query: InternalQuery{PurlName: ""}, want: ""},
+	}
+	for _, tt := range tests {
+		if got := purlType(tt.query); got != tt.want {
+			t.Errorf("purlType(%+v) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
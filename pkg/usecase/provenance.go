@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"scanoss.com/semgrep/pkg/dtos"
+	"scanoss.com/semgrep/pkg/models"
+)
+
+// KBInfo returns the details of the KB snapshot the lookups are served from (nil if unknown).
+func (d SemgrepUseCase) KBInfo(ctx context.Context, s *zap.SugaredLogger) *dtos.KBInfo {
+	if d.stores.KB == nil {
+		return nil
+	}
+	return kbInfo(ctx, s, d.stores.KB)
+}
+
+// kbInfo reads the KB snapshot details from the given provenance source.
+func kbInfo(ctx context.Context, s *zap.SugaredLogger, kb KBProvenance) *dtos.KBInfo {
+	info, err := kb.Info(ctx)
+	if err != nil {
+		s.Warnf("Failed to read the KB provenance: %v", err)
+		return nil
+	}
+	if info == nil {
+		return nil
+	}
+	return &dtos.KBInfo{Version: info.Version, SnapshotDate: info.SnapshotDate, EngineVersion: info.EngineVersion, RulesetVersion: info.RulesetVersion}
+}
+
+// annotateProvenance sets the provenance of each analysed component (updating the output in place) and returns the KB snapshot details.
+// urls holds the URL hashes selected for each output component. Components imported into the local store report their import,
+// while KB components report their recorded scan, falling back to the KB snapshot for anything not recorded.
+func annotateProvenance(ctx context.Context, s *zap.SugaredLogger, kb KBProvenance, local *models.LocalStoreModel, output dtos.SemgrepOutput, urls [][]string) *dtos.KBInfo {
+	var hashes []string
+	for _, h := range urls {
+		hashes = append(hashes, h...)
+	}
+	var info *dtos.KBInfo
+	var kbScans, localScans map[string]models.ComponentScan
+	var err error
+	if kb != nil {
+		info = kbInfo(ctx, s, kb)
+		if kbScans, err = kb.ComponentScans(ctx, hashes); err != nil {
+			s.Warnf("Failed to read the KB component scans: %v", err)
+		}
+	}
+	if local != nil {
+		if localScans, err = local.ComponentScans(ctx, hashes); err != nil {
+			s.Warnf("Failed to read the local store component scans: %v", err)
+		}
+	}
+	for i := range output.Purls {
+		if i < len(urls) && len(urls[i]) > 0 {
+			output.Purls[i].Provenance = componentProvenance(urls[i], info, kbScans, localScans)
+		}
+	}
+	return info
+}
+
+// componentProvenance returns the provenance of a component from the scans of its first URL that has one recorded.
+func componentProvenance(urlHashes []string, info *dtos.KBInfo, kbScans, localScans map[string]models.ComponentScan) *dtos.Provenance {
+	for _, hash := range urlHashes {
+		if scan, found := localScans[hash]; found {
+			return &dtos.Provenance{Source: dtos.ProvenanceLocal, ScanDate: scan.ScanDate, EngineVersion: scan.EngineVersion, RulesetVersion: scan.RulesetVersion}
+		}
+		if scan, found := kbScans[hash]; found {
+			p := &dtos.Provenance{Source: dtos.ProvenanceKB, ScanDate: scan.ScanDate, EngineVersion: scan.EngineVersion, RulesetVersion: scan.RulesetVersion}
+			if info != nil {
+				p.ScanDate = valueOr(p.ScanDate, info.SnapshotDate)
+				p.EngineVersion = valueOr(p.EngineVersion, info.EngineVersion)
+				p.RulesetVersion = valueOr(p.RulesetVersion, info.RulesetVersion)
+			}
+			return p
+		}
+	}
+	if info == nil {
+		return nil
+	}
+	return &dtos.Provenance{Source: dtos.ProvenanceKB, ScanDate: info.SnapshotDate, EngineVersion: info.EngineVersion, RulesetVersion: info.RulesetVersion}
+}
+
+// valueOr returns the value, or the fallback if the value is empty.
+func valueOr(value, fallback string) string {
+	if len(value) == 0 {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+	"scanoss.com/semgrep/pkg/models"
+)
+
+func TestAnnotateProvenance(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	local := models.NewLocalStoreModel(db)
+	if err = local.CreateTables(ctx); err != nil {
+		t.Fatalf("CreateTables() error = %v", err)
+	}
+	imported := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	if err = local.Import(ctx, models.LocalURL{URLHash: "local1", PurlName: "acme/tool", Version: "1.0.0", EngineVersion: "1.90.0", ImportedAt: imported}, nil, nil); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	kb := models.NewKBManifestFile("../models/tests/semgrep-manifest.json")
+	output := dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{{Purl: "pkg:npm/a"}, {Purl: "pkg:npm/b"}, {Purl: "pkg:github/acme/tool"}, {Purl: "pkg:npm/missing"}}}
+	urls := [][]string{{"other"}, {"4d66775f503b1e76582e7e5b2ea54d92"}, {"local1"}, nil}
+
+	info := annotateProvenance(ctx, zlog.S, kb, local, output, urls)
+	if want := (&dtos.KBInfo{Version: "25.09", SnapshotDate: "2025-09-01", EngineVersion: "1.85.0", RulesetVersion: "semgrep-rules@2025-08-28"}); !reflect.DeepEqual(info, want) {
+		t.Errorf("annotateProvenance() info = %+v, want %+v", info, want)
+	}
+	want := []*dtos.Provenance{
+		{Source: dtos.ProvenanceKB, ScanDate: "2025-09-01", EngineVersion: "1.85.0", RulesetVersion: "semgrep-rules@2025-08-28"},
+		{Source: dtos.ProvenanceKB, ScanDate: "2025-08-30", EngineVersion: "1.85.0", RulesetVersion: "semgrep-rules@2025-08-15"},
+		{Source: dtos.ProvenanceLocal, ScanDate: "2025-10-01T12:00:00Z", EngineVersion: "1.90.0"},
+		nil,
+	}
+	for i := range want {
+		if !reflect.DeepEqual(output.Purls[i].Provenance, want[i]) {
+			t.Errorf("annotateProvenance() %v = %+v, want %+v", output.Purls[i].Purl, output.Purls[i].Provenance, want[i])
+		}
+	}
+	output.Purls[0].Provenance = nil
+	if info = annotateProvenance(ctx, zlog.S, nil, local, output, urls); info != nil || output.Purls[0].Provenance != nil {
+		t.Errorf("annotateProvenance() without a KB source = %+v, %+v", info, output.Purls[0].Provenance)
+	}
+}
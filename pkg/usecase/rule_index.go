@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	purlHelper "github.com/scanoss/go-purl-helper/pkg"
+	"go.uber.org/zap"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+	"scanoss.com/semgrep/pkg/models"
+)
+
+const (
+	// DefaultRuleIndexBatchSize is the number of KB URLs looked up in the LDB at a time when rebuilding the rule index.
+	DefaultRuleIndexBatchSize = 1000
+	// DefaultRuleSearchLimit is the number of component versions returned per rule search page, if not specified.
+	DefaultRuleSearchLimit = 100
+	// MaxRuleSearchLimit is the maximum number of component versions returned per rule search page.
+	MaxRuleSearchLimit = 1000
+)
+
+// RuleIndexUseCase maintains and searches the reverse index of rule IDs to the KB component versions with findings of them.
+type RuleIndexUseCase struct {
+	allUrls      *models.AllUrlsModel
+	index        *models.RuleIndexModel
+	queryPivot   func(keys []string) map[string][]string
+	querySemgrep func(items map[string][]string) map[string][]models.SemgrepItem
+	queryFiles   func(fileURL []string) map[string]string
+}
+
+// RuleIndexSummary reports the content of a rebuilt rule index.
+type RuleIndexSummary struct {
+	URLs  int // Component versions with at least one finding
+	Rules int // Distinct rules with at least one finding
+	Hits  int // Rule, URL and file combinations indexed
+}
+
+// NewRuleIndex creates a new instance of the Rule Index Use Case.
+func NewRuleIndex(db *sqlx.DB) *RuleIndexUseCase {
+	return &RuleIndexUseCase{
+		allUrls:      models.NewAllURLModel(db, models.NewProjectModel(db)),
+		index:        models.NewRuleIndexModel(db),
+		queryPivot:   models.QueryBulkPivotLDB,
+		querySemgrep: models.QueryBulkSemgrepLDB,
+		queryFiles:   models.QueryBulkFilePairsLDB,
+	}
+}
+
+// Rebuild walks all the mined URLs in the KB, following their files (pivot table) to their findings (semgrep table),
+// and replaces the rule index with the result. The previous index is kept if the rebuild fails.
+func (ri RuleIndexUseCase) Rebuild(ctx context.Context, s *zap.SugaredLogger, batchSize int) (RuleIndexSummary, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultRuleIndexBatchSize
+	}
+	writer, err := ri.index.Rebuild(ctx)
+	if err != nil {
+		return RuleIndexSummary{}, err
+	}
+	defer writer.Rollback()
+	summary := RuleIndexSummary{}
+	rules := make(map[string]bool)
+	after := ""
+	for {
+		page, err := ri.allUrls.ListMinedURLs(ctx, after, batchSize)
+		if err != nil {
+			return RuleIndexSummary{}, err
+		}
+		if len(page) == 0 {
+			break
+		}
+		urls := make(map[string]models.AllURL, len(page))
+		var hashes []string
+		for _, u := range page {
+			if _, found := urls[u.URLHash]; !found {
+				urls[u.URLHash] = u
+				hashes = append(hashes, u.URLHash)
+			}
+		}
+		files := ri.queryPivot(hashes)
+		issues := ri.querySemgrep(files)
+		var hits []models.RuleHit
+		var hitHashes []string
+		for _, hash := range hashes {
+			found := len(hits)
+			for _, file := range files[hash] {
+				hits = append(hits, ruleHits(urls[hash], file, issues[file])...)
+			}
+			if len(hits) > found {
+				hitHashes = append(hitHashes, hash)
+			}
+		}
+		if len(hits) > 0 {
+			versions, err := ri.allUrls.GetVersionsByHashes(ctx, hitHashes)
+			if err != nil {
+				return RuleIndexSummary{}, err
+			}
+			for i := range hits {
+				hits[i].Version = versions[hits[i].URLHash]
+				rules[hits[i].RuleID] = true
+			}
+			if err = writer.Add(ctx, hits); err != nil {
+				return RuleIndexSummary{}, err
+			}
+		}
+		summary.URLs += len(hitHashes)
+		summary.Hits += len(hits)
+		after = page[len(page)-1].URLHash
+		s.Debugf("Indexed %v URLs for the rule index (up to %v)", len(page), after)
+		if len(page) < batchSize {
+			break
+		}
+	}
+	if err = writer.Commit(); err != nil {
+		return RuleIndexSummary{}, err
+	}
+	summary.Rules = len(rules)
+	return summary, nil
+}
+
+// ruleHits groups the findings of a file of the given URL by rule.
+func ruleHits(u models.AllURL, file string, issues []models.SemgrepItem) []models.RuleHit {
+	var hits []models.RuleHit
+	byRule := make(map[string]int) // index of the rule in hits
+	for _, issue := range issues {
+		if i, found := byRule[issue.RuleID]; found {
+			hits[i].Findings++
+			continue
+		}
+		byRule[issue.RuleID] = len(hits)
+		hits = append(hits, models.RuleHit{RuleID: issue.RuleID, URLHash: u.URLHash, FileMD5: file, PurlType: u.PurlType,
+			PurlName: u.PurlName, Severity: issue.Severity, Findings: 1})
+	}
+	return hits
+}
+
+// Search returns a page of the component versions with findings of the requested rule, along with the files containing them.
+// The search covers the whole KB unless restricted to a list of components.
+func (ri RuleIndexUseCase) Search(ctx context.Context, s *zap.SugaredLogger, search dtos.RuleSearch) (dtos.RuleSearchOutput, error) {
+	if len(search.RuleID) == 0 {
+		return dtos.RuleSearchOutput{}, se.NewBadRequestError("Request validation failed: no rule ID supplied", nil)
+	}
+	limit := search.Limit
+	switch {
+	case limit < 0:
+		return dtos.RuleSearchOutput{}, se.NewBadRequestError(fmt.Sprintf("Request validation failed: invalid limit %d", limit), nil)
+	case limit == 0:
+		limit = DefaultRuleSearchLimit
+	case limit > MaxRuleSearchLimit:
+		limit = MaxRuleSearchLimit
+	}
+	scope, err := ruleSearchScope(search.Components)
+	if err != nil {
+		return dtos.RuleSearchOutput{}, err
+	}
+	hits, err := ri.index.Search(ctx, search.RuleID, scope, search.Cursor, limit)
+	if err != nil {
+		return dtos.RuleSearchOutput{}, se.NewInternalError("Problem searching the rule index", err)
+	}
+	pairs := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		pairs = append(pairs, fmt.Sprintf("%s-%s", hit.FileMD5, hit.URLHash))
+	}
+	paths := ri.queryFiles(pairs) // keyed by pair, as the same file is usually found in several versions
+	output := dtos.RuleSearchOutput{RuleID: search.RuleID, Components: []dtos.RuleComponent{}}
+	for _, hit := range hits {
+		last := len(output.Components) - 1
+		if last < 0 || output.Components[last].URLHash != hit.URLHash {
+			output.Components = append(output.Components, dtos.RuleComponent{Purl: fmt.Sprintf("pkg:%v/%v", hit.PurlType, hit.PurlName),
+				Version: hit.Version, URLHash: hit.URLHash})
+			last++
+		}
+		output.Components[last].Files = append(output.Components[last].Files,
+			dtos.RuleFile{FileMD5: hit.FileMD5, Path: paths[fmt.Sprintf("%s-%s", hit.FileMD5, hit.URLHash)], Severity: hit.Severity, Findings: hit.Findings})
+	}
+	if len(output.Components) == limit {
+		output.NextCursor = output.Components[limit-1].URLHash
+	}
+	s.Debugf("Found %v component versions with findings of %v", len(output.Components), search.RuleID)
+	return output, nil
+}
+
+// ruleSearchScope converts the components a rule search is restricted to into index scopes.
+// A version in the purl, or an exact version requirement, restricts the scope to that version.
+func ruleSearchScope(components []dtos.ComponentDTO) ([]models.RuleIndexScope, error) {
+	scope := make([]models.RuleIndexScope, 0, len(components))
+	for _, c := range components {
+		purl, err := purlHelper.PurlFromString(c.Purl)
+		if err != nil {
+			return nil, se.NewBadRequestError(fmt.Sprintf("Request validation failed: invalid purl '%v'", c.Purl), err)
+		}
+		purlName, err := purlHelper.PurlNameFromString(c.Purl)
+		if err != nil {
+			return nil, se.NewBadRequestError(fmt.Sprintf("Request validation failed: invalid purl '%v'", c.Purl), err)
+		}
+		version := purl.Version
+		if len(version) == 0 && len(c.Requirement) > 0 {
+			version = purlHelper.GetVersionFromReq(c.Requirement)
+		}
+		scope = append(scope, models.RuleIndexScope{PurlType: purl.Type, PurlName: purlName, Version: version})
+	}
+	return scope, nil
+}
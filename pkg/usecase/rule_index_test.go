@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+	"scanoss.com/semgrep/pkg/models"
+)
+
+func TestRuleIndex(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	// File based, as the rebuild reads the KB tables while its transaction holds another connection
+	db, err := sqlx.Connect("sqlite3", filepath.Join(t.TempDir(), "kb.db"))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	db.MustExec("CREATE TABLE mines (id INTEGER, purl_type TEXT);" +
+		"INSERT INTO mines VALUES (1, 'npm'), (2, 'pypi');" +
+		"CREATE TABLE versions (id INTEGER, version_name TEXT);" +
+		"INSERT INTO versions VALUES (1, '4.17.20'), (2, '4.17.21'), (3, '2.31.0');" +
+		"CREATE TABLE all_urls (package_hash TEXT, purl_name TEXT, mine_id INTEGER, version_id INTEGER, is_mined BOOLEAN);" +
+		"INSERT INTO all_urls VALUES ('u1', 'lodash', 1, 1, true), ('u2', 'lodash', 1, 2, true), ('u3', 'requests', 2, 3, true);")
+	uc := NewRuleIndex(db)
+	if err = uc.index.CreateTable(ctx); err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+	files := map[string][]string{"u1": {"f1", "f2"}, "u2": {"f1"}, "u3": {"f3"}}
+	uc.queryPivot = func(keys []string) map[string][]string {
+		found := make(map[string][]string)
+		for _, k := range keys {
+			if len(files[k]) > 0 {
+				found[k] = files[k]
+			}
+		}
+		return found
+	}
+	uc.querySemgrep = func(_ map[string][]string) map[string][]models.SemgrepItem {
+		return map[string][]models.SemgrepItem{
+			"f1": {{RuleID: "js.eval", Severity: "ERROR"}, {RuleID: "js.eval", Severity: "ERROR"}},
+			"f2": {{RuleID: "js.proto", Severity: "WARNING"}},
+			"f3": {{RuleID: "py.exec", Severity: "ERROR"}},
+		}
+	}
+	uc.queryFiles = func(_ []string) map[string]string {
+		return map[string]string{"f1-u1": "lodash-4.17.20/lodash.js", "f1-u2": "package/lodash.js"}
+	}
+	summary, err := uc.Rebuild(ctx, zlog.S, 2)
+	if err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+	if summary != (RuleIndexSummary{URLs: 3, Rules: 3, Hits: 4}) {
+		t.Errorf("Rebuild() = %+v", summary)
+	}
+	tests := []struct {
+		name    string
+		search  dtos.RuleSearch
+		want    dtos.RuleSearchOutput
+		wantErr bool
+	}{
+		{
+			name:   "first page",
+			search: dtos.RuleSearch{RuleID: "js.eval", Limit: 1},
+			want: dtos.RuleSearchOutput{RuleID: "js.eval", NextCursor: "u1", Components: []dtos.RuleComponent{
+				{Purl: "pkg:npm/lodash", Version: "4.17.20", URLHash: "u1", Files: []dtos.RuleFile{{FileMD5: "f1", Path: "lodash-4.17.20/lodash.js", Severity: "ERROR", Findings: 2}}},
+			}},
+		},
+		{
+			name:   "last page",
+			search: dtos.RuleSearch{RuleID: "js.eval", Cursor: "u1", Limit: 1},
+			want: dtos.RuleSearchOutput{RuleID: "js.eval", NextCursor: "u2", Components: []dtos.RuleComponent{
+				{Purl: "pkg:npm/lodash", Version: "4.17.21", URLHash: "u2", Files: []dtos.RuleFile{{FileMD5: "f1", Path: "package/lodash.js", Severity: "ERROR", Findings: 2}}},
+			}},
+		},
+		{
+			name:   "scoped to a version",
+			search: dtos.RuleSearch{RuleID: "js.eval", Components: []dtos.ComponentDTO{{Purl: "pkg:npm/lodash", Requirement: "4.17.21"}, {Purl: "pkg:pypi/requests"}}},
+			want: dtos.RuleSearchOutput{RuleID: "js.eval", Components: []dtos.RuleComponent{
+				{Purl: "pkg:npm/lodash", Version: "4.17.21", URLHash: "u2", Files: []dtos.RuleFile{{FileMD5: "f1", Path: "package/lodash.js", Severity: "ERROR", Findings: 2}}},
+			}},
+		},
+		{
+			name:   "no hits",
+			search: dtos.RuleSearch{RuleID: "js.eval", Components: []dtos.ComponentDTO{{Purl: "pkg:npm/lodash@1.0.0"}}},
+			want:   dtos.RuleSearchOutput{RuleID: "js.eval", Components: []dtos.RuleComponent{}},
+		},
+		{name: "no rule", search: dtos.RuleSearch{}, wantErr: true},
+		{name: "invalid purl", search: dtos.RuleSearch{RuleID: "js.eval", Components: []dtos.ComponentDTO{{Purl: "lodash"}}}, wantErr: true},
+		{name: "invalid limit", search: dtos.RuleSearch{RuleID: "js.eval", Limit: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.Search(ctx, zlog.S, tt.search)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Search() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var serviceErr *se.ServiceError
+			if tt.wantErr && !errors.As(err, &serviceErr) {
+				t.Errorf("Search() error = %v, want a service error", err)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
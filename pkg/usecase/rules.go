@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+	"scanoss.com/semgrep/pkg/dtos"
+	"scanoss.com/semgrep/pkg/models"
+	"scanoss.com/semgrep/pkg/rules"
+)
+
+// RulesUseCase maintains the rule metadata catalog.
+type RulesUseCase struct {
+	rules *models.RuleModel
+}
+
+// NewRules creates a new instance of the Rules Use Case.
+func NewRules(db *sqlx.DB) *RulesUseCase {
+	return &RulesUseCase{rules: models.NewRuleModel(db)}
+}
+
+// Import adds the supplied rules to the catalog, replacing any existing entries with the same rule ID.
+// It returns the number of distinct rules imported (the last definition of a duplicated rule ID wins).
+func (d RulesUseCase) Import(ctx context.Context, s *zap.SugaredLogger, catalog []rules.Rule) (int, error) {
+	now := time.Now().UTC()
+	index := make(map[string]int, len(catalog))
+	var rows []models.Rule
+	for _, r := range catalog {
+		row := models.Rule{
+			RuleID:     r.ID,
+			Title:      r.Title,
+			Message:    r.Message,
+			CWE:        jsonList(r.CWE),
+			OWASP:      jsonList(r.OWASP),
+			Confidence: r.Confidence,
+			Likelihood: r.Likelihood,
+			Impact:     r.Impact,
+			References: jsonList(r.References),
+			UpdatedAt:  now,
+		}
+		if i, found := index[r.ID]; found {
+			s.Warnf("Rule %v is defined more than once, using the last definition", r.ID)
+			rows[i] = row
+			continue
+		}
+		index[r.ID] = len(rows)
+		rows = append(rows, row)
+	}
+	if err := d.rules.Upsert(ctx, rows); err != nil {
+		return 0, err
+	}
+	return len(rows), nil
+}
+
+// annotateRules attaches the catalog metadata of every rule reported in the output (updating it in place).
+func annotateRules(ctx context.Context, s *zap.SugaredLogger, model *models.RuleModel, output dtos.SemgrepOutput) {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, item := range output.Purls {
+		for _, file := range item.Files {
+			for _, issue := range file.Issues {
+				if !seen[issue.RuleID] {
+					seen[issue.RuleID] = true
+					ids = append(ids, issue.RuleID)
+				}
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	catalog, err := model.GetByIDs(ctx, ids)
+	if err != nil {
+		s.Warnf("Failed to load rule metadata, results will not be annotated: %v", err)
+		return
+	}
+	metadata := make(map[string]*dtos.RuleMetadata, len(catalog))
+	for id, r := range catalog {
+		metadata[id] = &dtos.RuleMetadata{
+			Title:      r.Title,
+			Message:    r.Message,
+			CWE:        parseJSONList(s, r.CWE),
+			OWASP:      parseJSONList(s, r.OWASP),
+			Confidence: r.Confidence,
+			Likelihood: r.Likelihood,
+			Impact:     r.Impact,
+			References: parseJSONList(s, r.References),
+		}
+	}
+	for _, item := range output.Purls {
+		for _, file := range item.Files {
+			for i := range file.Issues {
+				file.Issues[i].Rule = metadata[file.Issues[i].RuleID]
+			}
+		}
+	}
+}
+
+// jsonList encodes a list of strings as a JSON array (as stored in the rule catalog).
+func jsonList(values []string) string {
+	if len(values) == 0 {
+		return "[]"
+	}
+	data, _ := json.Marshal(values)
+	return string(data)
+}
+
+// parseJSONList decodes a JSON array of strings from the rule catalog (returning nil if it's empty or invalid).
+func parseJSONList(s *zap.SugaredLogger, value string) []string {
+	var values []string
+	if err := json.Unmarshal([]byte(value), &values); err != nil {
+		s.Warnf("Ignoring invalid rule catalog list %q: %v", value, err)
+		return nil
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
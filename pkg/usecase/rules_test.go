@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+	"scanoss.com/semgrep/pkg/models"
+	"scanoss.com/semgrep/pkg/rules"
+)
+
+func TestRulesUseCase(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	model := models.NewRuleModel(db)
+	if err = model.CreateTable(ctx); err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+	injection := dtos.RuleMetadata{
+		Title:      "Dangerous system call",
+		Message:    "Found user-controlled data used in a system call.",
+		CWE:        []string{"CWE-78: Improper Neutralization of Special Elements used in an OS Command ('OS Command Injection')"},
+		OWASP:      []string{"A03:2021 - Injection"},
+		Confidence: "MEDIUM",
+		Likelihood: "LOW",
+		Impact:     "HIGH",
+		References: []string{"https://owasp.org/Top10/A03_2021-Injection"},
+	}
+	imported, err := NewRules(db).Import(ctx, zlog.S, []rules.Rule{
+		{ID: "python.exec", RuleMetadata: dtos.RuleMetadata{Title: "Old title"}},
+		{ID: "python.style", RuleMetadata: dtos.RuleMetadata{Title: "Style"}},
+		{ID: "python.exec", RuleMetadata: injection},
+	})
+	if err != nil || imported != 2 {
+		t.Fatalf("Import() = %v, %v", imported, err)
+	}
+	output := dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{{Purl: "pkg:pypi/app", Files: []dtos.SemgrepFileIssues{
+		{File: "abc123", Issues: []dtos.IssueItem{{RuleID: "python.exec"}, {RuleID: "python.unknown"}}},
+		{File: "def456", Issues: []dtos.IssueItem{{RuleID: "python.style"}, {RuleID: "python.exec"}}},
+	}}}}
+	annotateRules(ctx, zlog.S, model, output)
+	files := output.Purls[0].Files
+	if !reflect.DeepEqual(files[0].Issues[0].Rule, &injection) || files[0].Issues[1].Rule != nil {
+		t.Errorf("annotateRules() first file = %+v", files[0].Issues)
+	}
+	if style := files[1].Issues[0].Rule; style == nil || style.Title != "Style" || style.CWE != nil || files[1].Issues[1].Rule != files[0].Issues[0].Rule {
+		t.Errorf("annotateRules() second file = %+v", files[1].Issues)
+	}
+}
@@ -24,13 +24,31 @@ import (
 	"github.com/jmoiron/sqlx"
 	purlHelper "github.com/scanoss/go-purl-helper/pkg"
 	"go.uber.org/zap"
+	myconfig "scanoss.com/semgrep/pkg/config"
 	"scanoss.com/semgrep/pkg/dtos"
+	"scanoss.com/semgrep/pkg/inputs"
 	"scanoss.com/semgrep/pkg/models"
 	"scanoss.com/semgrep/pkg/utils"
 )
 
 type SemgrepUseCase struct {
 	allUrls *models.AllUrlsModel
+	stores  SemgrepStores
+}
+
+// SemgrepStores holds the optional stores used by the Semgrep lookups. A nil store disables the matching feature.
+type SemgrepStores struct {
+	Triage *models.TriageModel     // Central triage decisions (annotates findings)
+	Peers  *models.PeerStatsModel  // Ecosystem finding density statistics (ranks components)
+	Rules  *models.RuleModel       // Rule metadata catalog (annotates findings)
+	Local  *models.LocalStoreModel // Locally imported Semgrep results (answers for private components)
+	KB     KBProvenance            // KB snapshot and component scan details (annotates the output)
+}
+
+// KBProvenance supplies the KB snapshot details and the scan details of individual component versions (URLs).
+type KBProvenance interface {
+	Info(ctx context.Context) (*models.KBInfo, error)
+	ComponentScans(ctx context.Context, urlHashes []string) (map[string]models.ComponentScan, error)
 }
 type SemgrepWorkerStruct struct {
 	URLMd5  string
@@ -45,14 +63,49 @@ type InternalQuery struct {
 	SelectedURLS    []models.AllURL
 }
 
-func NewSemgrep(db *sqlx.DB) *SemgrepUseCase {
+// NewSemgrep creates a new instance of the Semgrep Use Case, using the supplied optional stores.
+func NewSemgrep(db *sqlx.DB, stores SemgrepStores) *SemgrepUseCase {
 	return &SemgrepUseCase{
 		allUrls: models.NewAllURLModel(db, models.NewProjectModel(db)),
+		stores:  stores,
 	}
 }
 
+// StoresFromConfig returns the optional stores enabled in the server configuration.
+func StoresFromConfig(db *sqlx.DB, config *myconfig.ServerConfig) SemgrepStores {
+	stores := SemgrepStores{}
+	if config == nil {
+		return stores
+	}
+	if config.Triage.Enabled {
+		stores.Triage = models.NewTriageModel(db)
+	}
+	if config.PeerStats.Enabled {
+		stores.Peers = models.NewPeerStatsModel(db)
+	}
+	if config.RuleCatalog.Enabled {
+		stores.Rules = models.NewRuleModel(db)
+	}
+	if config.LocalStore.Enabled {
+		stores.Local = models.NewLocalStoreModel(db)
+	}
+	if config.KB.InfoDB {
+		stores.KB = models.NewKBInfoModel(db)
+	} else if len(config.KB.ManifestFile) > 0 {
+		stores.KB = models.NewKBManifestFile(config.KB.ManifestFile)
+	}
+	return stores
+}
+
 // GetIssues takes the Semgrep Input request, searches for Semgrep usages and returns a SemgrepOutput struct.
+// Findings are annotated with any triage decisions that apply (globally or to the project in the context).
 func (d SemgrepUseCase) GetIssues(ctx context.Context, s *zap.SugaredLogger, components []dtos.ComponentDTO) (dtos.SemgrepOutput, error) {
+	return d.GetIssuesInInventory(ctx, s, components, nil)
+}
+
+// GetIssuesInInventory is GetIssues restricted to the component files present in the given inventory (no restriction if nil).
+// Each found component also reports how much of it is present in the inventory.
+func (d SemgrepUseCase) GetIssuesInInventory(ctx context.Context, s *zap.SugaredLogger, components []dtos.ComponentDTO, inventory Inventory) (dtos.SemgrepOutput, error) {
 	query := []InternalQuery{}
 	purlsToQuery := []utils.PurlReq{}
 	// Prepare purls to query
@@ -74,6 +127,9 @@ func (d SemgrepUseCase) GetIssues(ctx context.Context, s *zap.SugaredLogger, com
 
 	url, err := d.allUrls.GetUrlsByPurlList(ctx, s, purlsToQuery)
 	_ = err
+	if d.stores.Local != nil {
+		url = append(url, localURLs(ctx, s, d.stores.Local, purlsToQuery)...)
+	}
 
 	purlMap := make(map[string][]models.AllURL)
 
@@ -94,13 +150,26 @@ func (d SemgrepUseCase) GetIssues(ctx context.Context, s *zap.SugaredLogger, com
 	}
 	// Create a map containing the files for each url
 	files := models.QueryBulkPivotLDB(urlHashes)
+	var localPaths map[string]string // paths of the files imported into the local store
+	if d.stores.Local != nil {
+		localPaths = mergeLocalFiles(ctx, s, d.stores.Local, urlHashes, files)
+	}
 
 	filesURL := []string{}
 
 	// Create a map containing the Semgrep issue for each file
 	semgrep := models.QueryBulkSemgrepLDB(files)
+	if d.stores.Local != nil {
+		localMD5s := make([]string, 0, len(localPaths))
+		for md5 := range localPaths {
+			localMD5s = append(localMD5s, md5)
+		}
+		mergeLocalIssues(ctx, s, d.stores.Local, localMD5s, semgrep)
+	}
 
 	retV := dtos.SemgrepOutput{}
+	purlTypes := make([]string, 0, len(query))  // purl type of each output component (for peer ranking)
+	urlsUsed := make([][]string, 0, len(query)) // URL hashes selected for each output component (for provenance)
 
 	// Create the response
 	for r := range query {
@@ -110,10 +179,16 @@ func (d SemgrepUseCase) GetIssues(ctx context.Context, s *zap.SugaredLogger, com
 		relatedURLs := query[r].SelectedURLS
 		semgrepOutItem.Version = query[r].SelectedVersion
 		semgrepOutItem.Purl = query[r].CompletePurl
+		urlFiles := make([][]string, 0, len(relatedURLs))
 		for u := range relatedURLs {
 			hash := relatedURLs[u].URLHash
 			filesInURL := files[hash]
+			urlFiles = append(urlFiles, filesInURL)
 			for f := range filesInURL {
+				if inventory != nil && !inventory[filesInURL[f]] {
+					continue // not present in the codebase
+				}
+				semgrepOutItem.AnalysedFiles++ // only count the files its findings could come from, so risk & density aren't diluted
 				if len(semgrep[filesInURL[f]]) > 0 {
 					fileIssues := dtos.SemgrepFileIssues{File: filesInURL[f]}
 					filesURL = append(filesURL, fmt.Sprintf("%s-%s", filesInURL[f], hash))
@@ -129,10 +204,63 @@ func (d SemgrepUseCase) GetIssues(ctx context.Context, s *zap.SugaredLogger, com
 		for f := range semgrepOutItem.Files {
 			key := semgrepOutItem.Files[f].File
 			semgrepOutItem.Files[f].Path = paths[key]
-			fmt.Println(semgrepOutItem.Files[f].Path)
+			if len(semgrepOutItem.Files[f].Path) == 0 {
+				semgrepOutItem.Files[f].Path = localPaths[key]
+			}
+			s.Debugf("File %v path: %v", key, semgrepOutItem.Files[f].Path)
+		}
+		if inventory != nil && len(relatedURLs) > 0 {
+			semgrepOutItem.Presence = inventory.presence(urlFiles)
 		}
 		retV.Purls = append(retV.Purls, semgrepOutItem)
+		purlTypes = append(purlTypes, purlType(query[r]))
+		hashes := make([]string, 0, len(relatedURLs))
+		for u := range relatedURLs {
+			hashes = append(hashes, relatedURLs[u].URLHash)
+		}
+		urlsUsed = append(urlsUsed, hashes)
+	}
+	dtos.AssignFingerprints(retV)
+	if d.stores.Triage != nil {
+		if err = annotateTriage(ctx, s, d.stores.Triage, ProjectFromContext(ctx), retV); err != nil {
+			return dtos.SemgrepOutput{}, err
+		}
+	}
+	if d.stores.Peers != nil {
+		annotatePeers(ctx, s, d.stores.Peers, retV, purlTypes)
+	}
+	if d.stores.Rules != nil {
+		annotateRules(ctx, s, d.stores.Rules, retV)
+	}
+	if d.stores.KB != nil || d.stores.Local != nil {
+		retV.KB = annotateProvenance(ctx, s, d.stores.KB, d.stores.Local, retV, urlsUsed)
 	}
-
 	return retV, nil
 }
+
+// purlType returns the purl type of the queried component, preferring the type recorded against its selected URLs.
+func purlType(q InternalQuery) string {
+	if len(q.SelectedURLS) > 0 && len(q.SelectedURLS[0].PurlType) > 0 {
+		return q.SelectedURLS[0].PurlType
+	}
+	if t, _, found := strings.Cut(strings.TrimPrefix(q.PurlName, "pkg:"), "/"); found {
+		return t
+	}
+	return ""
+}
+
+// AnnotateScanossResults looks up the Semgrep issues of every component matched in a SCANOSS scan result
+// and annotates each scanned file that matched a component with that component's findings.
+func (d SemgrepUseCase) AnnotateScanossResults(ctx context.Context, s *zap.SugaredLogger, results inputs.ScanossResults) (inputs.ScanossResults, error) {
+	components := results.Components()
+	if len(components) == 0 {
+		s.Infof("No matched components found in SCANOSS scan results")
+		return results, nil
+	}
+	s.Debugf("Looking up issues for %v components from SCANOSS scan results", len(components))
+	output, err := d.GetIssues(ctx, s, components)
+	if err != nil {
+		return nil, err
+	}
+	return results.Annotate(output), nil
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+)
+
+// DefaultStreamBatchSize is the number of components resolved at a time when streaming their issues.
+const DefaultStreamBatchSize = 100
+
+// IssueStream receives the results of a components issues stream.
+type IssueStream interface {
+	// Component is called with each requested component, as soon as its issues have been resolved.
+	// The item is complete: any field mask is left to the stream (i.e. after scoring the component).
+	Component(component dtos.ComponentDTO, item dtos.SemgrepOutputItem) error
+	// Progress is called after every batch of resolved components.
+	Progress(progress dtos.StreamProgress) error
+}
+
+// batchLookup looks up the issues of a batch of components, returning them in request order.
+type batchLookup func(ctx context.Context, batch []dtos.ComponentDTO) (dtos.SemgrepOutput, error)
+
+// StreamIssues looks up the issues of the given components in batches of batchSize (DefaultStreamBatchSize if not positive),
+// sending each component and the progress (along with the KB snapshot) to the stream as they are resolved.
+// Components without a purl are skipped. The lookup stops at the first stream error or when the context is cancelled.
+func (d SemgrepUseCase) StreamIssues(ctx context.Context, s *zap.SugaredLogger, components []dtos.ComponentDTO, batchSize int, stream IssueStream) error {
+	return streamBatches(ctx, components, batchSize, stream, func(ctx context.Context, batch []dtos.ComponentDTO) (dtos.SemgrepOutput, error) {
+		return d.GetIssues(ctx, s, batch)
+	})
+}
+
+// streamBatches resolves the components a batch at a time using the lookup function, sending the results to the stream.
+func streamBatches(ctx context.Context, components []dtos.ComponentDTO, batchSize int, stream IssueStream, lookup batchLookup) error {
+	valid := make([]dtos.ComponentDTO, 0, len(components))
+	for _, c := range components {
+		if len(strings.Split(c.Purl, "@")[0]) > 0 {
+			valid = append(valid, c)
+		}
+	}
+	if len(valid) == 0 {
+		return se.NewBadRequestError("Request validation failed: no components supplied", nil)
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultStreamBatchSize
+	}
+	progress := dtos.StreamProgress{Total: len(valid)}
+	for start := 0; start < len(valid); start += batchSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		batch := valid[start:min(start+batchSize, len(valid))]
+		output, err := lookup(ctx, batch)
+		if err != nil {
+			return err
+		}
+		if len(output.Purls) != len(batch) {
+			return se.NewInternalError("Problem resolving component batch", nil)
+		}
+		for i := range output.Purls {
+			if err = stream.Component(batch[i], output.Purls[i]); err != nil {
+				return err
+			}
+		}
+		progress.Resolved += len(batch)
+		progress.KB = output.KB
+		if err = stream.Progress(progress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
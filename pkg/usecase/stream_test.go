@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"scanoss.com/semgrep/pkg/dtos"
+)
+
+// recordingStream records the messages sent to a components issues stream, failing after failAfter components (if set).
+type recordingStream struct {
+	messages  []string
+	failAfter int
+	sent      int
+}
+
+func (r *recordingStream) Component(component dtos.ComponentDTO, item dtos.SemgrepOutputItem) error {
+	if r.failAfter > 0 && r.sent >= r.failAfter {
+		return errors.New("stream closed")
+	}
+	r.sent++
+	path := ""
+	if len(item.Files) > 0 {
+		path = item.Files[0].Path
+	}
+	r.messages = append(r.messages, fmt.Sprintf("%v@%v:%v", component.Purl, component.Requirement, path))
+	return nil
+}
+
+func (r *recordingStream) Progress(progress dtos.StreamProgress) error {
+	message := fmt.Sprintf("%d/%d", progress.Resolved, progress.Total)
+	if progress.KB != nil {
+		message += " kb " + progress.KB.Version
+	}
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func TestStreamBatches(t *testing.T) {
+	lookups := 0
+	lookup := func(_ context.Context, batch []dtos.ComponentDTO) (dtos.SemgrepOutput, error) {
+		lookups++
+		output := dtos.SemgrepOutput{}
+		if len(batch) > 2 {
+			output.KB = &dtos.KBInfo{Version: "2025.10"}
+		}
+		for _, c := range batch {
+			output.Purls = append(output.Purls, dtos.SemgrepOutputItem{Purl: c.Purl, Files: []dtos.SemgrepFileIssues{{File: "f1", Path: "src/index.js"}}})
+		}
+		return output, nil
+	}
+	components := []dtos.ComponentDTO{{Purl: "pkg:npm/a", Requirement: "1.0"}, {Purl: ""}, {Purl: "pkg:npm/b"}, {Purl: "pkg:npm/c"}}
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		components  []dtos.ComponentDTO
+		batchSize   int
+		failAfter   int
+		want        []string
+		wantLookups int
+		wantErr     bool
+	}{
+		{name: "batches", components: components, batchSize: 2, wantLookups: 2,
+			want: []string{"pkg:npm/a@1.0:src/index.js", "pkg:npm/b@:src/index.js", "2/3", "pkg:npm/c@:src/index.js", "3/3"}},
+		{name: "default batch size", components: components, wantLookups: 1,
+			want: []string{"pkg:npm/a@1.0:src/index.js", "pkg:npm/b@:src/index.js", "pkg:npm/c@:src/index.js", "3/3 kb 2025.10"}},
+		{name: "stream error", components: components, batchSize: 1, failAfter: 1, wantLookups: 2, wantErr: true,
+			want: []string{"pkg:npm/a@1.0:src/index.js", "1/3"}},
+		{name: "no components", components: []dtos.ComponentDTO{{Purl: "@1.0"}}, wantErr: true},
+		{name: "cancelled", ctx: cancelledContext(), components: components, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookups = 0
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			stream := &recordingStream{failAfter: tt.failAfter}
+			err := streamBatches(ctx, tt.components, tt.batchSize, stream, lookup)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("streamBatches() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(stream.messages, tt.want) || lookups != tt.wantLookups {
+				t.Errorf("streamBatches() messages = %v (%d lookups), want %v (%d lookups)", stream.messages, lookups, tt.want, tt.wantLookups)
+			}
+		})
+	}
+}
+
+// cancelledContext returns a context that has already been cancelled.
+func cancelledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
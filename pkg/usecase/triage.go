@@ -0,0 +1,274 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+	"scanoss.com/semgrep/pkg/models"
+)
+
+// projectKey is the context key holding the project a request is scoped to.
+type projectKey struct{}
+
+// ContextWithProject returns a context scoping the request to the given project (used to select triage decisions).
+func ContextWithProject(ctx context.Context, project string) context.Context {
+	return context.WithValue(ctx, projectKey{}, strings.TrimSpace(project))
+}
+
+// ProjectFromContext returns the project the request is scoped to (empty if none).
+func ProjectFromContext(ctx context.Context) string {
+	project, _ := ctx.Value(projectKey{}).(string)
+	return project
+}
+
+// TriageUseCase handles the central triage decisions on Semgrep findings.
+type TriageUseCase struct {
+	triage *models.TriageModel
+}
+
+// NewTriage creates a new instance of the Triage Use Case.
+func NewTriage(db *sqlx.DB) *TriageUseCase {
+	return &TriageUseCase{triage: models.NewTriageModel(db)}
+}
+
+// Create validates and records a new triage decision.
+func (d TriageUseCase) Create(ctx context.Context, s *zap.SugaredLogger, decision dtos.TriageDecision) (dtos.TriageDecision, error) {
+	if err := validateTriage(decision, true); err != nil {
+		return dtos.TriageDecision{}, err
+	}
+	now := time.Now().UTC()
+	t := models.Triage{
+		ID:        uuid.NewString(),
+		Project:   strings.TrimSpace(decision.Project),
+		FileMD5:   strings.ToLower(strings.TrimSpace(decision.FileMD5)),
+		RuleID:    strings.TrimSpace(decision.RuleID),
+		From:      strings.TrimSpace(decision.From),
+		To:        strings.TrimSpace(decision.To),
+		State:     decision.State,
+		Author:    strings.TrimSpace(decision.Author),
+		Comment:   decision.Comment,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := d.triage.Create(ctx, t); err != nil {
+		if errors.Is(err, models.ErrTriageExists) {
+			return dtos.TriageDecision{}, se.NewBadRequestError("A triage decision already exists for this finding and project. Update it instead", err)
+		}
+		return dtos.TriageDecision{}, se.NewInternalError("Problem recording triage decision", err)
+	}
+	s.Infof("Recorded triage decision %v (%v) for %v %v by %v", t.ID, t.State, t.FileMD5, t.RuleID, t.Author)
+	return triageToDTO(t), nil
+}
+
+// Get retrieves a triage decision by ID.
+func (d TriageUseCase) Get(ctx context.Context, _ *zap.SugaredLogger, id string) (dtos.TriageDecision, error) {
+	t, err := d.triage.Get(ctx, strings.TrimSpace(id))
+	if err != nil {
+		return dtos.TriageDecision{}, triageError(id, err)
+	}
+	return triageToDTO(t), nil
+}
+
+// List retrieves the triage decisions matching the filter.
+func (d TriageUseCase) List(ctx context.Context, _ *zap.SugaredLogger, filter dtos.TriageFilter) ([]dtos.TriageDecision, error) {
+	if len(filter.State) > 0 && !dtos.IsTriageState(filter.State) {
+		return nil, se.NewBadRequestError(fmt.Sprintf("Invalid triage state '%v'. Supported states: %v", filter.State, strings.Join(dtos.TriageStates, ", ")), nil)
+	}
+	decisions, err := d.triage.List(ctx, models.TriageQuery{
+		Project:    strings.TrimSpace(filter.Project),
+		GlobalOnly: filter.GlobalOnly,
+		FileMD5:    strings.ToLower(strings.TrimSpace(filter.FileMD5)),
+		RuleID:     strings.TrimSpace(filter.RuleID),
+		State:      filter.State,
+	})
+	if err != nil {
+		return nil, se.NewInternalError("Problem listing triage decisions", err)
+	}
+	result := make([]dtos.TriageDecision, 0, len(decisions))
+	for _, t := range decisions {
+		result = append(result, triageToDTO(t))
+	}
+	return result, nil
+}
+
+// Update changes the state, author and comment of an existing triage decision.
+func (d TriageUseCase) Update(ctx context.Context, s *zap.SugaredLogger, decision dtos.TriageDecision) (dtos.TriageDecision, error) {
+	if len(strings.TrimSpace(decision.ID)) == 0 {
+		return dtos.TriageDecision{}, se.NewBadRequestError("Request validation failed: a triage decision ID is required", nil)
+	}
+	if err := validateTriage(decision, false); err != nil {
+		return dtos.TriageDecision{}, err
+	}
+	t := models.Triage{
+		ID:        strings.TrimSpace(decision.ID),
+		State:     decision.State,
+		Author:    strings.TrimSpace(decision.Author),
+		Comment:   decision.Comment,
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := d.triage.Update(ctx, t); err != nil {
+		return dtos.TriageDecision{}, triageError(t.ID, err)
+	}
+	s.Infof("Updated triage decision %v (%v) by %v", t.ID, t.State, t.Author)
+	return d.Get(ctx, s, t.ID)
+}
+
+// Delete removes a triage decision, returning the deleted decision.
+func (d TriageUseCase) Delete(ctx context.Context, s *zap.SugaredLogger, id string) (dtos.TriageDecision, error) {
+	decision, err := d.Get(ctx, s, id)
+	if err != nil {
+		return dtos.TriageDecision{}, err
+	}
+	if err = d.triage.Delete(ctx, decision.ID); err != nil {
+		return dtos.TriageDecision{}, triageError(decision.ID, err)
+	}
+	s.Infof("Deleted triage decision %v", decision.ID)
+	return decision, nil
+}
+
+// validateTriage checks the required fields of a triage decision (the finding details are only required on creation).
+func validateTriage(decision dtos.TriageDecision, create bool) error {
+	var missing []string
+	if create && len(strings.TrimSpace(decision.FileMD5)) == 0 {
+		missing = append(missing, "fileMd5")
+	}
+	if create && len(strings.TrimSpace(decision.RuleID)) == 0 {
+		missing = append(missing, "ruleId")
+	}
+	if len(strings.TrimSpace(decision.Author)) == 0 {
+		missing = append(missing, "author")
+	}
+	if len(missing) > 0 {
+		return se.NewBadRequestError(fmt.Sprintf("Request validation failed: missing %v", strings.Join(missing, ", ")), nil)
+	}
+	if !dtos.IsTriageState(decision.State) {
+		return se.NewBadRequestError(fmt.Sprintf("Request validation failed: invalid triage state '%v'. Supported states: %v",
+			decision.State, strings.Join(dtos.TriageStates, ", ")), nil)
+	}
+	if create && len(strings.TrimSpace(decision.From)) == 0 && len(strings.TrimSpace(decision.To)) > 0 {
+		return se.NewBadRequestError("Request validation failed: 'to' requires 'from'", nil)
+	}
+	return nil
+}
+
+// triageError converts a triage model error into a service error.
+func triageError(id string, err error) error {
+	if errors.Is(err, models.ErrTriageNotFound) {
+		return se.NewNotFoundError(fmt.Sprintf("Triage decision '%v' not found", id))
+	}
+	return se.NewInternalError("Problem accessing triage decisions", err)
+}
+
+// triageToDTO converts a triage table row into a triage decision DTO.
+func triageToDTO(t models.Triage) dtos.TriageDecision {
+	return dtos.TriageDecision{
+		ID:        t.ID,
+		Project:   t.Project,
+		FileMD5:   t.FileMD5,
+		RuleID:    t.RuleID,
+		From:      t.From,
+		To:        t.To,
+		State:     t.State,
+		Author:    t.Author,
+		Comment:   t.Comment,
+		CreatedAt: t.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: t.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// annotateTriage attaches the triage decisions that apply to each finding in the output (updating it in place).
+// Project decisions take precedence over global ones, and decisions on specific lines over whole file decisions.
+// Failing to load the decisions is an error, as the findings would otherwise be reported without their accepted state.
+func annotateTriage(ctx context.Context, s *zap.SugaredLogger, triage *models.TriageModel, project string, output dtos.SemgrepOutput) error {
+	var files []string
+	seen := make(map[string]bool)
+	for _, item := range output.Purls {
+		for _, file := range item.Files {
+			if !seen[file.File] {
+				seen[file.File] = true
+				files = append(files, file.File)
+			}
+		}
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	decisions, err := triage.GetByFiles(ctx, project, files)
+	if err != nil {
+		s.Errorf("Failed to load triage decisions for %v files: %v", len(files), err)
+		return se.NewInternalError("Problem loading triage decisions", err)
+	}
+	byFile := make(map[string][]models.Triage)
+	for _, t := range decisions {
+		byFile[t.FileMD5] = append(byFile[t.FileMD5], t)
+	}
+	for _, item := range output.Purls {
+		for _, file := range item.Files {
+			candidates := byFile[file.File]
+			if len(candidates) == 0 {
+				continue
+			}
+			for i := range file.Issues {
+				if t, ok := bestTriage(candidates, file.Issues[i]); ok {
+					file.Issues[i].Triage = &dtos.IssueTriage{
+						ID:        t.ID,
+						State:     t.State,
+						Project:   t.Project,
+						Author:    t.Author,
+						Comment:   t.Comment,
+						UpdatedAt: t.UpdatedAt.UTC().Format(time.RFC3339),
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// bestTriage picks the most specific triage decision matching an issue.
+func bestTriage(candidates []models.Triage, issue dtos.IssueItem) (models.Triage, bool) {
+	best, bestScore := models.Triage{}, -1
+	for _, t := range candidates {
+		if t.RuleID != issue.RuleID {
+			continue
+		}
+		score := 0
+		if len(t.From) > 0 {
+			if t.From != issue.From || (len(t.To) > 0 && t.To != issue.To) {
+				continue
+			}
+			score++
+		}
+		if len(t.Project) > 0 {
+			score += 2
+		}
+		if score > bestScore {
+			best, bestScore = t, score
+		}
+	}
+	return best, bestScore >= 0
+}
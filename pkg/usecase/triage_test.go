@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/semgrep/pkg/dtos"
+	se "scanoss.com/semgrep/pkg/errors"
+	"scanoss.com/semgrep/pkg/models"
+)
+
+func TestTriageUseCase(t *testing.T) {
+	ctx := context.Background()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	model := models.NewTriageModel(db)
+	if err = model.CreateTable(ctx); err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+	uc := NewTriage(db)
+	s := zlog.S
+
+	for _, bad := range []dtos.TriageDecision{
+		{RuleID: "rule.a", State: dtos.TriageConfirmed, Author: "alice"},
+		{FileMD5: "abc123", RuleID: "rule.a", State: "wontfix", Author: "alice"},
+		{FileMD5: "abc123", RuleID: "rule.a", State: dtos.TriageConfirmed},
+		{FileMD5: "abc123", RuleID: "rule.a", To: "5", State: dtos.TriageConfirmed, Author: "alice"},
+	} {
+		if _, err = uc.Create(ctx, s, bad); err == nil {
+			t.Errorf("Create(%+v) expected a validation error", bad)
+		}
+	}
+	global, err := uc.Create(ctx, s, dtos.TriageDecision{FileMD5: "ABC123", RuleID: "rule.a", State: dtos.TriageFalsePositive, Author: "alice"})
+	if err != nil || len(global.ID) == 0 || global.FileMD5 != "abc123" {
+		t.Fatalf("Create() = %+v, %v", global, err)
+	}
+	if _, err = uc.Create(ctx, s, dtos.TriageDecision{FileMD5: "abc123", RuleID: "rule.a", State: dtos.TriageConfirmed, Author: "bob"}); err == nil {
+		t.Errorf("Create() duplicate expected an error")
+	}
+	project, err := uc.Create(ctx, s, dtos.TriageDecision{Project: "web", FileMD5: "abc123", RuleID: "rule.a", From: "10", State: dtos.TriageConfirmed, Author: "bob"})
+	if err != nil {
+		t.Fatalf("Create() project error = %v", err)
+	}
+	updated, err := uc.Update(ctx, s, dtos.TriageDecision{ID: global.ID, State: dtos.TriageAcceptedRisk, Author: "carol", Comment: "not reachable"})
+	if err != nil || updated.State != dtos.TriageAcceptedRisk || updated.FileMD5 != "abc123" || updated.Comment != "not reachable" {
+		t.Errorf("Update() = %+v, %v", updated, err)
+	}
+	if _, err = uc.Get(ctx, s, "missing"); err == nil {
+		t.Errorf("Get() expected a not found error")
+	} else if serviceErr, ok := se.GetServiceError(err); !ok || serviceErr.GetHTTPCode() != 404 {
+		t.Errorf("Get() error = %v, want a not found error", err)
+	}
+	if list, err := uc.List(ctx, s, dtos.TriageFilter{Project: "web"}); err != nil || len(list) != 1 {
+		t.Errorf("List() = %+v, %v", list, err)
+	}
+
+	output := dtos.SemgrepOutput{Purls: []dtos.SemgrepOutputItem{{Purl: "pkg:npm/lodash", Files: []dtos.SemgrepFileIssues{{
+		File: "abc123",
+		Issues: []dtos.IssueItem{
+			{RuleID: "rule.a", From: "10", To: "12"},
+			{RuleID: "rule.a", From: "20", To: "22"},
+			{RuleID: "rule.b", From: "10", To: "12"},
+		},
+	}}}}}
+	if err = annotateTriage(ctx, s, model, "", output); err != nil {
+		t.Fatalf("annotateTriage() error = %v", err)
+	}
+	issues := output.Purls[0].Files[0].Issues
+	if issues[0].Triage == nil || issues[0].Triage.ID != global.ID || issues[1].Triage == nil || issues[2].Triage != nil {
+		t.Errorf("annotateTriage() global = %+v", issues)
+	}
+	if err = annotateTriage(ctx, s, model, "web", output); err != nil {
+		t.Fatalf("annotateTriage() error = %v", err)
+	}
+	if issues[0].Triage.ID != project.ID || issues[0].Triage.Project != "web" || issues[1].Triage.ID != global.ID {
+		t.Errorf("annotateTriage() project = %+v, %+v", issues[0].Triage, issues[1].Triage)
+	}
+	// A triage store that can't be read must fail the lookup, rather than silently dropping the decisions
+	if err = annotateTriage(ctx, s, models.NewTriageModel(sqlx.MustConnect("sqlite3", ":memory:")), "", output); err == nil {
+		t.Errorf("annotateTriage() expected an error without a triage table")
+	}
+
+	if deleted, err := uc.Delete(ctx, s, project.ID); err != nil || deleted.ID != project.ID {
+		t.Errorf("Delete() = %+v, %v", deleted, err)
+	}
+	if _, err = uc.Delete(ctx, s, project.ID); err == nil {
+		t.Errorf("Delete() expected a not found error")
+	}
+}
+
+func TestProjectContext(t *testing.T) {
+	if project := ProjectFromContext(context.Background()); project != "" {
+		t.Errorf("ProjectFromContext() = %v, want empty", project)
+	}
+	if project := ProjectFromContext(ContextWithProject(context.Background(), " web ")); project != "web" {
+		t.Errorf("ProjectFromContext() = %v, want web", project)
+	}
+}